@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/config"
+	"github.com/rainbow96bear/planet_user_server/internal/bootstrap"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+)
+
+// withDependencies: 서버를 띄우지 않고 DB / 서비스만 초기화하여 fn 을 실행합니다.
+func withDependencies(cfg *config.Config, fn func(ctx context.Context, deps *bootstrap.Dependencies) int) int {
+	db, err := bootstrap.InitDatabase(cfg)
+	if err != nil {
+		logger.Errorf("failed to initialize database: %v", err)
+		return 1
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	deps, err := bootstrap.InitDependencies(db, cfg)
+	if err != nil {
+		logger.Errorf("fail to init Dependencies %s", err.Error())
+		return 1
+	}
+	defer deps.OutboxRelay.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return fn(ctx, deps)
+}
+
+// printJSON: 결과를 사람이 읽기 쉬운 JSON 으로 출력합니다.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func runSeed(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	events := fs.Int("events", 5, "calendar events per new demo user")
+	force := fs.Bool("force", false, "allow seeding in prod mode")
+	fs.Parse(args)
+
+	if cfg.Mode == "prod" && !*force {
+		fmt.Fprintln(os.Stderr, "refusing to seed demo data in prod mode (use -force)")
+		return 2
+	}
+
+	return withDependencies(cfg, func(ctx context.Context, deps *bootstrap.Dependencies) int {
+		result, err := deps.Services.Admin.Seed(ctx, *events)
+		if err != nil {
+			logger.Errorf("seed failed: %v", err)
+			return 1
+		}
+		printJSON(os.Stdout, result)
+		return 0
+	})
+}
+
+func runRecountFollows(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("recount-follows", flag.ExitOnError)
+	fs.Parse(args)
+
+	return withDependencies(cfg, func(ctx context.Context, deps *bootstrap.Dependencies) int {
+		updated, err := deps.Services.Admin.RecountFollows(ctx)
+		if err != nil {
+			logger.Errorf("recount-follows failed: %v", err)
+			return 1
+		}
+		fmt.Printf("%d profile(s) corrected\n", updated)
+		return 0
+	})
+}
+
+func runExportUser(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("export-user", flag.ExitOnError)
+	output := fs.String("o", "", "output file (default: stdout)")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: export-user [-o file] <user-id>")
+		return 2
+	}
+	userID, err := uuid.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid user id: %s\n", fs.Arg(0))
+		return 2
+	}
+
+	return withDependencies(cfg, func(ctx context.Context, deps *bootstrap.Dependencies) int {
+		export, err := deps.Services.Admin.ExportUser(ctx, userID)
+		if err != nil {
+			logger.Errorf("export-user failed: %v", err)
+			return 1
+		}
+
+		out := io.Writer(os.Stdout)
+		if *output != "" {
+			// 개인정보가 포함되므로 소유자만 읽을 수 있게 생성
+			f, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				logger.Errorf("failed to create %s: %v", *output, err)
+				return 1
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if err := printJSON(out, export); err != nil {
+			logger.Errorf("failed to write export: %v", err)
+			return 1
+		}
+		return 0
+	})
+}
+
+func runPurgeExpired(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("purge-expired", flag.ExitOnError)
+	retention := fs.Duration("outbox-retention", 7*24*time.Hour, "keep published outbox events newer than this")
+	fs.Parse(args)
+
+	return withDependencies(cfg, func(ctx context.Context, deps *bootstrap.Dependencies) int {
+		result, err := deps.Services.Admin.PurgeExpired(ctx, *retention)
+		if err != nil {
+			logger.Errorf("purge-expired failed: %v", err)
+			return 1
+		}
+		printJSON(os.Stdout, result)
+		return 0
+	})
+}
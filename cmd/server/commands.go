@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rainbow96bear/planet_user_server/config"
+)
+
+// command: user_server 서브커맨드
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(cfg *config.Config, args []string) int
+}
+
+var commands = []command{
+	{"serve", "", "run the GraphQL/HTTP and gRPC servers (default)", runServe},
+	{"migrate", "[-force] up | down [N] | status | to <version>", "manage database schema migrations", runMigrate},
+	{"seed", "[-events N] [-force]", "create demo users, calendar events and follows", runSeed},
+	{"recount-follows", "", "recompute follower/following counts from follows", runRecountFollows},
+	{"export-user", "[-o file] <user-id>", "export a user's profile, events, templates and follows as JSON", runExportUser},
+	{"purge-expired", "[-outbox-retention 168h]", "delete expired idempotency keys and old published outbox events", runPurgeExpired},
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func usage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "usage: %s [flags] <command> [args]\n\ncommands:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-16s %s\n", cmd.name, cmd.summary)
+		if cmd.args != "" {
+			fmt.Fprintf(out, "  %-16s   %s %s\n", "", cmd.name, cmd.args)
+		}
+	}
+	fmt.Fprintf(out, "\nflags:\n")
+	fs.PrintDefaults()
+}
@@ -3,9 +3,16 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -13,7 +20,11 @@ import (
 	"github.com/rainbow96bear/planet_user_server/config"
 	"github.com/rainbow96bear/planet_user_server/internal/bootstrap"
 	grpcserver "github.com/rainbow96bear/planet_user_server/internal/grpc/server"
+	"github.com/rainbow96bear/planet_user_server/internal/handler"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/migration"
 	"github.com/rainbow96bear/planet_user_server/internal/router"
+	"github.com/rainbow96bear/planet_user_server/internal/tracing"
 
 	// 🌟 공통 유틸리티/프로토 버퍼
 
@@ -22,34 +33,130 @@ import (
 
 // 빌드 플래그 (생략)
 var (
-	Mode      string
+	Mode      string // -ldflags 로 지정 가능, -mode / MODE 환경변수가 우선
 	Version   string
 	GitCommit string
 )
 
-func init() {
-	versionFlag := flag.Bool("version", false, "Print version and exit")
-	flag.Parse()
+// globalFlags: 서브커맨드 이름 앞에 오는 플래그 (-version, -mode, -config, -<section>.<key>)
+// 서브커맨드 플래그는 각 run 함수가 자신의 FlagSet 으로 파싱합니다.
+type globalFlags struct {
+	fs      *flag.FlagSet
+	version *bool
+	mode    *string
+	loader  *config.Loader // -config / -<section>.<key> 플래그를 모아 설정을 로드합니다.
+}
+
+func newGlobalFlags(name string, errorHandling flag.ErrorHandling) *globalFlags {
+	fs := flag.NewFlagSet(name, errorHandling)
+	g := &globalFlags{
+		fs:      fs,
+		version: fs.Bool("version", false, "Print version and exit"),
+		mode:    fs.String("mode", "", "Config mode: dev | prod (default: $MODE, build mode, dev)"),
+		loader:  config.NewLoader(),
+	}
+	g.loader.RegisterFlags(fs)
+	fs.Usage = func() { usage(fs) }
+	return g
+}
+
+// command: 플래그 뒤의 첫 인자. 없으면 serve 입니다.
+func (g *globalFlags) command() (string, []string) {
+	if g.fs.NArg() == 0 {
+		return "serve", nil
+	}
+	return g.fs.Arg(0), g.fs.Args()[1:]
+}
+
+// main: user_server [-mode dev|prod] <command> [args]. command 가 없으면 serve 입니다.
+func main() {
+	flags := newGlobalFlags(os.Args[0], flag.ExitOnError)
+	flags.fs.Parse(os.Args[1:])
 
-	if *versionFlag {
+	if *flags.version {
 		fmt.Printf("Version: %s\nCommit: %s\n", Version, GitCommit)
 		os.Exit(0)
 	}
-	Mode = "dev"
+
+	mode, err := resolveMode(*flags.mode, os.Getenv("MODE"), Mode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	Mode = mode
+
+	name, args := flags.command()
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+		flags.fs.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := flags.loader.Load(Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[CONFIG] invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+	logger.SetLevel(int16(cfg.Log.Level))
+	if err := logctx.SetLevel(cfg.Log.RequestLevel); err != nil {
+		logger.Errorf("[CONFIG] %v", err)
+		os.Exit(1)
+	}
+	logger.Infof("[CONFIG] effective configuration:\n%s", cfg.Dump())
+
+	os.Exit(cmd.run(cfg, args))
+}
+
+// resolveMode: -mode 플래그 → MODE 환경변수 → 빌드 시 지정값 → dev 순으로 결정합니다.
+func resolveMode(candidates ...string) (string, error) {
+	for _, mode := range candidates {
+		if mode == "" {
+			continue
+		}
+		if mode != "dev" && mode != "prod" {
+			return "", fmt.Errorf("invalid mode %q (dev | prod)", mode)
+		}
+		return mode, nil
+	}
+	return "dev", nil
+}
+
+// runServe: 서버 생명주기를 관리하고 종료 코드를 반환합니다.
+// (os.Exit 는 defer 를 실행하지 않으므로 정리 작업은 runServe 안에서 끝냅니다.)
+func runServe(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Parse(args)
+
 	fmt.Printf("user_server Start \nVersion : %s \nGit Commit : %s\n", Version, GitCommit)
 	fmt.Printf("Build Mode : %s\n", Mode)
-	config.InitConfig(Mode)
-	logger.SetLevel(config.LOG_LEVEL)
-}
 
-func main() {
 	// ----------------------------------------------------------------------
-	// 0. 인프라 초기화 (DB 연결)
+	// 0. 인프라 초기화 (Tracing → DB 연결 → Migration)
 	// ----------------------------------------------------------------------
-	db, err := bootstrap.InitDatabase()
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+		Version:      Version,
+	})
+	if err != nil {
+		logger.Errorf("failed to initialize tracing: %v", err)
+		return 1
+	}
+	// 💡 DB 보다 먼저 등록 → 마지막에 실행되어 종료 과정의 span 까지 flush
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Warnf("failed to flush traces: %v", err)
+		}
+	}()
+
+	db, err := bootstrap.InitDatabase(cfg)
 	if err != nil {
 		logger.Errorf("failed to initialize database: %v", err)
-		os.Exit(1)
+		return 1
 	}
 	sqlDB, err := db.DB()
 
@@ -58,21 +165,61 @@ func main() {
 		// 내부 sql.DB를 얻는 데 실패하면 경고만 로깅합니다.
 		logger.Warnf("failed to get underlying sql.DB for closing: %v", err)
 	} else {
-		// 💡 defer를 사용하여 main 함수 종료 시 연결 풀을 안전하게 닫습니다.
+		// 💡 defer를 사용하여 run 함수 종료 시 (서버 / 워커 정리 이후) 연결 풀을 마지막에 닫습니다.
 		defer func() {
 			if closeErr := sqlDB.Close(); closeErr != nil {
 				logger.Errorf("failed to close database connection: %v", closeErr)
 			}
+			logger.Infof("database connection closed")
 		}()
 	}
 
-	dependencies, err := bootstrap.InitDependencies(db)
+	if cfg.DB.MigrateOnStartup {
+		migrator, err := migration.NewMigrator(db)
+		if err != nil {
+			logger.Errorf("failed to load migrations: %v", err)
+			return 1
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			logger.Errorf("failed to apply migrations: %v", err)
+			return 1
+		}
+		logger.Infof("%d pending migration(s) applied", applied)
+	}
+
+	dependencies, err := bootstrap.InitDependencies(db, cfg)
 	if err != nil {
 		logger.Errorf("fail to init Dependencies %s", err.Error())
-		os.Exit(1)
+		return 1
 	}
 
-	go grpcserver.RunGrpcServer(db, dependencies)
+	// SIGINT / SIGTERM 수신 시 종료 절차 시작
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	// 백그라운드 워커는 서버 종료 이후에 취소합니다.
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+	var workers sync.WaitGroup
+
+	// Outbox relay: 도메인 이벤트를 다른 서비스로 발행
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		dependencies.OutboxRelay.Run(workerCtx)
+	}()
+
+	// ----------------------------------------------------------------------
+	// gRPC 서버 (Listen 실패 시 바로 종료)
+	// ----------------------------------------------------------------------
+	grpcServer, err := grpcserver.NewGrpcServer(db, dependencies)
+	if err != nil {
+		logger.Errorf("failed to start grpc server: %v", err)
+		cancelWorkers()
+		workers.Wait()
+		return 1
+	}
 
 	// ----------------------------------------------------------------------
 	// HTTP/GraphQL 서버 실행 (Gin)
@@ -81,7 +228,17 @@ func main() {
 	// 💡 컨테이너에서 UserService를 꺼내 GraphQL Resolver에 주입합니다.
 	// GraphQL Resolver는 DB 대신 Service 계층에 의존해야 합니다.
 
-	handlers := bootstrap.InitHandlers(dependencies)
+	handlers, err := bootstrap.InitHandlers(dependencies, handler.BuildInfo{
+		Version:   Version,
+		GitCommit: GitCommit,
+		Mode:      Mode,
+	})
+	if err != nil {
+		logger.Errorf("fail to init handlers %s", err.Error())
+		cancelWorkers()
+		workers.Wait()
+		return 1
+	}
 
 	r := router.SetupRouter(func(r *gin.Engine) {
 		for _, h := range handlers {
@@ -89,11 +246,74 @@ func main() {
 		}
 	})
 
-	userServerPort := fmt.Sprintf(":%s", config.PORT)
-	logger.Infof("GraphQL/HTTP Server started on port %s", config.PORT)
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfg.Server.Port),
+		Handler: r,
+	}
 
-	if err := r.Run(userServerPort); err != nil {
-		logger.Errorf("failed to start http server: %v", err)
-		os.Exit(1)
+	// 어느 한 서버라도 실패하면 전체 프로세스를 종료합니다.
+	serverErr := make(chan error, 2)
+
+	go func() {
+		if err := grpcServer.Serve(workerCtx); err != nil {
+			serverErr <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	go func() {
+		logger.Infof("GraphQL/HTTP Server started on port %s", cfg.Server.Port)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	exitCode := 0
+	select {
+	case <-signalCtx.Done():
+		logger.Infof("shutdown signal received, draining (timeout %s)", cfg.Server.ShutdownTimeout)
+	case err := <-serverErr:
+		logger.Errorf("server failed, shutting down: %v", err)
+		exitCode = 1
 	}
+
+	// ----------------------------------------------------------------------
+	// 종료 순서: 서버(신규 요청 차단 + 진행 중 요청 대기) → 워커 → DB
+	// ----------------------------------------------------------------------
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelShutdown()
+
+	var servers sync.WaitGroup
+	servers.Add(2)
+	go func() {
+		defer servers.Done()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("http server shutdown: %v", err)
+		}
+	}()
+	go func() {
+		defer servers.Done()
+		if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("grpc server shutdown: %v", err)
+		}
+	}()
+	servers.Wait()
+
+	cancelWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		logger.Warnf("background workers did not stop before drain timeout")
+	}
+
+	if err := dependencies.OutboxRelay.Close(); err != nil {
+		logger.Warnf("failed to close outbox publisher: %v", err)
+	}
+
+	logger.Infof("servers stopped")
+	return exitCode
 }
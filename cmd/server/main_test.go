@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestGlobalFlagsCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantMode string
+		wantCmd  string
+		wantArgs []string
+	}{
+		{name: "default serve", args: nil, wantCmd: "serve"},
+		{name: "mode before command", args: []string{"-mode", "prod", "migrate", "up"}, wantMode: "prod", wantCmd: "migrate", wantArgs: []string{"up"}},
+		{
+			// 서브커맨드 뒤의 플래그는 전역 FlagSet 이 아니라 서브커맨드 FlagSet 으로 넘어갑니다.
+			name:     "subcommand flags untouched",
+			args:     []string{"seed", "-events", "10"},
+			wantCmd:  "seed",
+			wantArgs: []string{"-events", "10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGlobalFlags("user_server", flag.ContinueOnError)
+			g.fs.SetOutput(io.Discard)
+			if err := g.fs.Parse(tt.args); err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			if *g.mode != tt.wantMode {
+				t.Errorf("mode = %q, want %q", *g.mode, tt.wantMode)
+			}
+			name, args := g.command()
+			if name != tt.wantCmd || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("command = %q %v, want %q %v", name, args, tt.wantCmd, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestGlobalFlagsRejectUnknown(t *testing.T) {
+	g := newGlobalFlags("user_server", flag.ContinueOnError)
+	g.fs.SetOutput(io.Discard)
+	if err := g.fs.Parse([]string{"-no-such-flag"}); err == nil {
+		t.Fatal("expected error for unknown flag")
+	}
+}
+
+func TestResolveMode(t *testing.T) {
+	tests := []struct {
+		candidates []string
+		want       string
+		wantErr    bool
+	}{
+		{candidates: []string{"", "", ""}, want: "dev"},
+		{candidates: []string{"", "prod", "dev"}, want: "prod"},
+		{candidates: []string{"dev", "prod"}, want: "dev"},
+		{candidates: []string{"staging"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveMode(tt.candidates...)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("resolveMode(%q) = %q, %v", tt.candidates, got, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/config"
+	"github.com/rainbow96bear/planet_user_server/internal/bootstrap"
+	"github.com/rainbow96bear/planet_user_server/internal/migration"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+)
+
+const migrateUsage = `usage: user_server migrate [-force] <command>
+
+commands:
+  up              apply all pending migrations
+  down [N]        revert the last N migrations (default 1)
+  status          show applied / pending migrations
+  to <version>    migrate up or down to version (0 reverts everything)
+
+flags:
+  -force          allow reverting the baseline migration (drops all tables)`
+
+// runMigrate: "migrate" 서브커맨드. 서버를 띄우지 않고 migration 만 실행합니다.
+func runMigrate(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fs.Usage = func() { fmt.Fprintln(fs.Output(), migrateUsage) }
+	force := fs.Bool("force", false, "allow reverting the baseline migration")
+	fs.Parse(args)
+	args = fs.Args()
+
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	db, err := bootstrap.InitDatabase(cfg)
+	if err != nil {
+		logger.Errorf("failed to initialize database: %v", err)
+		return 1
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	var opts []migration.Option
+	if *force {
+		opts = append(opts, migration.WithForce())
+	}
+	migrator, err := migration.NewMigrator(db, opts...)
+	if err != nil {
+		logger.Errorf("failed to load migrations: %v", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch args[0] {
+	case "up":
+		n, err := migrator.Up(ctx)
+		return reportMigrate("applied", n, err)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid step count: %s\n", args[1])
+				return 2
+			}
+		}
+		n, err := migrator.Down(ctx, steps)
+		return reportMigrate("reverted", n, err)
+
+	case "to":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid version: %s\n", args[1])
+			return 2
+		}
+		n, err := migrator.To(ctx, version)
+		return reportMigrate("applied/reverted", n, err)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			logger.Errorf("failed to read migration status: %v", err)
+			return 1
+		}
+		printMigrationStatus(statuses)
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command: %s\n\n%s\n", args[0], migrateUsage)
+		return 2
+	}
+}
+
+func reportMigrate(action string, n int, err error) int {
+	if err != nil {
+		logger.Errorf("migration failed after %d %s: %v", n, action, err)
+		return 1
+	}
+	fmt.Printf("%d migration(s) %s\n", n, action)
+	return 0
+}
+
+func printMigrationStatus(statuses []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range statuses {
+		state, appliedAt := "pending", "-"
+		if s.AppliedAt != nil {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		if !s.Known {
+			state += " (unknown to this binary)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Config: 서버 전체 설정. Load 가 기본값 → 설정 파일 → 환경 변수 → 플래그 순으로 채웁니다.
+//
+// 태그
+//   - config:   설정 파일 키 / 플래그 이름 (섹션.키)
+//   - env:      환경 변수 이름. <ENV>_FILE 이 있으면 해당 파일 내용을 값으로 사용 (Docker / k8s secret)
+//   - default:  기본값
+//   - required: 비어 있으면 검증 실패
+//   - secret:   Dump 에서 가려짐
+type Config struct {
+	Mode string `config:"-"` // dev | prod (-mode 플래그 / MODE 환경변수로만 지정)
+
+	Server        ServerConfig        `config:"server"`
+	GRPC          GRPCConfig          `config:"grpc"`
+	Log           LogConfig           `config:"log"`
+	Auth          AuthConfig          `config:"auth"`
+	DB            DBConfig            `config:"db"`
+	Todo          TodoConfig          `config:"todo"`
+	Idempotency   IdempotencyConfig   `config:"idempotency"`
+	Outbox        OutboxConfig        `config:"outbox"`
+	GraphQL       GraphQLConfig       `config:"graphql"`
+	Tracing       TracingConfig       `config:"tracing"`
+	CalendarCache CalendarCacheConfig `config:"calendar_cache"`
+	Redis         RedisConfig         `config:"redis"`
+}
+
+type ServerConfig struct {
+	Port            string        `config:"port" env:"PORT" required:"true"`
+	GRPCPort        string        `config:"grpc_port" env:"USER_GRPC_PORT" required:"true"`
+	ShutdownTimeout time.Duration `config:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s"` // 종료 시 진행 중인 요청을 기다리는 최대 시간
+}
+
+type GRPCConfig struct {
+	ServiceToken      string        `config:"service_token" env:"GRPC_SERVICE_TOKEN" required:"true" secret:"true"` // 서비스 간 gRPC 호출 인증용 공유 비밀
+	DefaultTimeout    time.Duration `config:"default_timeout" env:"GRPC_DEFAULT_TIMEOUT" default:"10s"`             // deadline 없는 unary 호출의 기본 timeout
+	StreamTimeout     time.Duration `config:"stream_timeout" env:"GRPC_STREAM_TIMEOUT" default:"0s"`                // 0 이면 stream 에 기본 deadline 없음
+	ReflectionEnabled bool          `config:"reflection_enabled" env:"GRPC_REFLECTION_ENABLED" default:"false"`     // grpcurl 등을 위한 server reflection
+
+	AuthServerAddr string `config:"auth_server_addr" env:"AUTH_GRPC_SERVER_ADDR" required:"true"`
+	DBServerAddr   string `config:"db_server_addr" env:"DB_GRPC_SERVER_ADDR" required:"true"`
+}
+
+type LogConfig struct {
+	Level        int    `config:"level" env:"LOG_LEVEL" required:"true"`
+	RequestLevel string `config:"request_level" env:"REQUEST_LOG_LEVEL" default:"info"` // 요청 단위 JSON 로그 레벨 (debug | info | warn | error)
+}
+
+type AuthConfig struct {
+	JWTSecretKey string `config:"jwt_secret_key" env:"JWT_SECRET_KEY" required:"true" secret:"true"`
+}
+
+type DBConfig struct {
+	User     string `config:"user" env:"DB_USER" required:"true"`
+	Password string `config:"password" env:"DB_PASSWORD" required:"true" secret:"true"`
+	Host     string `config:"host" env:"DB_HOST" required:"true"`
+	Port     string `config:"port" env:"DB_PORT" required:"true"`
+	Name     string `config:"name" env:"DB_NAME" required:"true"`
+
+	MigrateOnStartup bool `config:"migrate_on_startup" env:"DB_MIGRATE_ON_STARTUP" default:"false"` // 기동 시 미적용 migration 자동 적용 (기본: migrate 서브커맨드로 수동 적용)
+
+	// TLS (libpq sslmode). verify-ca / verify-full 은 ssl_root_cert 필수
+	SSLMode     string `config:"ssl_mode" env:"DB_SSLMODE" default:"disable"` // disable | allow | prefer | require | verify-ca | verify-full
+	SSLRootCert string `config:"ssl_root_cert" env:"DB_SSLROOTCERT"`          // 서버 인증서를 검증할 CA 파일 경로
+	SSLCert     string `config:"ssl_cert" env:"DB_SSLCERT"`                   // 클라이언트 인증서 (ssl_key 와 함께 지정)
+	SSLKey      string `config:"ssl_key" env:"DB_SSLKEY" secret:"true"`
+
+	// 커넥션 풀 (primary / replica 각각에 적용)
+	MaxOpenConns    int           `config:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"50"`
+	MaxIdleConns    int           `config:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"10"`
+	ConnMaxLifetime time.Duration `config:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"1h"`
+	ConnMaxIdleTime time.Duration `config:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"0s"` // 0 이면 유휴 시간 제한 없음
+
+	// 읽기 전용 replica "host:port,host:port". user / password / name / ssl 설정은 primary 와 같습니다.
+	// 비어 있으면 모든 쿼리가 primary 로 갑니다.
+	ReplicaHosts string `config:"replica_hosts" env:"DB_REPLICA_HOSTS"`
+}
+
+// Replicas: ReplicaHosts 를 host:port 목록으로 분리합니다.
+func (c DBConfig) Replicas() []string {
+	var hosts []string
+	for _, host := range strings.Split(c.ReplicaHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+type TodoConfig struct {
+	MaxLength int `config:"max_length" env:"MaxTodoLength" required:"true"`
+}
+
+type IdempotencyConfig struct {
+	KeyTTL time.Duration `config:"key_ttl" env:"IDEMPOTENCY_KEY_TTL" default:"24h"`
+}
+
+type OutboxConfig struct {
+	Publisher     string        `config:"publisher" env:"OUTBOX_PUBLISHER" default:"log"` // log | grpc
+	GRPCAddr      string        `config:"grpc_addr" env:"OUTBOX_GRPC_ADDR"`               // publisher=grpc 일 때 필수
+	GRPCMethod    string        `config:"grpc_method" env:"OUTBOX_GRPC_METHOD" default:"/planet.event.EventService/Publish"`
+	RelayInterval time.Duration `config:"relay_interval" env:"OUTBOX_RELAY_INTERVAL" default:"1s"`
+	MaxAttempts   int           `config:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+}
+
+type GraphQLConfig struct {
+	APQCache           string `config:"apq_cache" env:"GRAPHQL_APQ_CACHE" default:"memory"` // memory | db
+	APQCacheSize       int    `config:"apq_cache_size" env:"GRAPHQL_APQ_CACHE_SIZE" default:"1000"`
+	OperationAllowlist string `config:"operation_allowlist" env:"GRAPHQL_OPERATION_ALLOWLIST"` // manifest 경로. 설정 시 등록된 operation 만 허용 (APQ 대신 사용)
+
+	MaxComplexity int    `config:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" default:"2000"`
+	MaxDepth      int    `config:"max_depth" env:"GRAPHQL_MAX_DEPTH" default:"8"`
+	FieldCosts    string `config:"field_costs" env:"GRAPHQL_FIELD_COSTS" default:"Query.myCalendarEvents=31,Query.userCalendarEvents=31,Query.myCalendarEventsByDate=10,Query.myEventTemplates=20,Calendar.todos=10,EventTemplate.todos=10"` // "Type.field=N,..." 리스트 필드의 예상 항목 수
+
+	// token bucket (초당 토큰 / 최대 버스트). RPS 0 이면 해당 제한 비활성화
+	RateLimitRPS            float64 `config:"rate_limit_rps" env:"GRAPHQL_RATE_LIMIT_RPS" default:"10"`
+	RateLimitBurst          int     `config:"rate_limit_burst" env:"GRAPHQL_RATE_LIMIT_BURST" default:"30"`
+	MutationRateLimitRPS    float64 `config:"mutation_rate_limit_rps" env:"GRAPHQL_MUTATION_RATE_LIMIT_RPS" default:"2"`
+	MutationRateLimitBurst  int     `config:"mutation_rate_limit_burst" env:"GRAPHQL_MUTATION_RATE_LIMIT_BURST" default:"10"`
+	SensitiveRateLimitRPS   float64 `config:"sensitive_rate_limit_rps" env:"GRAPHQL_SENSITIVE_RATE_LIMIT_RPS" default:"0.2"` // 분당 12회
+	SensitiveRateLimitBurst int     `config:"sensitive_rate_limit_burst" env:"GRAPHQL_SENSITIVE_RATE_LIMIT_BURST" default:"5"`
+	SensitiveFields         string  `config:"sensitive_fields" env:"GRAPHQL_SENSITIVE_FIELDS" default:"checkNicknameAvailability"` // 콤마 구분 루트 필드 이름
+}
+
+type TracingConfig struct {
+	Exporter          string  `config:"exporter" env:"TRACING_EXPORTER" default:"none"` // none | stdout | otlp
+	OTLPEndpoint      string  `config:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`      // 예: otel-collector:4317 (비어 있으면 OTEL_EXPORTER_OTLP_* 표준 환경 변수)
+	SampleRatio       float64 `config:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
+	GraphQLFieldDepth int     `config:"graphql_field_depth" env:"TRACING_GRAPHQL_FIELD_DEPTH" default:"2"` // 이 깊이까지의 resolver field 에 span 생성 (0 이면 operation 만)
+}
+
+type CalendarCacheConfig struct {
+	Backend string        `config:"backend" env:"CALENDAR_CACHE" default:"memory"` // memory | redis | none
+	TTL     time.Duration `config:"ttl" env:"CALENDAR_CACHE_TTL" default:"1m"`
+	Size    int           `config:"size" env:"CALENDAR_CACHE_SIZE" default:"10000"` // memory: 최대 (사용자, 월, 공개 범위) 항목 수
+}
+
+type RedisConfig struct {
+	Addr     string `config:"addr" env:"REDIS_ADDR"` // CALENDAR_CACHE=redis 일 때 필수 (예: redis:6379)
+	Password string `config:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `config:"db" env:"REDIS_DB" default:"0"`
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const redacted = "******"
+
+// Dump: 기동 로그용 설정 덤프. secret 필드는 값이 있으면 가려서 출력합니다.
+func (c *Config) Dump() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "mode = %s\n", c.Mode)
+
+	for _, f := range fields(c) {
+		value := fmt.Sprint(f.value.Interface())
+		if f.secret && value != "" {
+			value = redacted
+		}
+		fmt.Fprintf(&b, "%s = %s\n", f.key, value)
+	}
+	return b.String()
+}
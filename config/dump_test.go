@@ -0,0 +1,33 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDumpRedactsSecrets(t *testing.T) {
+	setRequiredEnv(t)
+	cfg, err := load(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	dump := cfg.Dump()
+	for _, secret := range []string{"service-token", "jwt-secret", "db-password"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump contains secret %q\n%s", secret, dump)
+		}
+	}
+	for _, line := range []string{
+		"mode = dev",
+		"auth.jwt_secret_key = ******",
+		"grpc.service_token = ******",
+		"db.password = ******",
+		"db.user = planet",
+		"redis.password = \n", // 비어 있는 secret 은 설정되지 않았음을 알 수 있도록 그대로
+	} {
+		if !strings.Contains(dump, line) {
+			t.Errorf("dump does not contain %q\n%s", line, dump)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// field: Config 의 leaf 필드 하나 (섹션.키 단위)
+type field struct {
+	key      string // 예: graphql.max_depth
+	env      string
+	def      string
+	required bool
+	secret   bool
+	value    reflect.Value
+}
+
+// fields: cfg 의 모든 leaf 필드를 선언 순서대로 반환합니다.
+func fields(cfg *Config) []field {
+	var out []field
+	collectFields(reflect.ValueOf(cfg).Elem(), "", &out)
+	return out
+}
+
+func collectFields(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get("config")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Duration(0)) {
+			collectFields(v.Field(i), key, out)
+			continue
+		}
+
+		*out = append(*out, field{
+			key:      key,
+			env:      sf.Tag.Get("env"),
+			def:      sf.Tag.Get("default"),
+			required: sf.Tag.Get("required") == "true",
+			secret:   sf.Tag.Get("secret") == "true",
+			value:    v.Field(i),
+		})
+	}
+}
+
+// Loader: 설정 파일 경로와 플래그 override 를 모아 두었다가 Load 에서 적용합니다.
+type Loader struct {
+	file      string
+	overrides map[string]string
+}
+
+func NewLoader() *Loader {
+	return &Loader{
+		overrides: make(map[string]string),
+	}
+}
+
+// RegisterFlags: -config 와 모든 설정 키에 대한 override 플래그 (예: -graphql.max_depth=10) 를 등록합니다.
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&l.file, "config", "", "Config file (.yaml | .yml | .toml), default: $CONFIG_FILE or ./env/config.<mode>.yaml")
+
+	for _, f := range fields(&Config{}) {
+		key := f.key
+		fs.Func(key, "override "+f.env, func(v string) error {
+			l.overrides[key] = v
+			return nil
+		})
+	}
+}
+
+// Load: 기본값 → 설정 파일 → 환경 변수 (.env.<mode> 포함) → 플래그 순으로 적용하고 검증합니다.
+// 잘못된 값은 모두 모아 하나의 에러로 반환합니다.
+func (l *Loader) Load(mode string) (*Config, error) {
+	cfg := &Config{Mode: mode}
+	all := fields(cfg)
+	byKey := make(map[string]*field, len(all))
+	for i := range all {
+		byKey[all[i].key] = &all[i]
+	}
+
+	var errs []error
+	provided := make(map[string]bool, len(all))
+	apply := func(f *field, raw, source string) {
+		value := raw
+		if value == "" && f.value.Kind() != reflect.String {
+			value = f.def // 빈 숫자 / bool / duration 은 기본값으로 되돌립니다. (예: DB_MAX_OPEN_CONNS=)
+		}
+		if err := setValue(f.value, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", f.key, source, err))
+			return
+		}
+		provided[f.key] = raw != ""
+	}
+
+	// 1) 기본값
+	for i := range all {
+		if all[i].def != "" {
+			apply(&all[i], all[i].def, "default")
+		}
+	}
+
+	// 2) 설정 파일
+	path := l.configFile(mode)
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for key, raw := range values {
+			f, ok := byKey[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown config key %q", path, key))
+				continue
+			}
+			apply(f, raw, path)
+		}
+	}
+
+	// 3) 환경 변수. 이미 설정된 환경 변수는 .env 파일이 덮어쓰지 않습니다.
+	if err := godotenv.Load(filepath.Join("env", ".env."+mode)); err != nil {
+		fmt.Printf("[CONFIG] .env.%s not loaded, using process environment only\n", mode)
+	}
+	for i := range all {
+		f := &all[i]
+		if f.env == "" {
+			continue
+		}
+		raw, source, err := lookupEnv(f.env)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if source != "" {
+			apply(f, raw, source)
+		}
+	}
+
+	// 4) 플래그
+	for key, raw := range l.overrides {
+		apply(byKey[key], raw, "-"+key)
+	}
+
+	// 5) 필수값 / 값 범위 검증
+	for _, f := range all {
+		if f.required && !provided[f.key] {
+			errs = append(errs, fmt.Errorf("%s is required (env %s)", f.key, f.env))
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// configFile: -config → $CONFIG_FILE → ./env/config.<mode>.{yaml,yml,toml} (있을 때만)
+func (l *Loader) configFile(mode string) string {
+	if l.file != "" {
+		return l.file
+	}
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		return path
+	}
+	for _, ext := range []string{".yaml", ".yml", ".toml"} {
+		path := filepath.Join("env", "config."+mode+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// lookupEnv: <ENV>_FILE 이 있으면 파일 내용을 (끝 줄바꿈 제외) 값으로 사용합니다.
+func lookupEnv(name string) (value, source string, err error) {
+	path, hasFile := os.LookupEnv(name + "_FILE")
+	raw, hasValue := os.LookupEnv(name)
+
+	switch {
+	case hasFile && hasValue:
+		return "", "", fmt.Errorf("both %s and %s_FILE are set", name, name)
+	case hasFile:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("%s_FILE: %w", name, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), "$" + name + "_FILE", nil
+	case hasValue && raw != "":
+		return raw, "$" + name, nil
+	default:
+		return "", "", nil
+	}
+}
+
+// readConfigFile: YAML / TOML 파일을 "섹션.키" → 문자열 값으로 평탄화합니다.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config file type (yaml | yml | toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flatten(doc, "", values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flatten(node map[string]any, prefix string, out map[string]string) error {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case map[string]any:
+			if err := flatten(val, key, out); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+	return nil
+}
+
+// setValue: 문자열을 필드 타입 (string / int / float64 / bool / time.Duration) 으로 변환합니다.
+// 빈 문자열은 zero value 로 설정합니다.
+func setValue(v reflect.Value, raw string) error {
+	if raw == "" {
+		v.SetZero()
+		return nil
+	}
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
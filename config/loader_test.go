@@ -0,0 +1,291 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setRequiredEnv: required 필드를 모두 채웁니다.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+
+	for name, value := range map[string]string{
+		"PORT":                  "8080",
+		"USER_GRPC_PORT":        "9090",
+		"GRPC_SERVICE_TOKEN":    "service-token",
+		"AUTH_GRPC_SERVER_ADDR": "auth:9090",
+		"DB_GRPC_SERVER_ADDR":   "db:9090",
+		"LOG_LEVEL":             "1",
+		"JWT_SECRET_KEY":        "jwt-secret",
+		"DB_USER":               "planet",
+		"DB_PASSWORD":           "db-password",
+		"DB_HOST":               "localhost",
+		"DB_PORT":               "5432",
+		"DB_NAME":               "planet",
+		"MaxTodoLength":         "100",
+	} {
+		t.Setenv(name, value)
+	}
+}
+
+// load: args 를 플래그로 파싱한 Loader 로 dev 설정을 읽습니다.
+func load(t *testing.T, args ...string) (*Config, error) {
+	t.Helper()
+
+	l := NewLoader()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+	return l.Load("dev")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		file bool
+		env  bool
+		flag bool
+		want int
+	}{
+		{name: "default", want: 8},
+		{name: "file over default", file: true, want: 9},
+		{name: "env over file", file: true, env: true, want: 10},
+		{name: "flag over env", file: true, env: true, flag: true, want: 11},
+		{name: "flag over default", flag: true, want: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+
+			var args []string
+			if tt.file {
+				args = append(args, "-config", writeFile(t, "config.yaml", "graphql:\n  max_depth: 9\n"))
+			}
+			if tt.env {
+				t.Setenv("GRAPHQL_MAX_DEPTH", "10")
+			}
+			if tt.flag {
+				args = append(args, "-graphql.max_depth=11")
+			}
+
+			cfg, err := load(t, args...)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.GraphQL.MaxDepth != tt.want {
+				t.Fatalf("graphql.max_depth = %d, want %d", cfg.GraphQL.MaxDepth, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTOMLFile(t *testing.T) {
+	setRequiredEnv(t)
+
+	path := writeFile(t, "config.toml", "[outbox]\nrelay_interval = \"5s\"\nmax_attempts = 3\n")
+	cfg, err := load(t, "-config", path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Outbox.RelayInterval != 5*time.Second || cfg.Outbox.MaxAttempts != 3 {
+		t.Fatalf("outbox = %+v", cfg.Outbox)
+	}
+}
+
+func TestLoadSecretFile(t *testing.T) {
+	t.Run("value from file", func(t *testing.T) {
+		setRequiredEnv(t)
+		os.Unsetenv("JWT_SECRET_KEY") // t.Setenv 가 원래 값을 복원합니다.
+		t.Setenv("JWT_SECRET_KEY_FILE", writeFile(t, "jwt", "from-file\r\n"))
+
+		cfg, err := load(t)
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.Auth.JWTSecretKey != "from-file" {
+			t.Fatalf("jwt_secret_key = %q, want trailing newline trimmed", cfg.Auth.JWTSecretKey)
+		}
+	})
+
+	t.Run("both value and file", func(t *testing.T) {
+		setRequiredEnv(t)
+		t.Setenv("JWT_SECRET_KEY_FILE", writeFile(t, "jwt", "from-file"))
+
+		_, err := load(t)
+		if err == nil || !strings.Contains(err.Error(), "both JWT_SECRET_KEY and JWT_SECRET_KEY_FILE are set") {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		setRequiredEnv(t)
+		os.Unsetenv("JWT_SECRET_KEY")
+		t.Setenv("JWT_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := load(t)
+		if err == nil || !strings.Contains(err.Error(), "JWT_SECRET_KEY_FILE") {
+			t.Fatalf("err = %v", err)
+		}
+	})
+}
+
+func TestLoadEmptyValuesFallBackToDefault(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T) []string
+	}{
+		{
+			name: "env",
+			setup: func(t *testing.T) []string {
+				t.Setenv("DB_MAX_OPEN_CONNS", "")
+				t.Setenv("GRPC_REFLECTION_ENABLED", "")
+				t.Setenv("SHUTDOWN_TIMEOUT", "")
+				return nil
+			},
+		},
+		{
+			name: "env file",
+			setup: func(t *testing.T) []string {
+				t.Setenv("DB_MAX_OPEN_CONNS_FILE", writeFile(t, "conns", "\n"))
+				return nil
+			},
+		},
+		{
+			name: "config file",
+			setup: func(t *testing.T) []string {
+				return []string{"-config", writeFile(t, "config.yaml",
+					"db:\n  max_open_conns:\ngrpc:\n  reflection_enabled:\nserver:\n  shutdown_timeout:\n")}
+			},
+		},
+		{
+			name: "flag",
+			setup: func(t *testing.T) []string {
+				return []string{"-db.max_open_conns=", "-grpc.reflection_enabled=", "-server.shutdown_timeout="}
+			},
+		},
+		{
+			// 앞 단계에서 설정한 값도 빈 값이면 기본값으로 되돌립니다.
+			name: "flag after file",
+			setup: func(t *testing.T) []string {
+				return []string{
+					"-config", writeFile(t, "config.yaml", "db:\n  max_open_conns: 5\n"),
+					"-db.max_open_conns=",
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			args := tt.setup(t)
+
+			cfg, err := load(t, args...)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.DB.MaxOpenConns != 50 || cfg.GRPC.ReflectionEnabled || cfg.Server.ShutdownTimeout != 30*time.Second {
+				t.Fatalf("max_open_conns = %d, reflection_enabled = %v, shutdown_timeout = %s, want defaults",
+					cfg.DB.MaxOpenConns, cfg.GRPC.ReflectionEnabled, cfg.Server.ShutdownTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadEmptyRequiredValue(t *testing.T) {
+	setRequiredEnv(t)
+
+	_, err := load(t, "-log.level=")
+	if err == nil || !strings.Contains(err.Error(), "log.level is required") {
+		t.Fatalf("err = %v, want log.level is required", err)
+	}
+}
+
+func TestLoadAggregatesErrors(t *testing.T) {
+	setRequiredEnv(t)
+	os.Unsetenv("DB_HOST")
+	t.Setenv("GRAPHQL_MAX_DEPTH", "deep")
+	t.Setenv("DB_SSLMODE", "sometimes")
+
+	path := writeFile(t, "config.yaml", "graphql:\n  no_such_key: 1\n")
+	_, err := load(t, "-config", path, "-outbox.relay_interval=soon")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	for _, want := range []string{
+		`graphql.max_depth ($GRAPHQL_MAX_DEPTH): invalid integer "deep"`,
+		`outbox.relay_interval (-outbox.relay_interval): invalid duration "soon"`,
+		`unknown config key "graphql.no_such_key"`,
+		"db.host is required (env DB_HOST)",
+		`db.ssl_mode must be one of`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not contain %q\n%v", want, err)
+		}
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  any
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{name: "string", target: new(string), raw: "abc", want: "abc"},
+		{name: "empty string", target: ptr("old"), raw: "", want: ""},
+		{name: "int", target: new(int), raw: "42", want: 42},
+		{name: "empty int", target: ptr(7), raw: "", want: 0},
+		{name: "invalid int", target: new(int), raw: "4x", wantErr: true},
+		{name: "float", target: new(float64), raw: "0.5", want: 0.5},
+		{name: "empty float", target: ptr(1.5), raw: "", want: 0.0},
+		{name: "bool", target: new(bool), raw: "true", want: true},
+		{name: "empty bool", target: ptr(true), raw: "", want: false},
+		{name: "invalid bool", target: new(bool), raw: "yes please", wantErr: true},
+		{name: "duration", target: new(time.Duration), raw: "1m30s", want: 90 * time.Second},
+		{name: "empty duration", target: ptr(time.Hour), raw: "", want: time.Duration(0)},
+		{name: "invalid duration", target: new(time.Duration), raw: "90", wantErr: true},
+		{name: "unsupported", target: new([]string), raw: "a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := reflect.ValueOf(tt.target).Elem()
+			err := setValue(v, tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("setValue(%q) = %v, want error", tt.raw, v.Interface())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setValue(%q): %v", tt.raw, err)
+			}
+			if got := v.Interface(); got != tt.want {
+				t.Fatalf("setValue(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
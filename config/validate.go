@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// Validate: 허용 값 / 범위 / 설정 간 의존 관계를 확인합니다. 모든 위반을 모아서 반환합니다.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		check(slices.Contains(allowed, value), "%s must be one of %s, got %q", key, strings.Join(allowed, " | "), value)
+	}
+
+	oneOf("mode", c.Mode, "dev", "prod")
+
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.GRPC.DefaultTimeout >= 0, "grpc.default_timeout must not be negative")
+	check(c.GRPC.StreamTimeout >= 0, "grpc.stream_timeout must not be negative")
+
+	oneOf("log.request_level", c.Log.RequestLevel, "debug", "info", "warn", "error")
+
+	oneOf("db.ssl_mode", c.DB.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	check(!strings.HasPrefix(c.DB.SSLMode, "verify-") || c.DB.SSLRootCert != "", "db.ssl_root_cert is required when db.ssl_mode=%s", c.DB.SSLMode)
+	check((c.DB.SSLCert == "") == (c.DB.SSLKey == ""), "db.ssl_cert and db.ssl_key must be set together")
+	check(c.DB.MaxOpenConns >= 0, "db.max_open_conns must not be negative")
+	check(c.DB.MaxIdleConns >= 0, "db.max_idle_conns must not be negative")
+	check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns, "db.max_idle_conns must not exceed db.max_open_conns")
+	check(c.DB.ConnMaxLifetime >= 0 && c.DB.ConnMaxIdleTime >= 0, "db connection lifetimes must not be negative")
+	for _, host := range c.DB.Replicas() {
+		_, _, err := net.SplitHostPort(host)
+		check(err == nil, "db.replica_hosts: invalid host:port %q", host)
+	}
+
+	check(c.Todo.MaxLength >= 0, "todo.max_length must not be negative")
+	check(c.Idempotency.KeyTTL > 0, "idempotency.key_ttl must be positive")
+
+	oneOf("outbox.publisher", c.Outbox.Publisher, "log", "grpc")
+	check(c.Outbox.Publisher != "grpc" || c.Outbox.GRPCAddr != "", "outbox.grpc_addr is required when outbox.publisher=grpc")
+	check(c.Outbox.RelayInterval > 0, "outbox.relay_interval must be positive")
+	check(c.Outbox.MaxAttempts > 0, "outbox.max_attempts must be positive")
+
+	oneOf("graphql.apq_cache", c.GraphQL.APQCache, "memory", "db")
+	check(c.GraphQL.APQCacheSize > 0, "graphql.apq_cache_size must be positive")
+	check(c.GraphQL.MaxComplexity > 0, "graphql.max_complexity must be positive")
+	check(c.GraphQL.MaxDepth > 0, "graphql.max_depth must be positive")
+	check(c.GraphQL.RateLimitRPS >= 0 && c.GraphQL.MutationRateLimitRPS >= 0 && c.GraphQL.SensitiveRateLimitRPS >= 0,
+		"graphql rate limit rps must not be negative")
+
+	oneOf("tracing.exporter", c.Tracing.Exporter, "none", "stdout", "otlp")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	check(c.Tracing.GraphQLFieldDepth >= 0, "tracing.graphql_field_depth must not be negative")
+
+	oneOf("calendar_cache.backend", c.CalendarCache.Backend, "memory", "redis", "none")
+	check(c.CalendarCache.Backend != "redis" || c.Redis.Addr != "", "redis.addr is required when calendar_cache.backend=redis")
+	check(c.CalendarCache.TTL > 0, "calendar_cache.ttl must be positive")
+	check(c.CalendarCache.Size > 0, "calendar_cache.size must be positive")
+
+	return errors.Join(errs...)
+}
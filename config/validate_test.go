@@ -0,0 +1,39 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateAggregatesErrors(t *testing.T) {
+	setRequiredEnv(t)
+	cfg, err := load(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid config: %v", err)
+	}
+
+	cfg.Mode = "staging"
+	cfg.DB.SSLMode = "verify-full"
+	cfg.DB.MaxOpenConns, cfg.DB.MaxIdleConns = 5, 10
+	cfg.Outbox.Publisher = "grpc"
+	cfg.Tracing.SampleRatio = 2
+
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	want := []string{
+		`mode must be one of dev | prod, got "staging"`,
+		"db.ssl_root_cert is required when db.ssl_mode=verify-full",
+		"db.max_idle_conns must not exceed db.max_open_conns",
+		"outbox.grpc_addr is required when outbox.publisher=grpc",
+		"tracing.sample_ratio must be between 0 and 1",
+	}
+	if got := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+// UserExport: export-user 서브커맨드 출력 (사용자 데이터 전체 스냅샷)
+type UserExport struct {
+	ExportedAt     time.Time                       `json:"exported_at"`
+	Profile        *models.Profile                 `json:"profile"`
+	CalendarEvents []*models.CalendarEvent         `json:"calendar_events"`
+	Templates      []*models.CalendarEventTemplate `json:"templates"`
+	Following      []uuid.UUID                     `json:"following"`
+	Followers      []uuid.UUID                     `json:"followers"`
+}
+
+// SeedResult: seed 서브커맨드 결과
+type SeedResult struct {
+	UsersCreated  int `json:"users_created"`
+	UsersExisting int `json:"users_existing"`
+	EventsCreated int `json:"events_created"`
+	FollowsAdded  int `json:"follows_added"`
+}
+
+// PurgeResult: purge-expired 서브커맨드 결과
+type PurgeResult struct {
+	ExpiredIdempotencyKeys int64 `json:"expired_idempotency_keys"`
+	PublishedOutboxEvents  int64 `json:"published_outbox_events"`
+}
@@ -38,6 +38,7 @@ func ToCalendarModel(
 		StartAt:     input.StartAt,
 		EndAt:       input.EndAt,
 		Visibility:  derefVisibility(input.Visibility),
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -51,6 +52,7 @@ func ToCalendarModel(
 				CalendarEventID: event.ID, // ⭐ 중요
 				Content:         t.Content,
 				IsDone:          false,
+				Version:         1,
 				CreatedAt:       time.Now(),
 				UpdatedAt:       time.Now(),
 			})
@@ -73,8 +75,9 @@ func ToCalendarGraphQL(
 		StartAt:    event.StartAt,
 		EndAt:      event.EndAt,
 		Visibility: model.CalendarVisibility(event.Visibility),
+		Version:    event.Version,
 
-		Todos:     []*models.Todo{}, // 나중에 preload or resolver에서 채우기
+		Todos:     nil, // Calendar.todos resolver 에서 DataLoader 로 조회
 		CreatedAt: event.CreatedAt,
 		UpdatedAt: event.UpdatedAt,
 	}
@@ -118,6 +121,7 @@ func UpdateCalendarModelFromRequest(
 			todo := models.Todo{
 				ID:              uuid.New(),
 				CalendarEventID: event.ID,
+				Version:         1,
 				CreatedAt:       now,
 				UpdatedAt:       now,
 			}
@@ -137,6 +141,45 @@ func UpdateCalendarModelFromRequest(
 	event.UpdatedAt = time.Now()
 }
 
+// DuplicateCalendarModel: 기존 Event를 newStartAt 기준으로 복제합니다.
+// 기간(EndAt - StartAt)은 유지하고, Todo는 새 ID + 미완료 상태로 복사합니다.
+func DuplicateCalendarModel(
+	src *models.CalendarEvent,
+	newStartAt time.Time,
+) *models.CalendarEvent {
+
+	now := time.Now()
+
+	event := &models.CalendarEvent{
+		ID:          uuid.New(),
+		UserID:      src.UserID,
+		Title:       src.Title,
+		Emoji:       src.Emoji,
+		Description: src.Description,
+		StartAt:     newStartAt,
+		EndAt:       newStartAt.Add(src.EndAt.Sub(src.StartAt)),
+		Visibility:  src.Visibility,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	event.Todos = make([]models.Todo, 0, len(src.Todos))
+	for _, t := range src.Todos {
+		event.Todos = append(event.Todos, models.Todo{
+			ID:              uuid.New(),
+			CalendarEventID: event.ID,
+			Content:         t.Content,
+			IsDone:          false,
+			Version:         1,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	}
+
+	return event
+}
+
 func defaultEmoji(emoji *string) string {
 	if emoji == nil || *emoji == "" {
 		return "📝"
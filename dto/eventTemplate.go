@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+func ToEventTemplateModel(
+	input model.CreateEventTemplateInput,
+	userID uuid.UUID,
+) *models.CalendarEventTemplate {
+
+	now := time.Now()
+
+	template := &models.CalendarEventTemplate{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Name:            input.Name,
+		Title:           input.Title,
+		Emoji:           defaultEmoji(input.Emoji),
+		Description:     derefString(input.Description),
+		Visibility:      derefVisibility(input.Visibility),
+		DurationMinutes: input.DurationMinutes,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	template.Todos = toTemplateTodos(template.ID, input.Todos, now)
+
+	return template
+}
+
+func UpdateEventTemplateModelFromInput(
+	template *models.CalendarEventTemplate,
+	input model.UpdateEventTemplateInput,
+) {
+	if input.Name != nil {
+		template.Name = *input.Name
+	}
+
+	if input.Title != nil {
+		template.Title = *input.Title
+	}
+
+	if input.Emoji != nil {
+		template.Emoji = *input.Emoji
+	}
+
+	if input.Description != nil {
+		template.Description = *input.Description
+	}
+
+	if input.Visibility != nil {
+		template.Visibility = derefVisibility(input.Visibility)
+	}
+
+	if input.DurationMinutes != nil {
+		template.DurationMinutes = *input.DurationMinutes
+	}
+
+	// 🔹 Todos는 "전체 교체" 전략
+	if input.Todos != nil {
+		template.Todos = toTemplateTodos(template.ID, input.Todos, time.Now())
+	}
+
+	template.UpdatedAt = time.Now()
+}
+
+// ToCreateCalendarInputFromTemplate: 템플릿을 startAt 기준 일정 생성 입력으로 변환합니다.
+// 결과는 ToCalendarModel에 그대로 넘겨 새 ID의 Event/Todo를 만듭니다.
+func ToCreateCalendarInputFromTemplate(
+	template *models.CalendarEventTemplate,
+	startAt time.Time,
+) model.CreateCalendarInput {
+
+	visibility := model.CalendarVisibility(template.Visibility)
+	emoji := template.Emoji
+	description := template.Description
+
+	input := model.CreateCalendarInput{
+		Title:       template.Title,
+		Emoji:       &emoji,
+		Description: &description,
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Duration(template.DurationMinutes) * time.Minute),
+		Visibility:  &visibility,
+		Todos:       make([]*model.CreateTodoInput, 0, len(template.Todos)),
+	}
+
+	for _, t := range template.Todos {
+		input.Todos = append(input.Todos, &model.CreateTodoInput{
+			Content: t.Content,
+		})
+	}
+
+	return input
+}
+
+func toTemplateTodos(
+	templateID uuid.UUID,
+	inputs []*model.CreateTodoInput,
+	now time.Time,
+) []models.CalendarEventTemplateTodo {
+
+	todos := make([]models.CalendarEventTemplateTodo, 0, len(inputs))
+	for i, t := range inputs {
+		todos = append(todos, models.CalendarEventTemplateTodo{
+			ID:         uuid.New(),
+			TemplateID: templateID,
+			Content:    t.Content,
+			Position:   int32(i),
+			CreatedAt:  now,
+		})
+	}
+	return todos
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
 	"github.com/rainbow96bear/planet_utils/pb"
 )
 
@@ -12,6 +13,8 @@ type CreateProfileRequest struct {
 	Bio          string    `json:"bio"`
 	ProfileImage string    `json:"profile_image"`
 	Theme        string    `json:"theme"` // JSON 문자열 또는 미리 정의된 테마 값
+
+	AutoSuffixNickname bool `json:"-"` // 닉네임이 이미 사용 중이면 숫자 suffix 를 붙여 생성 (gRPC: x-nickname-auto-suffix metadata)
 }
 
 // 회원가입 응답 DTO
@@ -24,6 +27,8 @@ type ProfileResponse struct {
 	Theme        string    `json:"theme"`
 	CreatedAt    string    `json:"created_at"`
 	UpdatedAt    string    `json:"updated_at"`
+
+	AlreadyExisted bool `json:"-"` // 같은 UserID 의 재요청으로 기존 프로필을 반환한 경우
 }
 
 type ProfileUpdate struct {
@@ -32,6 +37,8 @@ type ProfileUpdate struct {
 	Bio          *string   `json:"bio,omitempty"`           // nil이면 업데이트하지 않음
 	ProfileImage *string   `json:"profile_image,omitempty"` // nil이면 업데이트하지 않음
 	Theme        *string   `json:"Theme,omitempty"`         // nil이면 업데이트하지 않음
+
+	ExpectedVersion *int32 `json:"-"` // nil이 아니면 version 일치 시에만 업데이트
 }
 
 type UserProfile struct {
@@ -43,6 +50,21 @@ type UserProfile struct {
 	Theme          string    `json:"theme"`
 	FollowerCount  int32     `json:"follower_count"`
 	FollowingCount int32     `json:"following_count"`
+	Version        int32     `json:"version"`
+}
+
+func ToUserProfile(profile *models.Profile) *UserProfile {
+	return &UserProfile{
+		ID:             profile.ID,
+		UserID:         profile.UserID,
+		Nickname:       profile.Nickname,
+		Bio:            profile.Bio,
+		ProfileImage:   profile.ProfileImage,
+		FollowerCount:  profile.FollowerCount,
+		FollowingCount: profile.FollowingCount,
+		Theme:          profile.Theme,
+		Version:        profile.Version,
+	}
 }
 
 func FromGrpcCreateUserRequest(req *pb.CreateUserRequest) (CreateProfileRequest, error) {
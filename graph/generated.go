@@ -41,9 +41,12 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Calendar() CalendarResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 	Todo() TodoResolver
+	UserProfile() UserProfileResolver
 }
 
 type DirectiveRoot struct {
@@ -60,16 +63,45 @@ type ComplexityRoot struct {
 		Title       func(childComplexity int) int
 		Todos       func(childComplexity int) int
 		UpdatedAt   func(childComplexity int) int
+		Version     func(childComplexity int) int
 		Visibility  func(childComplexity int) int
 	}
 
+	CalendarEventChange struct {
+		Action  func(childComplexity int) int
+		Event   func(childComplexity int) int
+		EventID func(childComplexity int) int
+	}
+
+	EventTemplate struct {
+		CreatedAt       func(childComplexity int) int
+		Description     func(childComplexity int) int
+		DurationMinutes func(childComplexity int) int
+		Emoji           func(childComplexity int) int
+		ID              func(childComplexity int) int
+		Name            func(childComplexity int) int
+		Title           func(childComplexity int) int
+		Todos           func(childComplexity int) int
+		UpdatedAt       func(childComplexity int) int
+		Visibility      func(childComplexity int) int
+	}
+
+	EventTemplateTodo struct {
+		Content func(childComplexity int) int
+	}
+
 	Mutation struct {
-		CreateCalendarEvent func(childComplexity int, input model.CreateCalendarInput) int
-		DeleteCalendarEvent func(childComplexity int, eventID string) int
-		Empty               func(childComplexity int) int
-		UpdateCalendarEvent func(childComplexity int, eventID string, input model.UpdateCalendarInput) int
-		UpdateMyProfile     func(childComplexity int, input model.UpdateProfileInput) int
-		UpdateTodoDone      func(childComplexity int, id string, isDone bool) int
+		CreateCalendarEvent     func(childComplexity int, input model.CreateCalendarInput) int
+		CreateEventFromTemplate func(childComplexity int, templateID string, startAt time.Time) int
+		CreateEventTemplate     func(childComplexity int, input model.CreateEventTemplateInput) int
+		DeleteCalendarEvent     func(childComplexity int, eventID string) int
+		DeleteEventTemplate     func(childComplexity int, templateID string) int
+		DuplicateCalendarEvent  func(childComplexity int, eventID string, newStartAt time.Time) int
+		Empty                   func(childComplexity int) int
+		UpdateCalendarEvent     func(childComplexity int, eventID string, input model.UpdateCalendarInput, expectedVersion *int32) int
+		UpdateEventTemplate     func(childComplexity int, templateID string, input model.UpdateEventTemplateInput) int
+		UpdateMyProfile         func(childComplexity int, input model.UpdateProfileInput, expectedVersion *int32) int
+		UpdateTodoDone          func(childComplexity int, id string, isDone bool, expectedVersion *int32) int
 	}
 
 	NicknameAvailability struct {
@@ -83,12 +115,19 @@ type ComplexityRoot struct {
 		MyCalendarEvent           func(childComplexity int, eventID string) int
 		MyCalendarEvents          func(childComplexity int, year int32, month int32) int
 		MyCalendarEventsByDate    func(childComplexity int, date time.Time) int
+		MyEventTemplate           func(childComplexity int, templateID string) int
+		MyEventTemplates          func(childComplexity int) int
 		MyProfile                 func(childComplexity int) int
 		Todo                      func(childComplexity int, id string) int
 		UserCalendarEvents        func(childComplexity int, userID string, year int32, month int32) int
 		UserProfile               func(childComplexity int, userID string) int
 	}
 
+	Subscription struct {
+		CalendarEventChanged func(childComplexity int, calendarID string) int
+		TodoChanged          func(childComplexity int, eventID string) int
+	}
+
 	Todo struct {
 		CalendarEventID func(childComplexity int) int
 		Content         func(childComplexity int) int
@@ -96,11 +135,20 @@ type ComplexityRoot struct {
 		ID              func(childComplexity int) int
 		IsDone          func(childComplexity int) int
 		UpdatedAt       func(childComplexity int) int
+		Version         func(childComplexity int) int
+	}
+
+	TodoChange struct {
+		Action  func(childComplexity int) int
+		EventID func(childComplexity int) int
+		Todo    func(childComplexity int) int
+		TodoID  func(childComplexity int) int
 	}
 
 	UserProfile struct {
 		Bio            func(childComplexity int) int
 		CreatedAt      func(childComplexity int) int
+		FollowedByMe   func(childComplexity int) int
 		FollowerCount  func(childComplexity int) int
 		FollowingCount func(childComplexity int) int
 		ID             func(childComplexity int) int
@@ -109,16 +157,25 @@ type ComplexityRoot struct {
 		Theme          func(childComplexity int) int
 		UpdatedAt      func(childComplexity int) int
 		UserID         func(childComplexity int) int
+		Version        func(childComplexity int) int
 	}
 }
 
+type CalendarResolver interface {
+	Todos(ctx context.Context, obj *model.Calendar) ([]*models.Todo, error)
+}
 type MutationResolver interface {
 	Empty(ctx context.Context) (*string, error)
 	CreateCalendarEvent(ctx context.Context, input model.CreateCalendarInput) (*model.Calendar, error)
-	UpdateCalendarEvent(ctx context.Context, eventID string, input model.UpdateCalendarInput) (*model.Calendar, error)
+	UpdateCalendarEvent(ctx context.Context, eventID string, input model.UpdateCalendarInput, expectedVersion *int32) (*model.Calendar, error)
 	DeleteCalendarEvent(ctx context.Context, eventID string) (bool, error)
-	UpdateMyProfile(ctx context.Context, input model.UpdateProfileInput) (*model.UserProfile, error)
-	UpdateTodoDone(ctx context.Context, id string, isDone bool) (*models.Todo, error)
+	DuplicateCalendarEvent(ctx context.Context, eventID string, newStartAt time.Time) (*model.Calendar, error)
+	CreateEventTemplate(ctx context.Context, input model.CreateEventTemplateInput) (*model.EventTemplate, error)
+	UpdateEventTemplate(ctx context.Context, templateID string, input model.UpdateEventTemplateInput) (*model.EventTemplate, error)
+	DeleteEventTemplate(ctx context.Context, templateID string) (bool, error)
+	CreateEventFromTemplate(ctx context.Context, templateID string, startAt time.Time) (*model.Calendar, error)
+	UpdateMyProfile(ctx context.Context, input model.UpdateProfileInput, expectedVersion *int32) (*model.UserProfile, error)
+	UpdateTodoDone(ctx context.Context, id string, isDone bool, expectedVersion *int32) (*models.Todo, error)
 }
 type QueryResolver interface {
 	Empty(ctx context.Context) (*string, error)
@@ -126,15 +183,24 @@ type QueryResolver interface {
 	MyCalendarEvent(ctx context.Context, eventID string) (*model.Calendar, error)
 	MyCalendarEventsByDate(ctx context.Context, date time.Time) ([]*model.Calendar, error)
 	UserCalendarEvents(ctx context.Context, userID string, year int32, month int32) ([]*model.Calendar, error)
+	MyEventTemplates(ctx context.Context) ([]*model.EventTemplate, error)
+	MyEventTemplate(ctx context.Context, templateID string) (*model.EventTemplate, error)
 	CheckNicknameAvailability(ctx context.Context, nickname string) (*model.NicknameAvailability, error)
 	MyProfile(ctx context.Context) (*model.UserProfile, error)
 	UserProfile(ctx context.Context, userID string) (*model.UserProfile, error)
 	Todo(ctx context.Context, id string) (*models.Todo, error)
 }
+type SubscriptionResolver interface {
+	CalendarEventChanged(ctx context.Context, calendarID string) (<-chan *model.CalendarEventChange, error)
+	TodoChanged(ctx context.Context, eventID string) (<-chan *model.TodoChange, error)
+}
 type TodoResolver interface {
 	ID(ctx context.Context, obj *models.Todo) (string, error)
 	CalendarEventID(ctx context.Context, obj *models.Todo) (*string, error)
 }
+type UserProfileResolver interface {
+	FollowedByMe(ctx context.Context, obj *model.UserProfile) (bool, error)
+}
 
 type executableSchema struct {
 	schema     *ast.Schema
@@ -209,6 +275,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Calendar.UpdatedAt(childComplexity), true
+	case "Calendar.version":
+		if e.complexity.Calendar.Version == nil {
+			break
+		}
+
+		return e.complexity.Calendar.Version(childComplexity), true
 	case "Calendar.visibility":
 		if e.complexity.Calendar.Visibility == nil {
 			break
@@ -216,6 +288,93 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Calendar.Visibility(childComplexity), true
 
+	case "CalendarEventChange.action":
+		if e.complexity.CalendarEventChange.Action == nil {
+			break
+		}
+
+		return e.complexity.CalendarEventChange.Action(childComplexity), true
+	case "CalendarEventChange.event":
+		if e.complexity.CalendarEventChange.Event == nil {
+			break
+		}
+
+		return e.complexity.CalendarEventChange.Event(childComplexity), true
+	case "CalendarEventChange.eventId":
+		if e.complexity.CalendarEventChange.EventID == nil {
+			break
+		}
+
+		return e.complexity.CalendarEventChange.EventID(childComplexity), true
+
+	case "EventTemplate.createdAt":
+		if e.complexity.EventTemplate.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.CreatedAt(childComplexity), true
+	case "EventTemplate.description":
+		if e.complexity.EventTemplate.Description == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Description(childComplexity), true
+	case "EventTemplate.durationMinutes":
+		if e.complexity.EventTemplate.DurationMinutes == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.DurationMinutes(childComplexity), true
+	case "EventTemplate.emoji":
+		if e.complexity.EventTemplate.Emoji == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Emoji(childComplexity), true
+	case "EventTemplate.id":
+		if e.complexity.EventTemplate.ID == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.ID(childComplexity), true
+	case "EventTemplate.name":
+		if e.complexity.EventTemplate.Name == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Name(childComplexity), true
+	case "EventTemplate.title":
+		if e.complexity.EventTemplate.Title == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Title(childComplexity), true
+	case "EventTemplate.todos":
+		if e.complexity.EventTemplate.Todos == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Todos(childComplexity), true
+	case "EventTemplate.updatedAt":
+		if e.complexity.EventTemplate.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.UpdatedAt(childComplexity), true
+	case "EventTemplate.visibility":
+		if e.complexity.EventTemplate.Visibility == nil {
+			break
+		}
+
+		return e.complexity.EventTemplate.Visibility(childComplexity), true
+
+	case "EventTemplateTodo.content":
+		if e.complexity.EventTemplateTodo.Content == nil {
+			break
+		}
+
+		return e.complexity.EventTemplateTodo.Content(childComplexity), true
+
 	case "Mutation.createCalendarEvent":
 		if e.complexity.Mutation.CreateCalendarEvent == nil {
 			break
@@ -227,6 +386,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.CreateCalendarEvent(childComplexity, args["input"].(model.CreateCalendarInput)), true
+	case "Mutation.createEventFromTemplate":
+		if e.complexity.Mutation.CreateEventFromTemplate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createEventFromTemplate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateEventFromTemplate(childComplexity, args["templateId"].(string), args["startAt"].(time.Time)), true
+	case "Mutation.createEventTemplate":
+		if e.complexity.Mutation.CreateEventTemplate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_createEventTemplate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.CreateEventTemplate(childComplexity, args["input"].(model.CreateEventTemplateInput)), true
 	case "Mutation.deleteCalendarEvent":
 		if e.complexity.Mutation.DeleteCalendarEvent == nil {
 			break
@@ -238,6 +419,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.DeleteCalendarEvent(childComplexity, args["eventId"].(string)), true
+	case "Mutation.deleteEventTemplate":
+		if e.complexity.Mutation.DeleteEventTemplate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_deleteEventTemplate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DeleteEventTemplate(childComplexity, args["templateId"].(string)), true
+	case "Mutation.duplicateCalendarEvent":
+		if e.complexity.Mutation.DuplicateCalendarEvent == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_duplicateCalendarEvent_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DuplicateCalendarEvent(childComplexity, args["eventId"].(string), args["newStartAt"].(time.Time)), true
 	case "Mutation._empty":
 		if e.complexity.Mutation.Empty == nil {
 			break
@@ -254,7 +457,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateCalendarEvent(childComplexity, args["eventId"].(string), args["input"].(model.UpdateCalendarInput)), true
+		return e.complexity.Mutation.UpdateCalendarEvent(childComplexity, args["eventId"].(string), args["input"].(model.UpdateCalendarInput), args["expectedVersion"].(*int32)), true
+	case "Mutation.updateEventTemplate":
+		if e.complexity.Mutation.UpdateEventTemplate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_updateEventTemplate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UpdateEventTemplate(childComplexity, args["templateId"].(string), args["input"].(model.UpdateEventTemplateInput)), true
 	case "Mutation.updateMyProfile":
 		if e.complexity.Mutation.UpdateMyProfile == nil {
 			break
@@ -265,7 +479,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateMyProfile(childComplexity, args["input"].(model.UpdateProfileInput)), true
+		return e.complexity.Mutation.UpdateMyProfile(childComplexity, args["input"].(model.UpdateProfileInput), args["expectedVersion"].(*int32)), true
 	case "Mutation.updateTodoDone":
 		if e.complexity.Mutation.UpdateTodoDone == nil {
 			break
@@ -276,7 +490,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.UpdateTodoDone(childComplexity, args["id"].(string), args["isDone"].(bool)), true
+		return e.complexity.Mutation.UpdateTodoDone(childComplexity, args["id"].(string), args["isDone"].(bool), args["expectedVersion"].(*int32)), true
 
 	case "NicknameAvailability.available":
 		if e.complexity.NicknameAvailability.Available == nil {
@@ -341,6 +555,23 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.MyCalendarEventsByDate(childComplexity, args["date"].(time.Time)), true
+	case "Query.myEventTemplate":
+		if e.complexity.Query.MyEventTemplate == nil {
+			break
+		}
+
+		args, err := ec.field_Query_myEventTemplate_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.MyEventTemplate(childComplexity, args["templateId"].(string)), true
+	case "Query.myEventTemplates":
+		if e.complexity.Query.MyEventTemplates == nil {
+			break
+		}
+
+		return e.complexity.Query.MyEventTemplates(childComplexity), true
 	case "Query.myProfile":
 		if e.complexity.Query.MyProfile == nil {
 			break
@@ -381,6 +612,29 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Query.UserProfile(childComplexity, args["userId"].(string)), true
 
+	case "Subscription.calendarEventChanged":
+		if e.complexity.Subscription.CalendarEventChanged == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_calendarEventChanged_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.CalendarEventChanged(childComplexity, args["calendarId"].(string)), true
+	case "Subscription.todoChanged":
+		if e.complexity.Subscription.TodoChanged == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_todoChanged_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.TodoChanged(childComplexity, args["eventId"].(string)), true
+
 	case "Todo.calendarEventId":
 		if e.complexity.Todo.CalendarEventID == nil {
 			break
@@ -417,6 +671,37 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Todo.UpdatedAt(childComplexity), true
+	case "Todo.version":
+		if e.complexity.Todo.Version == nil {
+			break
+		}
+
+		return e.complexity.Todo.Version(childComplexity), true
+
+	case "TodoChange.action":
+		if e.complexity.TodoChange.Action == nil {
+			break
+		}
+
+		return e.complexity.TodoChange.Action(childComplexity), true
+	case "TodoChange.eventId":
+		if e.complexity.TodoChange.EventID == nil {
+			break
+		}
+
+		return e.complexity.TodoChange.EventID(childComplexity), true
+	case "TodoChange.todo":
+		if e.complexity.TodoChange.Todo == nil {
+			break
+		}
+
+		return e.complexity.TodoChange.Todo(childComplexity), true
+	case "TodoChange.todoId":
+		if e.complexity.TodoChange.TodoID == nil {
+			break
+		}
+
+		return e.complexity.TodoChange.TodoID(childComplexity), true
 
 	case "UserProfile.bio":
 		if e.complexity.UserProfile.Bio == nil {
@@ -430,6 +715,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.UserProfile.CreatedAt(childComplexity), true
+	case "UserProfile.followedByMe":
+		if e.complexity.UserProfile.FollowedByMe == nil {
+			break
+		}
+
+		return e.complexity.UserProfile.FollowedByMe(childComplexity), true
 	case "UserProfile.followerCount":
 		if e.complexity.UserProfile.FollowerCount == nil {
 			break
@@ -478,6 +769,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.UserProfile.UserID(childComplexity), true
+	case "UserProfile.version":
+		if e.complexity.UserProfile.Version == nil {
+			break
+		}
+
+		return e.complexity.UserProfile.Version(childComplexity), true
 
 	}
 	return 0, false
@@ -488,8 +785,10 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	ec := executionContext{opCtx, e, 0, 0, make(chan graphql.DeferredResult)}
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
 		ec.unmarshalInputCreateCalendarInput,
+		ec.unmarshalInputCreateEventTemplateInput,
 		ec.unmarshalInputCreateTodoInput,
 		ec.unmarshalInputUpdateCalendarInput,
+		ec.unmarshalInputUpdateEventTemplateInput,
 		ec.unmarshalInputUpdateProfileInput,
 		ec.unmarshalInputUpdateTodoInput,
 	)
@@ -537,6 +836,23 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, opCtx.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next(ctx)
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
 			return &graphql.Response{
 				Data: buf.Bytes(),
 			}
@@ -588,7 +904,7 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 	return introspection.WrapTypeFromDef(ec.Schema(), ec.Schema().Types[name]), nil
 }
 
-//go:embed "graphqls/calendarEvent.graphqls" "graphqls/common.graphqls" "graphqls/nickname.graphqls" "graphqls/profile.graphqls" "graphqls/todo.graphqls"
+//go:embed "graphqls/calendarEvent.graphqls" "graphqls/common.graphqls" "graphqls/eventTemplate.graphqls" "graphqls/nickname.graphqls" "graphqls/profile.graphqls" "graphqls/subscription.graphqls" "graphqls/todo.graphqls"
 var sourcesFS embed.FS
 
 func sourceData(filename string) string {
@@ -602,8 +918,10 @@ func sourceData(filename string) string {
 var sources = []*ast.Source{
 	{Name: "graphqls/calendarEvent.graphqls", Input: sourceData("graphqls/calendarEvent.graphqls"), BuiltIn: false},
 	{Name: "graphqls/common.graphqls", Input: sourceData("graphqls/common.graphqls"), BuiltIn: false},
+	{Name: "graphqls/eventTemplate.graphqls", Input: sourceData("graphqls/eventTemplate.graphqls"), BuiltIn: false},
 	{Name: "graphqls/nickname.graphqls", Input: sourceData("graphqls/nickname.graphqls"), BuiltIn: false},
 	{Name: "graphqls/profile.graphqls", Input: sourceData("graphqls/profile.graphqls"), BuiltIn: false},
+	{Name: "graphqls/subscription.graphqls", Input: sourceData("graphqls/subscription.graphqls"), BuiltIn: false},
 	{Name: "graphqls/todo.graphqls", Input: sourceData("graphqls/todo.graphqls"), BuiltIn: false},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
@@ -623,6 +941,33 @@ func (ec *executionContext) field_Mutation_createCalendarEvent_args(ctx context.
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_createEventFromTemplate_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "templateId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["templateId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "startAt", ec.unmarshalNTime2timeᚐTime)
+	if err != nil {
+		return nil, err
+	}
+	args["startAt"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_createEventTemplate_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNCreateEventTemplateInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateEventTemplateInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_deleteCalendarEvent_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -634,6 +979,33 @@ func (ec *executionContext) field_Mutation_deleteCalendarEvent_args(ctx context.
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_deleteEventTemplate_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "templateId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["templateId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_duplicateCalendarEvent_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "eventId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["eventId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "newStartAt", ec.unmarshalNTime2timeᚐTime)
+	if err != nil {
+		return nil, err
+	}
+	args["newStartAt"] = arg1
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_updateCalendarEvent_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -647,6 +1019,27 @@ func (ec *executionContext) field_Mutation_updateCalendarEvent_args(ctx context.
 		return nil, err
 	}
 	args["input"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "expectedVersion", ec.unmarshalOInt2ᚖint32)
+	if err != nil {
+		return nil, err
+	}
+	args["expectedVersion"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_updateEventTemplate_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "templateId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["templateId"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNUpdateEventTemplateInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateEventTemplateInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg1
 	return args, nil
 }
 
@@ -658,6 +1051,11 @@ func (ec *executionContext) field_Mutation_updateMyProfile_args(ctx context.Cont
 		return nil, err
 	}
 	args["input"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "expectedVersion", ec.unmarshalOInt2ᚖint32)
+	if err != nil {
+		return nil, err
+	}
+	args["expectedVersion"] = arg1
 	return args, nil
 }
 
@@ -674,6 +1072,11 @@ func (ec *executionContext) field_Mutation_updateTodoDone_args(ctx context.Conte
 		return nil, err
 	}
 	args["isDone"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "expectedVersion", ec.unmarshalOInt2ᚖint32)
+	if err != nil {
+		return nil, err
+	}
+	args["expectedVersion"] = arg2
 	return args, nil
 }
 
@@ -737,6 +1140,17 @@ func (ec *executionContext) field_Query_myCalendarEvents_args(ctx context.Contex
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_myEventTemplate_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "templateId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["templateId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_todo_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -780,6 +1194,28 @@ func (ec *executionContext) field_Query_userProfile_args(ctx context.Context, ra
 	return args, nil
 }
 
+func (ec *executionContext) field_Subscription_calendarEventChanged_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "calendarId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["calendarId"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_todoChanged_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "eventId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["eventId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1042,7 +1478,7 @@ func (ec *executionContext) _Calendar_todos(ctx context.Context, field graphql.C
 		field,
 		ec.fieldContext_Calendar_todos,
 		func(ctx context.Context) (any, error) {
-			return obj.Todos, nil
+			return ec.resolvers.Calendar().Todos(ctx, obj)
 		},
 		nil,
 		ec.marshalNTodo2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋinternalᚋmodelsᚐTodoᚄ,
@@ -1055,8 +1491,8 @@ func (ec *executionContext) fieldContext_Calendar_todos(_ context.Context, field
 	fc = &graphql.FieldContext{
 		Object:     "Calendar",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
@@ -1067,6 +1503,8 @@ func (ec *executionContext) fieldContext_Calendar_todos(_ context.Context, field
 				return ec.fieldContext_Todo_content(ctx, field)
 			case "isDone":
 				return ec.fieldContext_Todo_isDone(ctx, field)
+			case "version":
+				return ec.fieldContext_Todo_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Todo_createdAt(ctx, field)
 			case "updatedAt":
@@ -1078,28 +1516,462 @@ func (ec *executionContext) fieldContext_Calendar_todos(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Calendar_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.Calendar) (ret graphql.Marshaler) {
+func (ec *executionContext) _Calendar_version(ctx context.Context, field graphql.CollectedField, obj *model.Calendar) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Calendar_createdAt,
+		ec.fieldContext_Calendar_version,
 		func(ctx context.Context) (any, error) {
-			return obj.CreatedAt, nil
+			return obj.Version, nil
 		},
 		nil,
-		ec.marshalNTime2timeᚐTime,
+		ec.marshalNInt2int32,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Calendar_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Calendar_version(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Calendar",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Calendar_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.Calendar) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Calendar_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
+		},
+		nil,
+		ec.marshalNTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Calendar_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Calendar",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Calendar_updatedAt(ctx context.Context, field graphql.CollectedField, obj *model.Calendar) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Calendar_updatedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.UpdatedAt, nil
+		},
+		nil,
+		ec.marshalNTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Calendar_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Calendar",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalendarEventChange_action(ctx context.Context, field graphql.CollectedField, obj *model.CalendarEventChange) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_CalendarEventChange_action,
+		func(ctx context.Context) (any, error) {
+			return obj.Action, nil
+		},
+		nil,
+		ec.marshalNChangeAction2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐChangeAction,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_CalendarEventChange_action(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalendarEventChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ChangeAction does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalendarEventChange_eventId(ctx context.Context, field graphql.CollectedField, obj *model.CalendarEventChange) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_CalendarEventChange_eventId,
+		func(ctx context.Context) (any, error) {
+			return obj.EventID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_CalendarEventChange_eventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalendarEventChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _CalendarEventChange_event(ctx context.Context, field graphql.CollectedField, obj *model.CalendarEventChange) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_CalendarEventChange_event,
+		func(ctx context.Context) (any, error) {
+			return obj.Event, nil
+		},
+		nil,
+		ec.marshalOCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_CalendarEventChange_event(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "CalendarEventChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_id(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_name(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_title(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_title,
+		func(ctx context.Context) (any, error) {
+			return obj.Title, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_title(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_emoji(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_emoji,
+		func(ctx context.Context) (any, error) {
+			return obj.Emoji, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_emoji(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_description(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_visibility(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_visibility,
+		func(ctx context.Context) (any, error) {
+			return obj.Visibility, nil
+		},
+		nil,
+		ec.marshalNCalendarVisibility2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarVisibility,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_visibility(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type CalendarVisibility does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_durationMinutes(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_durationMinutes,
+		func(ctx context.Context) (any, error) {
+			return obj.DurationMinutes, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_durationMinutes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_todos(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_todos,
+		func(ctx context.Context) (any, error) {
+			return obj.Todos, nil
+		},
+		nil,
+		ec.marshalNEventTemplateTodo2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateTodoᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_todos(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "content":
+				return ec.fieldContext_EventTemplateTodo_content(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EventTemplateTodo", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventTemplate_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplate_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
+		},
+		nil,
+		ec.marshalNTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplate_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplate",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type Time does not have child fields")
 		},
@@ -1107,12 +1979,12 @@ func (ec *executionContext) fieldContext_Calendar_createdAt(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Calendar_updatedAt(ctx context.Context, field graphql.CollectedField, obj *model.Calendar) (ret graphql.Marshaler) {
+func (ec *executionContext) _EventTemplate_updatedAt(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplate) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Calendar_updatedAt,
+		ec.fieldContext_EventTemplate_updatedAt,
 		func(ctx context.Context) (any, error) {
 			return obj.UpdatedAt, nil
 		},
@@ -1123,9 +1995,9 @@ func (ec *executionContext) _Calendar_updatedAt(ctx context.Context, field graph
 	)
 }
 
-func (ec *executionContext) fieldContext_Calendar_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EventTemplate_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Calendar",
+		Object:     "EventTemplate",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1136,6 +2008,35 @@ func (ec *executionContext) fieldContext_Calendar_updatedAt(_ context.Context, f
 	return fc, nil
 }
 
+func (ec *executionContext) _EventTemplateTodo_content(ctx context.Context, field graphql.CollectedField, obj *model.EventTemplateTodo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventTemplateTodo_content,
+		func(ctx context.Context) (any, error) {
+			return obj.Content, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventTemplateTodo_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventTemplateTodo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation__empty(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1165,15 +2066,186 @@ func (ec *executionContext) fieldContext_Mutation__empty(_ context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_createCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_createCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_createCalendarEvent,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().CreateCalendarEvent(ctx, fc.Args["input"].(model.CreateCalendarInput))
+		},
+		nil,
+		ec.marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_createCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_updateCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_updateCalendarEvent,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UpdateCalendarEvent(ctx, fc.Args["eventId"].(string), fc.Args["input"].(model.UpdateCalendarInput), fc.Args["expectedVersion"].(*int32))
+		},
+		nil,
+		ec.marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_deleteCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_deleteCalendarEvent,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().DeleteCalendarEvent(ctx, fc.Args["eventId"].(string))
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_deleteCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_deleteCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_duplicateCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_createCalendarEvent,
+		ec.fieldContext_Mutation_duplicateCalendarEvent,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().CreateCalendarEvent(ctx, fc.Args["input"].(model.CreateCalendarInput))
+			return ec.resolvers.Mutation().DuplicateCalendarEvent(ctx, fc.Args["eventId"].(string), fc.Args["newStartAt"].(time.Time))
 		},
 		nil,
 		ec.marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
@@ -1182,7 +2254,7 @@ func (ec *executionContext) _Mutation_createCalendarEvent(ctx context.Context, f
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_createCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_duplicateCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -1206,6 +2278,8 @@ func (ec *executionContext) fieldContext_Mutation_createCalendarEvent(ctx contex
 				return ec.fieldContext_Calendar_visibility(ctx, field)
 			case "todos":
 				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Calendar_createdAt(ctx, field)
 			case "updatedAt":
@@ -1221,31 +2295,31 @@ func (ec *executionContext) fieldContext_Mutation_createCalendarEvent(ctx contex
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_createCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_duplicateCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_createEventTemplate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_updateCalendarEvent,
+		ec.fieldContext_Mutation_createEventTemplate,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateCalendarEvent(ctx, fc.Args["eventId"].(string), fc.Args["input"].(model.UpdateCalendarInput))
+			return ec.resolvers.Mutation().CreateEventTemplate(ctx, fc.Args["input"].(model.CreateEventTemplateInput))
 		},
 		nil,
-		ec.marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
+		ec.marshalNEventTemplate2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_createEventTemplate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -1254,27 +2328,27 @@ func (ec *executionContext) fieldContext_Mutation_updateCalendarEvent(ctx contex
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Calendar_id(ctx, field)
+				return ec.fieldContext_EventTemplate_id(ctx, field)
+			case "name":
+				return ec.fieldContext_EventTemplate_name(ctx, field)
 			case "title":
-				return ec.fieldContext_Calendar_title(ctx, field)
+				return ec.fieldContext_EventTemplate_title(ctx, field)
 			case "emoji":
-				return ec.fieldContext_Calendar_emoji(ctx, field)
+				return ec.fieldContext_EventTemplate_emoji(ctx, field)
 			case "description":
-				return ec.fieldContext_Calendar_description(ctx, field)
-			case "startAt":
-				return ec.fieldContext_Calendar_startAt(ctx, field)
-			case "endAt":
-				return ec.fieldContext_Calendar_endAt(ctx, field)
+				return ec.fieldContext_EventTemplate_description(ctx, field)
 			case "visibility":
-				return ec.fieldContext_Calendar_visibility(ctx, field)
+				return ec.fieldContext_EventTemplate_visibility(ctx, field)
+			case "durationMinutes":
+				return ec.fieldContext_EventTemplate_durationMinutes(ctx, field)
 			case "todos":
-				return ec.fieldContext_Calendar_todos(ctx, field)
+				return ec.fieldContext_EventTemplate_todos(ctx, field)
 			case "createdAt":
-				return ec.fieldContext_Calendar_createdAt(ctx, field)
+				return ec.fieldContext_EventTemplate_createdAt(ctx, field)
 			case "updatedAt":
-				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+				return ec.fieldContext_EventTemplate_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type EventTemplate", field.Name)
 		},
 	}
 	defer func() {
@@ -1284,22 +2358,85 @@ func (ec *executionContext) fieldContext_Mutation_updateCalendarEvent(ctx contex
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_createEventTemplate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_deleteCalendarEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_updateEventTemplate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_deleteCalendarEvent,
+		ec.fieldContext_Mutation_updateEventTemplate,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().DeleteCalendarEvent(ctx, fc.Args["eventId"].(string))
+			return ec.resolvers.Mutation().UpdateEventTemplate(ctx, fc.Args["templateId"].(string), fc.Args["input"].(model.UpdateEventTemplateInput))
+		},
+		nil,
+		ec.marshalNEventTemplate2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateEventTemplate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_EventTemplate_id(ctx, field)
+			case "name":
+				return ec.fieldContext_EventTemplate_name(ctx, field)
+			case "title":
+				return ec.fieldContext_EventTemplate_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_EventTemplate_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_EventTemplate_description(ctx, field)
+			case "visibility":
+				return ec.fieldContext_EventTemplate_visibility(ctx, field)
+			case "durationMinutes":
+				return ec.fieldContext_EventTemplate_durationMinutes(ctx, field)
+			case "todos":
+				return ec.fieldContext_EventTemplate_todos(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_EventTemplate_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_EventTemplate_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EventTemplate", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateEventTemplate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_deleteEventTemplate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_deleteEventTemplate,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().DeleteEventTemplate(ctx, fc.Args["templateId"].(string))
 		},
 		nil,
 		ec.marshalNBoolean2bool,
@@ -1308,7 +2445,7 @@ func (ec *executionContext) _Mutation_deleteCalendarEvent(ctx context.Context, f
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_deleteCalendarEvent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_deleteEventTemplate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -1325,7 +2462,72 @@ func (ec *executionContext) fieldContext_Mutation_deleteCalendarEvent(ctx contex
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_deleteCalendarEvent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_deleteEventTemplate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_createEventFromTemplate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_createEventFromTemplate,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().CreateEventFromTemplate(ctx, fc.Args["templateId"].(string), fc.Args["startAt"].(time.Time))
+		},
+		nil,
+		ec.marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_createEventFromTemplate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_createEventFromTemplate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
@@ -1340,7 +2542,7 @@ func (ec *executionContext) _Mutation_updateMyProfile(ctx context.Context, field
 		ec.fieldContext_Mutation_updateMyProfile,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateMyProfile(ctx, fc.Args["input"].(model.UpdateProfileInput))
+			return ec.resolvers.Mutation().UpdateMyProfile(ctx, fc.Args["input"].(model.UpdateProfileInput), fc.Args["expectedVersion"].(*int32))
 		},
 		nil,
 		ec.marshalNUserProfile2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUserProfile,
@@ -1373,6 +2575,10 @@ func (ec *executionContext) fieldContext_Mutation_updateMyProfile(ctx context.Co
 				return ec.fieldContext_UserProfile_followerCount(ctx, field)
 			case "followingCount":
 				return ec.fieldContext_UserProfile_followingCount(ctx, field)
+			case "followedByMe":
+				return ec.fieldContext_UserProfile_followedByMe(ctx, field)
+			case "version":
+				return ec.fieldContext_UserProfile_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_UserProfile_createdAt(ctx, field)
 			case "updatedAt":
@@ -1403,7 +2609,7 @@ func (ec *executionContext) _Mutation_updateTodoDone(ctx context.Context, field
 		ec.fieldContext_Mutation_updateTodoDone,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateTodoDone(ctx, fc.Args["id"].(string), fc.Args["isDone"].(bool))
+			return ec.resolvers.Mutation().UpdateTodoDone(ctx, fc.Args["id"].(string), fc.Args["isDone"].(bool), fc.Args["expectedVersion"].(*int32))
 		},
 		nil,
 		ec.marshalNTodo2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋinternalᚋmodelsᚐTodo,
@@ -1428,6 +2634,8 @@ func (ec *executionContext) fieldContext_Mutation_updateTodoDone(ctx context.Con
 				return ec.fieldContext_Todo_content(ctx, field)
 			case "isDone":
 				return ec.fieldContext_Todo_isDone(ctx, field)
+			case "version":
+				return ec.fieldContext_Todo_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Todo_createdAt(ctx, field)
 			case "updatedAt":
@@ -1578,6 +2786,8 @@ func (ec *executionContext) fieldContext_Query_myCalendarEvents(ctx context.Cont
 				return ec.fieldContext_Calendar_visibility(ctx, field)
 			case "todos":
 				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Calendar_createdAt(ctx, field)
 			case "updatedAt":
@@ -1641,6 +2851,8 @@ func (ec *executionContext) fieldContext_Query_myCalendarEvent(ctx context.Conte
 				return ec.fieldContext_Calendar_visibility(ctx, field)
 			case "todos":
 				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Calendar_createdAt(ctx, field)
 			case "updatedAt":
@@ -1674,13 +2886,142 @@ func (ec *executionContext) _Query_myCalendarEventsByDate(ctx context.Context, f
 			return ec.resolvers.Query().MyCalendarEventsByDate(ctx, fc.Args["date"].(time.Time))
 		},
 		nil,
-		ec.marshalNCalendar2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarᚄ,
+		ec.marshalNCalendar2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_myCalendarEventsByDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_myCalendarEventsByDate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_userCalendarEvents(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_userCalendarEvents,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().UserCalendarEvents(ctx, fc.Args["userId"].(string), fc.Args["year"].(int32), fc.Args["month"].(int32))
+		},
+		nil,
+		ec.marshalNCalendar2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_userCalendarEvents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Calendar_id(ctx, field)
+			case "title":
+				return ec.fieldContext_Calendar_title(ctx, field)
+			case "emoji":
+				return ec.fieldContext_Calendar_emoji(ctx, field)
+			case "description":
+				return ec.fieldContext_Calendar_description(ctx, field)
+			case "startAt":
+				return ec.fieldContext_Calendar_startAt(ctx, field)
+			case "endAt":
+				return ec.fieldContext_Calendar_endAt(ctx, field)
+			case "visibility":
+				return ec.fieldContext_Calendar_visibility(ctx, field)
+			case "todos":
+				return ec.fieldContext_Calendar_todos(ctx, field)
+			case "version":
+				return ec.fieldContext_Calendar_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Calendar_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_userCalendarEvents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_myEventTemplates(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_myEventTemplates,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().MyEventTemplates(ctx)
+		},
+		nil,
+		ec.marshalNEventTemplate2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_myCalendarEventsByDate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_myEventTemplates(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -1689,61 +3030,50 @@ func (ec *executionContext) fieldContext_Query_myCalendarEventsByDate(ctx contex
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Calendar_id(ctx, field)
+				return ec.fieldContext_EventTemplate_id(ctx, field)
+			case "name":
+				return ec.fieldContext_EventTemplate_name(ctx, field)
 			case "title":
-				return ec.fieldContext_Calendar_title(ctx, field)
+				return ec.fieldContext_EventTemplate_title(ctx, field)
 			case "emoji":
-				return ec.fieldContext_Calendar_emoji(ctx, field)
+				return ec.fieldContext_EventTemplate_emoji(ctx, field)
 			case "description":
-				return ec.fieldContext_Calendar_description(ctx, field)
-			case "startAt":
-				return ec.fieldContext_Calendar_startAt(ctx, field)
-			case "endAt":
-				return ec.fieldContext_Calendar_endAt(ctx, field)
+				return ec.fieldContext_EventTemplate_description(ctx, field)
 			case "visibility":
-				return ec.fieldContext_Calendar_visibility(ctx, field)
+				return ec.fieldContext_EventTemplate_visibility(ctx, field)
+			case "durationMinutes":
+				return ec.fieldContext_EventTemplate_durationMinutes(ctx, field)
 			case "todos":
-				return ec.fieldContext_Calendar_todos(ctx, field)
+				return ec.fieldContext_EventTemplate_todos(ctx, field)
 			case "createdAt":
-				return ec.fieldContext_Calendar_createdAt(ctx, field)
+				return ec.fieldContext_EventTemplate_createdAt(ctx, field)
 			case "updatedAt":
-				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+				return ec.fieldContext_EventTemplate_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type EventTemplate", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_myCalendarEventsByDate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_userCalendarEvents(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_myEventTemplate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_userCalendarEvents,
+		ec.fieldContext_Query_myEventTemplate,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().UserCalendarEvents(ctx, fc.Args["userId"].(string), fc.Args["year"].(int32), fc.Args["month"].(int32))
+			return ec.resolvers.Query().MyEventTemplate(ctx, fc.Args["templateId"].(string))
 		},
 		nil,
-		ec.marshalNCalendar2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarᚄ,
+		ec.marshalNEventTemplate2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_userCalendarEvents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_myEventTemplate(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -1752,27 +3082,27 @@ func (ec *executionContext) fieldContext_Query_userCalendarEvents(ctx context.Co
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Calendar_id(ctx, field)
+				return ec.fieldContext_EventTemplate_id(ctx, field)
+			case "name":
+				return ec.fieldContext_EventTemplate_name(ctx, field)
 			case "title":
-				return ec.fieldContext_Calendar_title(ctx, field)
+				return ec.fieldContext_EventTemplate_title(ctx, field)
 			case "emoji":
-				return ec.fieldContext_Calendar_emoji(ctx, field)
+				return ec.fieldContext_EventTemplate_emoji(ctx, field)
 			case "description":
-				return ec.fieldContext_Calendar_description(ctx, field)
-			case "startAt":
-				return ec.fieldContext_Calendar_startAt(ctx, field)
-			case "endAt":
-				return ec.fieldContext_Calendar_endAt(ctx, field)
+				return ec.fieldContext_EventTemplate_description(ctx, field)
 			case "visibility":
-				return ec.fieldContext_Calendar_visibility(ctx, field)
+				return ec.fieldContext_EventTemplate_visibility(ctx, field)
+			case "durationMinutes":
+				return ec.fieldContext_EventTemplate_durationMinutes(ctx, field)
 			case "todos":
-				return ec.fieldContext_Calendar_todos(ctx, field)
+				return ec.fieldContext_EventTemplate_todos(ctx, field)
 			case "createdAt":
-				return ec.fieldContext_Calendar_createdAt(ctx, field)
+				return ec.fieldContext_EventTemplate_createdAt(ctx, field)
 			case "updatedAt":
-				return ec.fieldContext_Calendar_updatedAt(ctx, field)
+				return ec.fieldContext_EventTemplate_updatedAt(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Calendar", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type EventTemplate", field.Name)
 		},
 	}
 	defer func() {
@@ -1782,7 +3112,7 @@ func (ec *executionContext) fieldContext_Query_userCalendarEvents(ctx context.Co
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_userCalendarEvents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_myEventTemplate_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
@@ -1876,6 +3206,10 @@ func (ec *executionContext) fieldContext_Query_myProfile(_ context.Context, fiel
 				return ec.fieldContext_UserProfile_followerCount(ctx, field)
 			case "followingCount":
 				return ec.fieldContext_UserProfile_followingCount(ctx, field)
+			case "followedByMe":
+				return ec.fieldContext_UserProfile_followedByMe(ctx, field)
+			case "version":
+				return ec.fieldContext_UserProfile_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_UserProfile_createdAt(ctx, field)
 			case "updatedAt":
@@ -1928,6 +3262,10 @@ func (ec *executionContext) fieldContext_Query_userProfile(ctx context.Context,
 				return ec.fieldContext_UserProfile_followerCount(ctx, field)
 			case "followingCount":
 				return ec.fieldContext_UserProfile_followingCount(ctx, field)
+			case "followedByMe":
+				return ec.fieldContext_UserProfile_followedByMe(ctx, field)
+			case "version":
+				return ec.fieldContext_UserProfile_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_UserProfile_createdAt(ctx, field)
 			case "updatedAt":
@@ -1983,6 +3321,8 @@ func (ec *executionContext) fieldContext_Query_todo(ctx context.Context, field g
 				return ec.fieldContext_Todo_content(ctx, field)
 			case "isDone":
 				return ec.fieldContext_Todo_isDone(ctx, field)
+			case "version":
+				return ec.fieldContext_Todo_version(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Todo_createdAt(ctx, field)
 			case "updatedAt":
@@ -2113,6 +3453,106 @@ func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field
 	return fc, nil
 }
 
+func (ec *executionContext) _Subscription_calendarEventChanged(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
+	return graphql.ResolveFieldStream(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Subscription_calendarEventChanged,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Subscription().CalendarEventChanged(ctx, fc.Args["calendarId"].(string))
+		},
+		nil,
+		ec.marshalNCalendarEventChange2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarEventChange,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Subscription_calendarEventChanged(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Subscription",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "action":
+				return ec.fieldContext_CalendarEventChange_action(ctx, field)
+			case "eventId":
+				return ec.fieldContext_CalendarEventChange_eventId(ctx, field)
+			case "event":
+				return ec.fieldContext_CalendarEventChange_event(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type CalendarEventChange", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_calendarEventChanged_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Subscription_todoChanged(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
+	return graphql.ResolveFieldStream(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Subscription_todoChanged,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Subscription().TodoChanged(ctx, fc.Args["eventId"].(string))
+		},
+		nil,
+		ec.marshalNTodoChange2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐTodoChange,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Subscription_todoChanged(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Subscription",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "action":
+				return ec.fieldContext_TodoChange_action(ctx, field)
+			case "eventId":
+				return ec.fieldContext_TodoChange_eventId(ctx, field)
+			case "todoId":
+				return ec.fieldContext_TodoChange_todoId(ctx, field)
+			case "todo":
+				return ec.fieldContext_TodoChange_todo(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TodoChange", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_todoChanged_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Todo_id(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -2120,168 +3560,329 @@ func (ec *executionContext) _Todo_id(ctx context.Context, field graphql.Collecte
 		field,
 		ec.fieldContext_Todo_id,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Todo().ID(ctx, obj)
+			return ec.resolvers.Todo().ID(ctx, obj)
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Todo_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Todo",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Todo_calendarEventId(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Todo_calendarEventId,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Todo().CalendarEventID(ctx, obj)
+		},
+		nil,
+		ec.marshalOID2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Todo_calendarEventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Todo",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Todo_content(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Todo_content,
+		func(ctx context.Context) (any, error) {
+			return obj.Content, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Todo_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Todo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Todo_isDone(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Todo_isDone,
+		func(ctx context.Context) (any, error) {
+			return obj.IsDone, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Todo_isDone(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Todo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Todo_version(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Todo_version,
+		func(ctx context.Context) (any, error) {
+			return obj.Version, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Todo_version(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Todo",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Todo_createdAt(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Todo_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
 		},
 		nil,
-		ec.marshalNID2string,
+		ec.marshalNTime2timeᚐTime,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Todo_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Todo",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Todo_calendarEventId(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+func (ec *executionContext) _Todo_updatedAt(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Todo_calendarEventId,
+		ec.fieldContext_Todo_updatedAt,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.Todo().CalendarEventID(ctx, obj)
+			return obj.UpdatedAt, nil
 		},
 		nil,
-		ec.marshalOID2ᚖstring,
+		ec.marshalNTime2timeᚐTime,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_calendarEventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Todo_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Todo",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Todo_content(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+func (ec *executionContext) _TodoChange_action(ctx context.Context, field graphql.CollectedField, obj *model.TodoChange) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Todo_content,
+		ec.fieldContext_TodoChange_action,
 		func(ctx context.Context) (any, error) {
-			return obj.Content, nil
+			return obj.Action, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNChangeAction2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐChangeAction,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_content(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_TodoChange_action(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Todo",
+		Object:     "TodoChange",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ChangeAction does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Todo_isDone(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+func (ec *executionContext) _TodoChange_eventId(ctx context.Context, field graphql.CollectedField, obj *model.TodoChange) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Todo_isDone,
+		ec.fieldContext_TodoChange_eventId,
 		func(ctx context.Context) (any, error) {
-			return obj.IsDone, nil
+			return obj.EventID, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_isDone(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_TodoChange_eventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Todo",
+		Object:     "TodoChange",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Todo_createdAt(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+func (ec *executionContext) _TodoChange_todoId(ctx context.Context, field graphql.CollectedField, obj *model.TodoChange) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Todo_createdAt,
+		ec.fieldContext_TodoChange_todoId,
 		func(ctx context.Context) (any, error) {
-			return obj.CreatedAt, nil
+			return obj.TodoID, nil
 		},
 		nil,
-		ec.marshalNTime2timeᚐTime,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_TodoChange_todoId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Todo",
+		Object:     "TodoChange",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Todo_updatedAt(ctx context.Context, field graphql.CollectedField, obj *models.Todo) (ret graphql.Marshaler) {
+func (ec *executionContext) _TodoChange_todo(ctx context.Context, field graphql.CollectedField, obj *model.TodoChange) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Todo_updatedAt,
+		ec.fieldContext_TodoChange_todo,
 		func(ctx context.Context) (any, error) {
-			return obj.UpdatedAt, nil
+			return obj.Todo, nil
 		},
 		nil,
-		ec.marshalNTime2timeᚐTime,
-		true,
+		ec.marshalOTodo2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋinternalᚋmodelsᚐTodo,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Todo_updatedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_TodoChange_todo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Todo",
+		Object:     "TodoChange",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Todo_id(ctx, field)
+			case "calendarEventId":
+				return ec.fieldContext_Todo_calendarEventId(ctx, field)
+			case "content":
+				return ec.fieldContext_Todo_content(ctx, field)
+			case "isDone":
+				return ec.fieldContext_Todo_isDone(ctx, field)
+			case "version":
+				return ec.fieldContext_Todo_version(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Todo_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Todo_updatedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Todo", field.Name)
 		},
 	}
 	return fc, nil
@@ -2519,6 +4120,64 @@ func (ec *executionContext) fieldContext_UserProfile_followingCount(_ context.Co
 	return fc, nil
 }
 
+func (ec *executionContext) _UserProfile_followedByMe(ctx context.Context, field graphql.CollectedField, obj *model.UserProfile) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_UserProfile_followedByMe,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.UserProfile().FollowedByMe(ctx, obj)
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_UserProfile_followedByMe(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UserProfile",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _UserProfile_version(ctx context.Context, field graphql.CollectedField, obj *model.UserProfile) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_UserProfile_version,
+		func(ctx context.Context) (any, error) {
+			return obj.Version, nil
+		},
+		nil,
+		ec.marshalNInt2int32,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_UserProfile_version(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "UserProfile",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _UserProfile_createdAt(ctx context.Context, field graphql.CollectedField, obj *model.UserProfile) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -4034,7 +5693,7 @@ func (ec *executionContext) unmarshalInputCreateCalendarInput(ctx context.Contex
 		asMap["visibility"] = "public"
 	}
 
-	fieldsInOrder := [...]string{"title", "emoji", "description", "startAt", "endAt", "visibility", "todos"}
+	fieldsInOrder := [...]string{"title", "emoji", "description", "startAt", "endAt", "visibility", "todos", "clientMutationId"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -4090,6 +5749,86 @@ func (ec *executionContext) unmarshalInputCreateCalendarInput(ctx context.Contex
 				return it, err
 			}
 			it.Todos = data
+		case "clientMutationId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("clientMutationId"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ClientMutationID = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputCreateEventTemplateInput(ctx context.Context, obj any) (model.CreateEventTemplateInput, error) {
+	var it model.CreateEventTemplateInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	if _, present := asMap["visibility"]; !present {
+		asMap["visibility"] = "public"
+	}
+
+	fieldsInOrder := [...]string{"name", "title", "emoji", "description", "visibility", "durationMinutes", "todos"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "name":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Name = data
+		case "title":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("title"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Title = data
+		case "emoji":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("emoji"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Emoji = data
+		case "description":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("description"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Description = data
+		case "visibility":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("visibility"))
+			data, err := ec.unmarshalOCalendarVisibility2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarVisibility(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Visibility = data
+		case "durationMinutes":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("durationMinutes"))
+			data, err := ec.unmarshalNInt2int32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DurationMinutes = data
+		case "todos":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("todos"))
+			data, err := ec.unmarshalOCreateTodoInput2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateTodoInputᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Todos = data
 		}
 	}
 
@@ -4181,7 +5920,76 @@ func (ec *executionContext) unmarshalInputUpdateCalendarInput(ctx context.Contex
 			it.Visibility = data
 		case "todos":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("todos"))
-			data, err := ec.unmarshalOUpdateTodoInput2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateTodoInputᚄ(ctx, v)
+			data, err := ec.unmarshalOUpdateTodoInput2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateTodoInputᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Todos = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputUpdateEventTemplateInput(ctx context.Context, obj any) (model.UpdateEventTemplateInput, error) {
+	var it model.UpdateEventTemplateInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"name", "title", "emoji", "description", "visibility", "durationMinutes", "todos"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "name":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Name = data
+		case "title":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("title"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Title = data
+		case "emoji":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("emoji"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Emoji = data
+		case "description":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("description"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Description = data
+		case "visibility":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("visibility"))
+			data, err := ec.unmarshalOCalendarVisibility2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarVisibility(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Visibility = data
+		case "durationMinutes":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("durationMinutes"))
+			data, err := ec.unmarshalOInt2ᚖint32(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DurationMinutes = data
+		case "todos":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("todos"))
+			data, err := ec.unmarshalOCreateTodoInput2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateTodoInputᚄ(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -4303,12 +6111,12 @@ func (ec *executionContext) _Calendar(ctx context.Context, sel ast.SelectionSet,
 		case "id":
 			out.Values[i] = ec._Calendar_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "title":
 			out.Values[i] = ec._Calendar_title(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "emoji":
 			out.Values[i] = ec._Calendar_emoji(ctx, field, obj)
@@ -4317,30 +6125,229 @@ func (ec *executionContext) _Calendar(ctx context.Context, sel ast.SelectionSet,
 		case "startAt":
 			out.Values[i] = ec._Calendar_startAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "endAt":
 			out.Values[i] = ec._Calendar_endAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "visibility":
 			out.Values[i] = ec._Calendar_visibility(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "todos":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Calendar_todos(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "version":
+			out.Values[i] = ec._Calendar_version(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "createdAt":
+			out.Values[i] = ec._Calendar_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "updatedAt":
+			out.Values[i] = ec._Calendar_updatedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var calendarEventChangeImplementors = []string{"CalendarEventChange"}
+
+func (ec *executionContext) _CalendarEventChange(ctx context.Context, sel ast.SelectionSet, obj *model.CalendarEventChange) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, calendarEventChangeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CalendarEventChange")
+		case "action":
+			out.Values[i] = ec._CalendarEventChange_action(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "eventId":
+			out.Values[i] = ec._CalendarEventChange_eventId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "event":
+			out.Values[i] = ec._CalendarEventChange_event(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var eventTemplateImplementors = []string{"EventTemplate"}
+
+func (ec *executionContext) _EventTemplate(ctx context.Context, sel ast.SelectionSet, obj *model.EventTemplate) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, eventTemplateImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EventTemplate")
+		case "id":
+			out.Values[i] = ec._EventTemplate_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "name":
+			out.Values[i] = ec._EventTemplate_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "title":
+			out.Values[i] = ec._EventTemplate_title(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "emoji":
+			out.Values[i] = ec._EventTemplate_emoji(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._EventTemplate_description(ctx, field, obj)
+		case "visibility":
+			out.Values[i] = ec._EventTemplate_visibility(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "durationMinutes":
+			out.Values[i] = ec._EventTemplate_durationMinutes(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "todos":
-			out.Values[i] = ec._Calendar_todos(ctx, field, obj)
+			out.Values[i] = ec._EventTemplate_todos(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "createdAt":
-			out.Values[i] = ec._Calendar_createdAt(ctx, field, obj)
+			out.Values[i] = ec._EventTemplate_createdAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
 		case "updatedAt":
-			out.Values[i] = ec._Calendar_updatedAt(ctx, field, obj)
+			out.Values[i] = ec._EventTemplate_updatedAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var eventTemplateTodoImplementors = []string{"EventTemplateTodo"}
+
+func (ec *executionContext) _EventTemplateTodo(ctx context.Context, sel ast.SelectionSet, obj *model.EventTemplateTodo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, eventTemplateTodoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EventTemplateTodo")
+		case "content":
+			out.Values[i] = ec._EventTemplateTodo_content(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -4411,6 +6418,41 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "duplicateCalendarEvent":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_duplicateCalendarEvent(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createEventTemplate":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_createEventTemplate(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "updateEventTemplate":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_updateEventTemplate(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "deleteEventTemplate":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_deleteEventTemplate(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createEventFromTemplate":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_createEventFromTemplate(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "updateMyProfile":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_updateMyProfile(ctx, field)
@@ -4614,6 +6656,50 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "myEventTemplates":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_myEventTemplates(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "myEventTemplate":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_myEventTemplate(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "checkNicknameAvailability":
 			field := field
@@ -4728,7 +6814,29 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 		})
 	}
 
-	return out
+	return out
+}
+
+var subscriptionImplementors = []string{"Subscription"}
+
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func(ctx context.Context) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, subscriptionImplementors)
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		graphql.AddErrorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "calendarEventChanged":
+		return ec._Subscription_calendarEventChanged(ctx, fields[0])
+	case "todoChanged":
+		return ec._Subscription_todoChanged(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
 }
 
 var todoImplementors = []string{"Todo"}
@@ -4821,6 +6929,11 @@ func (ec *executionContext) _Todo(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&out.Invalids, 1)
 			}
+		case "version":
+			out.Values[i] = ec._Todo_version(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
 		case "createdAt":
 			out.Values[i] = ec._Todo_createdAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -4854,6 +6967,57 @@ func (ec *executionContext) _Todo(ctx context.Context, sel ast.SelectionSet, obj
 	return out
 }
 
+var todoChangeImplementors = []string{"TodoChange"}
+
+func (ec *executionContext) _TodoChange(ctx context.Context, sel ast.SelectionSet, obj *model.TodoChange) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, todoChangeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TodoChange")
+		case "action":
+			out.Values[i] = ec._TodoChange_action(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "eventId":
+			out.Values[i] = ec._TodoChange_eventId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "todoId":
+			out.Values[i] = ec._TodoChange_todoId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "todo":
+			out.Values[i] = ec._TodoChange_todo(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var userProfileImplementors = []string{"UserProfile"}
 
 func (ec *executionContext) _UserProfile(ctx context.Context, sel ast.SelectionSet, obj *model.UserProfile) graphql.Marshaler {
@@ -4868,17 +7032,17 @@ func (ec *executionContext) _UserProfile(ctx context.Context, sel ast.SelectionS
 		case "id":
 			out.Values[i] = ec._UserProfile_id(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "userID":
 			out.Values[i] = ec._UserProfile_userID(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "nickname":
 			out.Values[i] = ec._UserProfile_nickname(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "bio":
 			out.Values[i] = ec._UserProfile_bio(ctx, field, obj)
@@ -4887,27 +7051,68 @@ func (ec *executionContext) _UserProfile(ctx context.Context, sel ast.SelectionS
 		case "theme":
 			out.Values[i] = ec._UserProfile_theme(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "followerCount":
 			out.Values[i] = ec._UserProfile_followerCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "followingCount":
 			out.Values[i] = ec._UserProfile_followingCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "followedByMe":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._UserProfile_followedByMe(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "version":
+			out.Values[i] = ec._UserProfile_version(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "createdAt":
 			out.Values[i] = ec._UserProfile_createdAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "updatedAt":
 			out.Values[i] = ec._UserProfile_updatedAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
@@ -5341,6 +7546,20 @@ func (ec *executionContext) marshalNCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋp
 	return ec._Calendar(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNCalendarEventChange2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarEventChange(ctx context.Context, sel ast.SelectionSet, v model.CalendarEventChange) graphql.Marshaler {
+	return ec._CalendarEventChange(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNCalendarEventChange2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarEventChange(ctx context.Context, sel ast.SelectionSet, v *model.CalendarEventChange) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CalendarEventChange(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNCalendarVisibility2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarVisibility(ctx context.Context, v any) (model.CalendarVisibility, error) {
 	var res model.CalendarVisibility
 	err := res.UnmarshalGQL(v)
@@ -5351,16 +7570,143 @@ func (ec *executionContext) marshalNCalendarVisibility2githubᚗcomᚋrainbow96b
 	return v
 }
 
+func (ec *executionContext) unmarshalNChangeAction2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐChangeAction(ctx context.Context, v any) (model.ChangeAction, error) {
+	var res model.ChangeAction
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNChangeAction2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐChangeAction(ctx context.Context, sel ast.SelectionSet, v model.ChangeAction) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNCreateCalendarInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateCalendarInput(ctx context.Context, v any) (model.CreateCalendarInput, error) {
 	res, err := ec.unmarshalInputCreateCalendarInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNCreateEventTemplateInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateEventTemplateInput(ctx context.Context, v any) (model.CreateEventTemplateInput, error) {
+	res, err := ec.unmarshalInputCreateEventTemplateInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNCreateTodoInput2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCreateTodoInput(ctx context.Context, v any) (*model.CreateTodoInput, error) {
 	res, err := ec.unmarshalInputCreateTodoInput(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalNEventTemplate2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate(ctx context.Context, sel ast.SelectionSet, v model.EventTemplate) graphql.Marshaler {
+	return ec._EventTemplate(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNEventTemplate2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.EventTemplate) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNEventTemplate2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNEventTemplate2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplate(ctx context.Context, sel ast.SelectionSet, v *model.EventTemplate) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._EventTemplate(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNEventTemplateTodo2ᚕᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateTodoᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.EventTemplateTodo) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNEventTemplateTodo2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateTodo(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNEventTemplateTodo2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐEventTemplateTodo(ctx context.Context, sel ast.SelectionSet, v *model.EventTemplateTodo) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._EventTemplateTodo(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNID2string(ctx context.Context, v any) (string, error) {
 	res, err := graphql.UnmarshalID(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -5497,11 +7843,30 @@ func (ec *executionContext) marshalNTodo2ᚖgithubᚗcomᚋrainbow96bearᚋplane
 	return ec._Todo(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNTodoChange2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐTodoChange(ctx context.Context, sel ast.SelectionSet, v model.TodoChange) graphql.Marshaler {
+	return ec._TodoChange(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNTodoChange2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐTodoChange(ctx context.Context, sel ast.SelectionSet, v *model.TodoChange) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._TodoChange(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNUpdateCalendarInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateCalendarInput(ctx context.Context, v any) (model.UpdateCalendarInput, error) {
 	res, err := ec.unmarshalInputUpdateCalendarInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNUpdateEventTemplateInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateEventTemplateInput(ctx context.Context, v any) (model.UpdateEventTemplateInput, error) {
+	res, err := ec.unmarshalInputUpdateEventTemplateInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNUpdateProfileInput2githubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐUpdateProfileInput(ctx context.Context, v any) (model.UpdateProfileInput, error) {
 	res, err := ec.unmarshalInputUpdateProfileInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -5809,6 +8174,13 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) marshalOCalendar2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendar(ctx context.Context, sel ast.SelectionSet, v *model.Calendar) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Calendar(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOCalendarVisibility2ᚖgithubᚗcomᚋrainbow96bearᚋplanet_user_serverᚋgraphᚋmodelᚐCalendarVisibility(ctx context.Context, v any) (*model.CalendarVisibility, error) {
 	if v == nil {
 		return nil, nil
@@ -5861,6 +8233,24 @@ func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) unmarshalOInt2ᚖint32(ctx context.Context, v any) (*int32, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalInt32(v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2ᚖint32(ctx context.Context, sel ast.SelectionSet, v *int32) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	_ = sel
+	_ = ctx
+	res := graphql.MarshalInt32(*v)
+	return res
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
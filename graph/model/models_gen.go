@@ -21,24 +21,59 @@ type Calendar struct {
 	EndAt       time.Time          `json:"endAt"`
 	Visibility  CalendarVisibility `json:"visibility"`
 	Todos       []*models.Todo     `json:"todos"`
+	Version     int32              `json:"version"`
 	CreatedAt   time.Time          `json:"createdAt"`
 	UpdatedAt   time.Time          `json:"updatedAt"`
 }
 
+type CalendarEventChange struct {
+	Action  ChangeAction `json:"action"`
+	EventID string       `json:"eventId"`
+	Event   *Calendar    `json:"event,omitempty"`
+}
+
 type CreateCalendarInput struct {
-	Title       string              `json:"title"`
-	Emoji       *string             `json:"emoji,omitempty"`
-	Description *string             `json:"description,omitempty"`
-	StartAt     time.Time           `json:"startAt"`
-	EndAt       time.Time           `json:"endAt"`
-	Visibility  *CalendarVisibility `json:"visibility,omitempty"`
-	Todos       []*CreateTodoInput  `json:"todos,omitempty"`
+	Title            string              `json:"title"`
+	Emoji            *string             `json:"emoji,omitempty"`
+	Description      *string             `json:"description,omitempty"`
+	StartAt          time.Time           `json:"startAt"`
+	EndAt            time.Time           `json:"endAt"`
+	Visibility       *CalendarVisibility `json:"visibility,omitempty"`
+	Todos            []*CreateTodoInput  `json:"todos,omitempty"`
+	ClientMutationID *string             `json:"clientMutationId,omitempty"`
+}
+
+type CreateEventTemplateInput struct {
+	Name            string              `json:"name"`
+	Title           string              `json:"title"`
+	Emoji           *string             `json:"emoji,omitempty"`
+	Description     *string             `json:"description,omitempty"`
+	Visibility      *CalendarVisibility `json:"visibility,omitempty"`
+	DurationMinutes int32               `json:"durationMinutes"`
+	Todos           []*CreateTodoInput  `json:"todos,omitempty"`
 }
 
 type CreateTodoInput struct {
 	Content string `json:"content"`
 }
 
+type EventTemplate struct {
+	ID              string               `json:"id"`
+	Name            string               `json:"name"`
+	Title           string               `json:"title"`
+	Emoji           *string              `json:"emoji,omitempty"`
+	Description     *string              `json:"description,omitempty"`
+	Visibility      CalendarVisibility   `json:"visibility"`
+	DurationMinutes int32                `json:"durationMinutes"`
+	Todos           []*EventTemplateTodo `json:"todos"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+}
+
+type EventTemplateTodo struct {
+	Content string `json:"content"`
+}
+
 type Mutation struct {
 }
 
@@ -50,6 +85,16 @@ type NicknameAvailability struct {
 type Query struct {
 }
 
+type Subscription struct {
+}
+
+type TodoChange struct {
+	Action  ChangeAction `json:"action"`
+	EventID string       `json:"eventId"`
+	TodoID  string       `json:"todoId"`
+	Todo    *models.Todo `json:"todo,omitempty"`
+}
+
 type UpdateCalendarInput struct {
 	Title       *string             `json:"title,omitempty"`
 	Emoji       *string             `json:"emoji,omitempty"`
@@ -60,6 +105,16 @@ type UpdateCalendarInput struct {
 	Todos       []*UpdateTodoInput  `json:"todos,omitempty"`
 }
 
+type UpdateEventTemplateInput struct {
+	Name            *string             `json:"name,omitempty"`
+	Title           *string             `json:"title,omitempty"`
+	Emoji           *string             `json:"emoji,omitempty"`
+	Description     *string             `json:"description,omitempty"`
+	Visibility      *CalendarVisibility `json:"visibility,omitempty"`
+	DurationMinutes *int32              `json:"durationMinutes,omitempty"`
+	Todos           []*CreateTodoInput  `json:"todos,omitempty"`
+}
+
 type UpdateProfileInput struct {
 	Nickname     *string `json:"nickname,omitempty"`
 	Bio          *string `json:"bio,omitempty"`
@@ -82,6 +137,8 @@ type UserProfile struct {
 	Theme          string    `json:"theme"`
 	FollowerCount  int32     `json:"followerCount"`
 	FollowingCount int32     `json:"followingCount"`
+	FollowedByMe   bool      `json:"followedByMe"`
+	Version        int32     `json:"version"`
 	CreatedAt      time.Time `json:"createdAt"`
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
@@ -142,3 +199,60 @@ func (e CalendarVisibility) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type ChangeAction string
+
+const (
+	ChangeActionCreated ChangeAction = "created"
+	ChangeActionUpdated ChangeAction = "updated"
+	ChangeActionDeleted ChangeAction = "deleted"
+)
+
+var AllChangeAction = []ChangeAction{
+	ChangeActionCreated,
+	ChangeActionUpdated,
+	ChangeActionDeleted,
+}
+
+func (e ChangeAction) IsValid() bool {
+	switch e {
+	case ChangeActionCreated, ChangeActionUpdated, ChangeActionDeleted:
+		return true
+	}
+	return false
+}
+
+func (e ChangeAction) String() string {
+	return string(e)
+}
+
+func (e *ChangeAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChangeAction", str)
+	}
+	return nil
+}
+
+func (e ChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ChangeAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ChangeAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
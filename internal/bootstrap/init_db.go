@@ -1,48 +1,140 @@
 package bootstrap
 
 import (
+	"database/sql"
 	"fmt"
-	"time"
+	"net"
+	"strings"
 
 	"github.com/rainbow96bear/planet_user_server/config"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/tracing"
 	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // InitDatabase: PostgreSQL 데이터베이스 연결을 초기화하고 GORM DB 인스턴스를 반환합니다.
-func InitDatabase() (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=Asia/Seoul",
-		config.DB_HOST,
-		config.DB_PORT,
-		config.DB_USER,
-		config.DB_PASSWORD,
-		config.DB_NAME,
-	)
-	logger.Debugf("PostgreSQL DSN: %s", dsn)
-
-	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// replica 가 설정되어 있으면 dbresolver 에 repository.ReplicaResolver 이름으로 등록합니다.
+// (repository 가 명시적으로 고른 읽기 전용 조회만 replica 로 가고, 나머지는 모두 primary 를 사용합니다.)
+func InitDatabase(cfg *config.Config) (*gorm.DB, error) {
+	// 💡 DSN 에는 비밀번호가 포함되므로 로그에 남기지 않습니다.
+	gormDB, err := gorm.Open(postgres.Open(buildDSN(&cfg.DB, cfg.DB.Host, cfg.DB.Port)), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open GORM DB: %w", err)
 	}
 
+	// 쿼리마다 tracing span 생성
+	if err := gormDB.Use(tracing.NewGormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	// sql.DB 가져오기 → 커넥션 풀 설정
 	sqlDB, err := gormDB.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
+	configurePool(sqlDB, &cfg.DB)
 
-	sqlDB.SetMaxOpenConns(50)
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	// 커넥션 풀 상태를 /metrics 로 노출
+	metrics.RegisterDBStats(sqlDB, cfg.DB.Name)
 
 	// 연결 확인
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	logger.Infof("✅ Successfully connected to PostgreSQL [%s:%s/%s]", config.DB_HOST, config.DB_PORT, config.DB_NAME)
+	logger.Infof("✅ Successfully connected to PostgreSQL [%s:%s/%s] sslmode=%s", cfg.DB.Host, cfg.DB.Port, cfg.DB.Name, cfg.DB.SSLMode)
+
+	if err := registerReplicas(gormDB, &cfg.DB); err != nil {
+		return nil, err
+	}
 
 	return gormDB, nil
 }
+
+// registerReplicas: replica 마다 커넥션 풀을 열어 확인한 뒤 dbresolver 에 등록합니다.
+func registerReplicas(gormDB *gorm.DB, cfg *config.DBConfig) error {
+	hosts := cfg.Replicas()
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(hosts))
+	for _, hostPort := range hosts {
+		host, port, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return fmt.Errorf("invalid replica address %q: %w", hostPort, err)
+		}
+
+		replicaDB, err := sql.Open("pgx", buildDSN(cfg, host, port))
+		if err != nil {
+			return fmt.Errorf("failed to open replica %s: %w", hostPort, err)
+		}
+		configurePool(replicaDB, cfg)
+		if err := replicaDB.Ping(); err != nil {
+			replicaDB.Close()
+			return fmt.Errorf("failed to ping replica %s: %w", hostPort, err)
+		}
+		metrics.RegisterDBStats(replicaDB, cfg.Name+"@"+hostPort)
+
+		replicas = append(replicas, postgres.New(postgres.Config{Conn: replicaDB}))
+		logger.Infof("✅ Successfully connected to PostgreSQL replica [%s/%s]", hostPort, cfg.Name)
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}, repository.ReplicaResolver)
+	if err := gormDB.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replicas: %w", err)
+	}
+	return nil
+}
+
+// configurePool: 설정의 커넥션 풀 값을 적용합니다.
+func configurePool(sqlDB *sql.DB, cfg *config.DBConfig) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// buildDSN: libpq key=value DSN 을 만듭니다. 값은 공백 / 따옴표가 있어도 안전하도록 인용합니다.
+func buildDSN(cfg *config.DBConfig, host, port string) string {
+	params := [][2]string{
+		{"host", host},
+		{"port", port},
+		{"user", cfg.User},
+		{"password", cfg.Password},
+		{"dbname", cfg.Name},
+		{"sslmode", cfg.SSLMode},
+		{"sslrootcert", cfg.SSLRootCert},
+		{"sslcert", cfg.SSLCert},
+		{"sslkey", cfg.SSLKey},
+		{"TimeZone", "Asia/Seoul"},
+	}
+
+	var b strings.Builder
+	for _, p := range params {
+		if p[1] == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(p[0])
+		b.WriteByte('=')
+		b.WriteString(quoteDSNValue(p[1]))
+	}
+	return b.String()
+}
+
+func quoteDSNValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
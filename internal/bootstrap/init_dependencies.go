@@ -1,65 +1,241 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/config"
+	"github.com/rainbow96bear/planet_user_server/internal/calendarcache"
+	"github.com/rainbow96bear/planet_user_server/internal/dataloader"
 	grpcclient "github.com/rainbow96bear/planet_user_server/internal/grpc/client"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
 	"github.com/rainbow96bear/planet_user_server/internal/repository"
 	"github.com/rainbow96bear/planet_user_server/internal/resolver"
 	"github.com/rainbow96bear/planet_user_server/internal/service"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
 type Dependencies struct {
+	Config      *config.Config
+	DB          *gorm.DB
 	Repos       *Repositories
 	GrpcClients *grpcclient.GrpcClients
 	Services    *Services
 	Resolver    *resolver.Resolver
+	Hub         *pubsub.Hub
+	OutboxRelay *outbox.Relay
 }
 
 type Repositories struct {
-	Profile *repository.ProfileRepository
+	Profile        repository.ProfileRepositoryInterface
+	CalendarEvents repository.CalendarEventsRepositoryInterface
+	Todos          repository.TodosRepositoryInterface
+	EventTemplates repository.CalendarEventTemplatesRepositoryInterface
+	Idempotency    repository.IdempotencyKeysRepositoryInterface
+	Outbox         repository.OutboxEventsRepositoryInterface
+	Follows        repository.FollowsRepositoryInterface
+	PersistedQuery repository.PersistedQueriesRepositoryInterface
+}
+
+// NewRepositories: PostgreSQL repository 로 Repositories 를 구성합니다.
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Profile:        repository.NewProfilesRepository(db),
+		CalendarEvents: repository.NewCalendarEventsRepository(db),
+		Todos:          repository.NewTodosRepository(db),
+		EventTemplates: repository.NewCalendarEventTemplatesRepository(db),
+		Idempotency:    repository.NewIdempotencyKeysRepository(db),
+		Outbox:         repository.NewOutboxEventsRepository(db),
+		Follows:        repository.NewFollowsRepository(db),
+		PersistedQuery: repository.NewPersistedQueriesRepository(db),
+	}
 }
 
 type Services struct {
 	Profile service.ProfileServiceInterface
+	Admin   service.AdminServiceInterface
+}
+
+type options struct {
+	repos           *Repositories
+	grpcDialOptions []grpc.DialOption
 }
 
-func InitDependencies(db *gorm.DB) (*Dependencies, error) {
+// Option: InitDependencies 구성을 바꿉니다. (주로 테스트용)
+type Option func(*options)
+
+// WithRepositories: db 대신 repos 를 사용합니다. (in-memory repository 로 구성할 때는 db 를 nil 로 넘김)
+func WithRepositories(repos *Repositories) Option {
+	return func(o *options) { o.repos = repos }
+}
+
+// WithGrpcDialOptions: gRPC client 연결에 dial option 을 추가합니다. (bufconn dialer 등)
+func WithGrpcDialOptions(dialOpts ...grpc.DialOption) Option {
+	return func(o *options) { o.grpcDialOptions = append(o.grpcDialOptions, dialOpts...) }
+}
+
+func InitDependencies(db *gorm.DB, cfg *config.Config, opts ...Option) (*Dependencies, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// --- 1. Repository 초기화 ---
-	profileRepo := repository.NewProfilesRepository(db)
-	calendarRepo := repository.NewCalendarEventsRepository(db)
-	todoRepo := repository.NewTodosRepository(db)
+	repos := o.repos
+	if repos == nil {
+		repos = NewRepositories(db)
+	}
+	profileRepo := repos.Profile
+	calendarRepo := repos.CalendarEvents
+	todoRepo := repos.Todos
+	templateRepo := repos.EventTemplates
+	idempotencyRepo := repos.Idempotency
+	outboxRepo := repos.Outbox
+	followsRepo := repos.Follows
 
 	// --- 2. gRPC Clients 초기화 ---
-	grpcClients, err := grpcclient.NewGrpcClients()
+	grpcClients, err := grpcclient.NewGrpcClients(cfg.GRPC.AuthServerAddr, o.grpcDialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	// --- 3. Pub/Sub 초기화 (GraphQL Subscription) ---
+	hub := pubsub.NewHub()
+
+	// --- 4. Outbox 초기화 (다른 서비스로 도메인 이벤트 발행) ---
+	outboxWriter := outbox.NewWriter(outboxRepo)
+	outboxPublisher, err := newOutboxPublisher(cfg.Outbox)
+	if err != nil {
+		return nil, err
+	}
+	outboxRelay := outbox.NewRelay(db, outboxRepo, outboxPublisher, outbox.RelayConfig{
+		Interval:    cfg.Outbox.RelayInterval,
+		MaxAttempts: cfg.Outbox.MaxAttempts,
+	})
+
+	// --- 5. DataLoader 초기화 (GraphQL 요청 단위 배치 조회) ---
+	loaderFactory := dataloader.NewFactory(profileRepo, todoRepo, followsRepo)
+
+	// --- 6. 캘린더 월별 캐시 초기화 ---
+	monthCache, err := newCalendarCache(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	// --- 3. Service 초기화 ---
-	profileService := service.NewProfileService(db, profileRepo)
+	// --- 7. Service 초기화 ---
+	profileService := service.NewProfileService(db,
+		profileRepo,
+		calendarRepo,
+		templateRepo,
+		followsRepo,
+		outboxWriter,
+		monthCache,
+	)
 	calendarService := service.NewCalendarService(db,
 		profileRepo,
 		calendarRepo,
 		// todoRepo,
+		hub,
+		outboxWriter,
+		monthCache,
 	)
 	todoService := service.NewTodoService(db,
 		todoRepo,
+		hub,
+	)
+	eventTemplateService := service.NewEventTemplateService(db,
+		templateRepo,
+		calendarService,
+	)
+
+	idempotencyService := service.NewIdempotencyService(db,
+		idempotencyRepo,
+		cfg.Idempotency.KeyTTL,
+	)
+
+	// 운영용 CLI 서브커맨드 전용 (GraphQL / gRPC 에는 노출하지 않음)
+	adminService := service.NewAdminService(db,
+		profileService,
+		calendarService,
+		profileRepo,
+		calendarRepo,
+		templateRepo,
+		followsRepo,
+		idempotencyRepo,
+		outboxRepo,
 	)
 
 	resolver := resolver.NewResolver(
 		profileService,
 		calendarService,
 		todoService,
+		eventTemplateService,
+		idempotencyService,
+		hub,
+		loaderFactory,
 	)
 	// DI Container 패턴
 	return &Dependencies{
-		Repos: &Repositories{
-			Profile: profileRepo,
-		},
+		Config:      cfg,
+		DB:          db,
+		Repos:       repos,
 		GrpcClients: grpcClients,
 		Services: &Services{
 			Profile: profileService,
+			Admin:   adminService,
 		},
-		Resolver: resolver,
+		Resolver:    resolver,
+		Hub:         hub,
+		OutboxRelay: outboxRelay,
 	}, nil
 }
+
+// newOutboxPublisher: outbox.publisher 설정에 따라 Publisher 를 생성합니다.
+func newOutboxPublisher(cfg config.OutboxConfig) (outbox.Publisher, error) {
+	switch cfg.Publisher {
+	case "log":
+		return outbox.NewLogPublisher(), nil
+	case "grpc":
+		conn, err := grpc.Dial(cfg.GRPCAddr, grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		return outbox.NewGrpcStreamPublisher(conn, cfg.GRPCMethod), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher: %s", cfg.Publisher)
+	}
+}
+
+// newCalendarCache: calendar_cache.backend 설정에 따라 월별 캘린더 캐시를 생성합니다.
+func newCalendarCache(cfg *config.Config) (calendarcache.Cache, error) {
+	switch cfg.CalendarCache.Backend {
+	case calendarcache.BackendNone:
+		return calendarcache.Noop{}, nil
+	case calendarcache.BackendMemory:
+		lru := calendarcache.NewLRU(cfg.CalendarCache.Size, cfg.CalendarCache.TTL)
+		return calendarcache.WithMetrics(lru, calendarcache.BackendMemory), nil
+	case calendarcache.BackendRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("redis ping failed (%s): %w", cfg.Redis.Addr, err)
+		}
+
+		logger.Infof("calendar month cache: redis %s (ttl=%s)", cfg.Redis.Addr, cfg.CalendarCache.TTL)
+		return calendarcache.WithMetrics(calendarcache.NewRedis(client, cfg.CalendarCache.TTL), calendarcache.BackendRedis), nil
+	default:
+		return nil, fmt.Errorf("unknown calendar cache backend: %s", cfg.CalendarCache.Backend)
+	}
+}
@@ -1,10 +1,14 @@
 package bootstrap
 
 import (
+	"fmt"
 
-	// 프로젝트 내부 패키지
+	"github.com/99designs/gqlgen/graphql"
 
+	// 프로젝트 내부 패키지
 	"github.com/rainbow96bear/planet_user_server/internal/handler"
+	"github.com/rainbow96bear/planet_user_server/internal/persistedquery"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
 )
 
 // HandlerMap: 모든 초기화된 핸들러를 저장하는 맵
@@ -12,10 +16,53 @@ type HandlerMap map[string]handler.Handler
 
 // InitDependencies: 모든 하위 의존성(Repo, Client, Service)을 초기화하고 HTTP 핸들러를 반환합니다.
 // 이 함수는 'main'에서 호출됩니다.
-func InitHandlers(dep *Dependencies) HandlerMap {
-	graphqlHandler := handler.NewGraphqlHandler(dep.Resolver)
+func InitHandlers(dep *Dependencies, build handler.BuildInfo) (HandlerMap, error) {
+	apqCache, err := newAPQCache(dep)
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := loadOperationAllowlist(dep.Config.GraphQL.OperationAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	graphqlHandler, err := handler.NewGraphqlHandler(dep.Config, dep.Resolver, dep.Resolver.Loaders, apqCache, allowlist)
+	if err != nil {
+		return nil, err
+	}
+	healthHandler := handler.NewHealthHandler(dep.DB, dep.GrpcClients.AuthConn, build)
+	metricsHandler := handler.NewMetricsHandler()
 
 	return HandlerMap{
 		"graphql": graphqlHandler,
+		"health":  healthHandler,
+		"metrics": metricsHandler,
+	}, nil
+}
+
+// newAPQCache: graphql.apq_cache 설정에 따라 APQ 캐시를 생성합니다.
+func newAPQCache(dep *Dependencies) (graphql.Cache[string], error) {
+	cfg := dep.Config.GraphQL
+	switch cfg.APQCache {
+	case persistedquery.CacheMemory:
+		return persistedquery.NewMemoryCache(cfg.APQCacheSize), nil
+	case persistedquery.CacheDB:
+		return persistedquery.NewDBCache(dep.Repos.PersistedQuery, cfg.APQCacheSize), nil
+	default:
+		return nil, fmt.Errorf("unknown graphql apq cache: %s", cfg.APQCache)
+	}
+}
+
+// loadOperationAllowlist: path 가 비어 있으면 nil (모든 operation 허용)
+func loadOperationAllowlist(path string) (*persistedquery.Allowlist, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	allowlist, err := persistedquery.LoadAllowlist(path)
+	if err != nil {
+		return nil, err
 	}
+	logger.Infof("GraphQL operation allowlist enabled (%d hashes from %s)", allowlist.Len(), path)
+	return allowlist, nil
 }
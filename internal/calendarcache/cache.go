@@ -0,0 +1,86 @@
+package calendarcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+const (
+	BackendNone   = "none"
+	BackendMemory = "memory" // 인스턴스별 LRU + TTL
+	BackendRedis  = "redis"  // 인스턴스 간 공유 (무효화가 모든 인스턴스에 반영됨)
+)
+
+// Key: 사용자 / 월 / 공개 범위 단위로 캐시합니다.
+type Key struct {
+	UserID     uuid.UUID
+	Month      time.Time // 해당 월 1일 00:00 UTC
+	Visibility string
+}
+
+// String: "UserID:2006-01:visibility"
+func (k Key) String() string {
+	return k.UserID.String() + ":" + k.Month.Format("2006-01") + ":" + k.Visibility
+}
+
+// Cache: 월별 캘린더 조회 결과 (Todo 없는 일정 목록) 캐시.
+// Get 이 돌려준 슬라이스와 일정은 다른 요청과 공유될 수 있으므로 수정하면 안 됩니다.
+type Cache interface {
+	Get(ctx context.Context, key Key) ([]*models.CalendarEvent, bool)
+	Set(ctx context.Context, key Key, events []*models.CalendarEvent)
+	Delete(ctx context.Context, keys ...Key)
+}
+
+// Noop: 캐시 비활성화
+type Noop struct{}
+
+func (Noop) Get(context.Context, Key) ([]*models.CalendarEvent, bool) { return nil, false }
+func (Noop) Set(context.Context, Key, []*models.CalendarEvent)        {}
+func (Noop) Delete(context.Context, ...Key)                           {}
+
+// instrumented: 구현과 관계없이 hit / miss / 무효화 수를 기록합니다.
+type instrumented struct {
+	Cache
+	backend string
+}
+
+func WithMetrics(c Cache, backend string) Cache {
+	return &instrumented{Cache: c, backend: backend}
+}
+
+func (c *instrumented) Get(ctx context.Context, key Key) ([]*models.CalendarEvent, bool) {
+	events, ok := c.Cache.Get(ctx, key)
+	result := "miss"
+	if ok {
+		result = "hit"
+	}
+	metrics.CalendarMonthCacheRequests.WithLabelValues(c.backend, result).Inc()
+	return events, ok
+}
+
+func (c *instrumented) Delete(ctx context.Context, keys ...Key) {
+	c.Cache.Delete(ctx, keys...)
+	metrics.CalendarMonthCacheInvalidations.WithLabelValues(c.backend).Add(float64(len(keys)))
+}
+
+// MonthStart: t 가 속한 달의 1일 00:00 UTC
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthsOverlapping: 조회 조건 (start_at < 월말 AND end_at >= 월초) 기준으로 일정이 포함되는 모든 달
+func MonthsOverlapping(startAt, endAt time.Time) []time.Time {
+	first := MonthStart(startAt)
+	last := MonthStart(endAt)
+
+	var months []time.Time
+	for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+		months = append(months, m)
+	}
+	return months
+}
@@ -0,0 +1,49 @@
+package calendarcache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMonthsOverlapping(t *testing.T) {
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	month := func(year int, m time.Month) time.Time {
+		return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		startAt time.Time
+		endAt   time.Time
+		want    []time.Time
+	}{
+		{name: "single day", startAt: date(3, 14, 18), endAt: date(3, 14, 20), want: []time.Time{month(2025, 3)}},
+		{name: "ends at month start", startAt: date(3, 31, 22), endAt: date(4, 1, 0), want: []time.Time{month(2025, 3), month(2025, 4)}},
+		{name: "spans three months", startAt: date(1, 30, 0), endAt: date(3, 2, 0), want: []time.Time{month(2025, 1), month(2025, 2), month(2025, 3)}},
+		{
+			name:    "across year",
+			startAt: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			endAt:   date(1, 1, 12),
+			want:    []time.Time{month(2024, 12), month(2025, 1)},
+		},
+		{
+			// 월 경계는 UTC 기준
+			name:    "non UTC input",
+			startAt: time.Date(2025, 4, 1, 8, 0, 0, 0, time.FixedZone("KST", 9*60*60)),
+			endAt:   time.Date(2025, 4, 1, 10, 0, 0, 0, time.FixedZone("KST", 9*60*60)),
+			want:    []time.Time{month(2025, 3), month(2025, 4)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MonthsOverlapping(tt.startAt, tt.endAt)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Fatalf("MonthsOverlapping = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package calendarcache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+type lruEntry struct {
+	key       string
+	events    []*models.CalendarEvent
+	expiresAt time.Time
+}
+
+// LRU: 크기 제한 + TTL 메모리 캐시
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	ll    *list.List // 앞쪽이 최근 사용
+	items map[string]*list.Element
+
+	now func() time.Time
+}
+
+var _ Cache = (*LRU)(nil)
+
+func NewLRU(size int, ttl time.Duration) *LRU {
+	if size < 1 {
+		size = 1
+	}
+	return &LRU{
+		size:  size,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+		now:   time.Now,
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key Key) ([]*models.CalendarEvent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key.String()]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if c.now().After(entry.expiresAt) {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	return entry.events, true
+}
+
+func (c *LRU) Set(_ context.Context, key Key, events []*models.CalendarEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key.String()
+	expiresAt := c.now().Add(c.ttl)
+
+	if el, ok := c.items[k]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.events = events
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[k] = c.ll.PushFront(&lruEntry{key: k, events: events, expiresAt: expiresAt})
+	for c.ll.Len() > c.size {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.items[key.String()]; ok {
+			c.removeElement(el)
+		}
+	}
+}
+
+func (c *LRU) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
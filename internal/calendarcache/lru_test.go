@@ -0,0 +1,110 @@
+package calendarcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+func testKey(userID uuid.UUID, month time.Month, visibility string) Key {
+	return Key{UserID: userID, Month: time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC), Visibility: visibility}
+}
+
+// newTestLRU: 시각을 직접 움직일 수 있는 LRU
+func newTestLRU(size int, ttl time.Duration) (*LRU, *time.Time) {
+	c := NewLRU(size, ttl)
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c, _ := newTestLRU(2, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+	jan, feb, mar := testKey(userID, 1, "public"), testKey(userID, 2, "public"), testKey(userID, 3, "public")
+
+	c.Set(ctx, jan, nil)
+	c.Set(ctx, feb, nil)
+	if _, ok := c.Get(ctx, jan); !ok { // jan 이 최근 사용으로 앞으로 이동
+		t.Fatal("jan missing before eviction")
+	}
+	c.Set(ctx, mar, nil)
+
+	for key, want := range map[Key]bool{jan: true, feb: false, mar: true} {
+		if _, ok := c.Get(ctx, key); ok != want {
+			t.Errorf("Get(%s) hit = %v, want %v", key, ok, want)
+		}
+	}
+	if c.ll.Len() != 2 || len(c.items) != 2 {
+		t.Fatalf("len = %d / %d, want 2", c.ll.Len(), len(c.items))
+	}
+}
+
+func TestLRUSetExistingKeyDoesNotEvict(t *testing.T) {
+	c, _ := newTestLRU(2, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+	jan, feb := testKey(userID, 1, "public"), testKey(userID, 2, "public")
+
+	c.Set(ctx, jan, nil)
+	c.Set(ctx, feb, nil)
+	event := &models.CalendarEvent{ID: uuid.New()}
+	c.Set(ctx, jan, []*models.CalendarEvent{event})
+
+	got, ok := c.Get(ctx, jan)
+	if !ok || len(got) != 1 || got[0] != event {
+		t.Fatalf("Get(jan) = %v, %v, want replaced value", got, ok)
+	}
+	if _, ok := c.Get(ctx, feb); !ok {
+		t.Fatal("feb evicted by overwrite")
+	}
+}
+
+func TestLRUExpiresAfterTTL(t *testing.T) {
+	c, now := newTestLRU(10, time.Minute)
+	ctx := context.Background()
+	key := testKey(uuid.New(), 3, "public")
+
+	c.Set(ctx, key, nil)
+	*now = now.Add(time.Minute)
+	if _, ok := c.Get(ctx, key); !ok {
+		t.Fatal("expired at exactly TTL")
+	}
+
+	*now = now.Add(time.Nanosecond)
+	if _, ok := c.Get(ctx, key); ok {
+		t.Fatal("hit after TTL")
+	}
+	if len(c.items) != 0 {
+		t.Fatal("expired entry not removed")
+	}
+
+	// 다시 Set 하면 TTL 이 새로 시작됩니다.
+	c.Set(ctx, key, nil)
+	*now = now.Add(30 * time.Second)
+	if _, ok := c.Get(ctx, key); !ok {
+		t.Fatal("miss after refresh")
+	}
+}
+
+func TestLRUDelete(t *testing.T) {
+	c, _ := newTestLRU(10, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+	keys := []Key{testKey(userID, 1, "public"), testKey(userID, 1, "private"), testKey(userID, 2, "public")}
+	for _, key := range keys {
+		c.Set(ctx, key, nil)
+	}
+
+	c.Delete(ctx, keys[0], keys[2], testKey(uuid.New(), 1, "public"))
+
+	for i, key := range keys {
+		if _, ok := c.Get(ctx, key); ok != (i == 1) {
+			t.Errorf("Get(%s) hit = %v", key, ok)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package calendarcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "planet_user:calendar_month:"
+
+// Redis: 여러 인스턴스가 공유하는 캐시.
+// redis.UniversalClient 를 받으므로 로컬에서는 miniredis 같은 fake 서버를 가리키는 클라이언트로 대체할 수 있습니다.
+// Redis 장애 시에는 miss 로 처리하고 DB 를 조회합니다.
+type Redis struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+var _ Cache = (*Redis)(nil)
+
+func NewRedis(client redis.UniversalClient, ttl time.Duration) *Redis {
+	return &Redis{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (c *Redis) Get(ctx context.Context, key Key) ([]*models.CalendarEvent, bool) {
+	data, err := c.client.Get(ctx, redisKeyPrefix+key.String()).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logctx.Warnf(ctx, "[CalendarCache] redis get failed key=%s err=%v", key, err)
+		}
+		return nil, false
+	}
+
+	var events []*models.CalendarEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		logctx.Warnf(ctx, "[CalendarCache] corrupted entry key=%s err=%v", key, err)
+		return nil, false
+	}
+	return events, true
+}
+
+func (c *Redis) Set(ctx context.Context, key Key, events []*models.CalendarEvent) {
+	data, err := json.Marshal(events)
+	if err != nil {
+		logctx.Warnf(ctx, "[CalendarCache] marshal failed key=%s err=%v", key, err)
+		return
+	}
+
+	if err := c.client.Set(ctx, redisKeyPrefix+key.String(), data, c.ttl).Err(); err != nil {
+		logctx.Warnf(ctx, "[CalendarCache] redis set failed key=%s err=%v", key, err)
+	}
+}
+
+func (c *Redis) Delete(ctx context.Context, keys ...Key) {
+	if len(keys) == 0 {
+		return
+	}
+
+	redisKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		redisKeys = append(redisKeys, redisKeyPrefix+key.String())
+	}
+
+	// 무효화 실패 시 TTL 이 지날 때까지 이전 결과가 보일 수 있습니다.
+	if err := c.client.Del(ctx, redisKeys...).Err(); err != nil {
+		logctx.Errorf(ctx, "[CalendarCache] redis delete failed keys=%v err=%v", redisKeys, err)
+	}
+}
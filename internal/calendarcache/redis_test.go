@@ -0,0 +1,198 @@
+package calendarcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis: Redis 캐시가 사용하는 GET / SET / DEL 만 구현한 in-memory 클라이언트.
+// 나머지 메서드는 embed 한 nil 인터페이스로 남겨 두어 호출하면 panic 합니다.
+type fakeRedis struct {
+	redis.UniversalClient
+
+	mu      sync.Mutex
+	data    map[string]fakeRedisEntry
+	now     time.Time
+	failing error // 설정되면 모든 명령이 이 에러로 실패 (Redis 장애)
+}
+
+type fakeRedisEntry struct {
+	value     string
+	expiresAt time.Time // zero 면 만료 없음
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{
+		data: make(map[string]fakeRedisEntry),
+		now:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (f *fakeRedis) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cmd := redis.NewStringCmd(ctx, "get", key)
+	if f.failing != nil {
+		cmd.SetErr(f.failing)
+		return cmd
+	}
+	entry, ok := f.data[key]
+	if !ok || (!entry.expiresAt.IsZero() && !f.now.Before(entry.expiresAt)) {
+		delete(f.data, key)
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(entry.value)
+	return cmd
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cmd := redis.NewStatusCmd(ctx, "set", key, value)
+	if f.failing != nil {
+		cmd.SetErr(f.failing)
+		return cmd
+	}
+	entry := fakeRedisEntry{}
+	switch v := value.(type) {
+	case []byte:
+		entry.value = string(v)
+	case string:
+		entry.value = v
+	default:
+		cmd.SetErr(errors.New("fakeRedis: unsupported value type"))
+		return cmd
+	}
+	if expiration > 0 {
+		entry.expiresAt = f.now.Add(expiration)
+	}
+	f.data[key] = entry
+	cmd.SetVal("OK")
+	return cmd
+}
+
+func (f *fakeRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cmd := redis.NewIntCmd(ctx, "del")
+	if f.failing != nil {
+		cmd.SetErr(f.failing)
+		return cmd
+	}
+	var n int64
+	for _, key := range keys {
+		if _, ok := f.data[key]; ok {
+			delete(f.data, key)
+			n++
+		}
+	}
+	cmd.SetVal(n)
+	return cmd
+}
+
+func TestRedisRoundTrip(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, time.Minute)
+	ctx := context.Background()
+	key := testKey(uuid.New(), 3, "public")
+
+	if _, ok := c.Get(ctx, key); ok {
+		t.Fatal("hit before Set")
+	}
+
+	event := &models.CalendarEvent{ID: uuid.New(), UserID: key.UserID, Title: "dinner", Visibility: "public"}
+	c.Set(ctx, key, []*models.CalendarEvent{event})
+
+	got, ok := c.Get(ctx, key)
+	if !ok || len(got) != 1 || got[0].ID != event.ID || got[0].Title != "dinner" {
+		t.Fatalf("Get = %v, %v", got, ok)
+	}
+
+	// 빈 결과도 hit 으로 캐시됩니다.
+	empty := testKey(key.UserID, 4, "public")
+	c.Set(ctx, empty, []*models.CalendarEvent{})
+	if got, ok := c.Get(ctx, empty); !ok || len(got) != 0 {
+		t.Fatalf("empty Get = %v, %v", got, ok)
+	}
+}
+
+func TestRedisTTL(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, time.Minute)
+	ctx := context.Background()
+	key := testKey(uuid.New(), 3, "public")
+
+	c.Set(ctx, key, nil)
+	client.advance(59 * time.Second)
+	if _, ok := c.Get(ctx, key); !ok {
+		t.Fatal("expired before TTL")
+	}
+	client.advance(time.Second)
+	if _, ok := c.Get(ctx, key); ok {
+		t.Fatal("hit after TTL")
+	}
+}
+
+func TestRedisDelete(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, time.Minute)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	keys := []Key{testKey(userID, 1, "public"), testKey(userID, 2, "public"), testKey(userID, 2, "private")}
+	for _, key := range keys {
+		c.Set(ctx, key, nil)
+	}
+
+	c.Delete(ctx, keys[:2]...)
+	for i, key := range keys {
+		if _, ok := c.Get(ctx, key); ok != (i == 2) {
+			t.Errorf("Get(%s) hit = %v", key, ok)
+		}
+	}
+}
+
+func TestRedisUnavailableIsMiss(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, time.Minute)
+	ctx := context.Background()
+	key := testKey(uuid.New(), 3, "public")
+
+	c.Set(ctx, key, nil)
+	client.failing = errors.New("connection refused")
+
+	if _, ok := c.Get(ctx, key); ok {
+		t.Fatal("hit while redis is unavailable")
+	}
+	c.Set(ctx, key, nil) // 실패해도 panic 하지 않고 로깅만 합니다.
+	c.Delete(ctx, key)
+}
+
+func TestRedisCorruptedEntryIsMiss(t *testing.T) {
+	client := newFakeRedis()
+	c := NewRedis(client, time.Minute)
+	ctx := context.Background()
+	key := testKey(uuid.New(), 3, "public")
+
+	client.Set(ctx, redisKeyPrefix+key.String(), "not json", 0)
+	if _, ok := c.Get(ctx, key); ok {
+		t.Fatal("hit on corrupted entry")
+	}
+}
@@ -0,0 +1,202 @@
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+)
+
+// goroutine 시작이 늦어도 한 배치로 모이도록 대기 시간을 늘립니다.
+const testWait = 50 * time.Millisecond
+
+// 아래 repository 는 memory 구현을 감싸 배치 조회 메서드의 호출 수 (= 쿼리 수) 를 셉니다.
+
+type countingProfiles struct {
+	*memory.ProfileRepository
+	calls atomic.Int64
+}
+
+func (r *countingProfiles) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.Profile, error) {
+	r.calls.Add(1)
+	return r.ProfileRepository.FindByUserIDs(ctx, userIDs)
+}
+
+type countingTodos struct {
+	*memory.TodosRepository
+	calls atomic.Int64
+}
+
+func (r *countingTodos) FindByEventIDs(ctx context.Context, eventIDs []uuid.UUID) ([]models.Todo, error) {
+	r.calls.Add(1)
+	return r.TodosRepository.FindByEventIDs(ctx, eventIDs)
+}
+
+type countingFollows struct {
+	*memory.FollowsRepository
+	calls atomic.Int64
+}
+
+func (r *countingFollows) FindFollowedAmong(ctx context.Context, followerUUID uuid.UUID, followeeUUIDs []uuid.UUID) ([]uuid.UUID, error) {
+	r.calls.Add(1)
+	return r.FollowsRepository.FindFollowedAmong(ctx, followerUUID, followeeUUIDs)
+}
+
+type fixture struct {
+	profiles *countingProfiles
+	todos    *countingTodos
+	follows  *countingFollows
+	loaders  *Loaders
+
+	viewerID uuid.UUID
+	userIDs  []uuid.UUID // 짝수 번째만 viewer 가 팔로우
+	eventIDs []uuid.UUID // 일정마다 todo 2개
+}
+
+func newFixture(t *testing.T, users int) *fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.NewStore()
+	f := &fixture{
+		profiles: &countingProfiles{ProfileRepository: memory.NewProfileRepository(store)},
+		todos:    &countingTodos{TodosRepository: memory.NewTodosRepository(store)},
+		follows:  &countingFollows{FollowsRepository: memory.NewFollowsRepository(store)},
+		viewerID: uuid.New(),
+	}
+	events := memory.NewCalendarEventsRepository(store)
+
+	for i := range users {
+		userID := uuid.New()
+		if err := f.profiles.Create(ctx, &models.Profile{UserID: userID, Nickname: fmt.Sprintf("user%d", i)}); err != nil {
+			t.Fatalf("seed profile: %v", err)
+		}
+		if i%2 == 0 {
+			store.AddFollow(f.viewerID, userID)
+		}
+
+		event := &models.CalendarEvent{
+			ID:     uuid.New(),
+			UserID: userID,
+			Title:  "event",
+			Todos:  []models.Todo{{ID: uuid.New(), Content: "a"}, {ID: uuid.New(), Content: "b"}},
+		}
+		if _, err := events.CreateCalendarEvent(ctx, event); err != nil {
+			t.Fatalf("seed event: %v", err)
+		}
+		f.userIDs = append(f.userIDs, userID)
+		f.eventIDs = append(f.eventIDs, event.ID)
+	}
+
+	f.loaders = NewFactory(f.profiles, f.todos, f.follows).New(ctx)
+	f.loaders.ProfileByUserID.wait = testWait
+	f.loaders.TodosByEventID.wait = testWait
+	f.loaders.FollowState.wait = testWait
+	return f
+}
+
+// loadConcurrently: resolver 처럼 키마다 goroutine 에서 Load 합니다.
+func loadConcurrently(t *testing.T, n int, load func(i int) error) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := load(i); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("load: %v", err)
+	}
+}
+
+func TestLoadersBatchQueries(t *testing.T) {
+	tests := []struct {
+		name        string
+		users       int
+		wantQueries int64
+	}{
+		{name: "single batch", users: 10, wantQueries: 1},
+		{name: "split at max batch", users: defaultMaxBatch*2 + 1, wantQueries: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.users)
+			ctx := context.Background()
+
+			loadConcurrently(t, tt.users, func(i int) error {
+				p, err := f.loaders.ProfileByUserID.Load(ctx, f.userIDs[i])
+				if err == nil && (p == nil || p.UserID != f.userIDs[i]) {
+					err = fmt.Errorf("profile %d = %v", i, p)
+				}
+				return err
+			})
+			loadConcurrently(t, tt.users, func(i int) error {
+				todos, err := f.loaders.TodosByEventID.Load(ctx, f.eventIDs[i])
+				if err == nil && len(todos) != 2 {
+					err = fmt.Errorf("event %d has %d todos, want 2", i, len(todos))
+				}
+				return err
+			})
+			loadConcurrently(t, tt.users, func(i int) error {
+				followed, err := f.loaders.FollowState.Load(ctx, FollowKey{ViewerID: f.viewerID, TargetID: f.userIDs[i]})
+				if err == nil && followed != (i%2 == 0) {
+					err = fmt.Errorf("follow state %d = %v", i, followed)
+				}
+				return err
+			})
+
+			for name, got := range map[string]int64{
+				"FindByUserIDs":     f.profiles.calls.Load(),
+				"FindByEventIDs":    f.todos.calls.Load(),
+				"FindFollowedAmong": f.follows.calls.Load(),
+			} {
+				if got != tt.wantQueries {
+					t.Errorf("%s called %d times for %d keys, want %d", name, got, tt.users, tt.wantQueries)
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderCachesKeys(t *testing.T) {
+	f := newFixture(t, 3)
+	ctx := context.Background()
+
+	// 같은 키를 여러 번 요청해도 배치에는 한 번만 들어가고, 다시 Load 하면 캐시에서 돌려줍니다.
+	loadConcurrently(t, 9, func(i int) error {
+		_, err := f.loaders.ProfileByUserID.Load(ctx, f.userIDs[i%3])
+		return err
+	})
+	if _, err := f.loaders.ProfileByUserID.Load(ctx, f.userIDs[0]); err != nil {
+		t.Fatalf("load cached: %v", err)
+	}
+
+	if got := f.profiles.calls.Load(); got != 1 {
+		t.Fatalf("FindByUserIDs called %d times, want 1", got)
+	}
+}
+
+func TestLoaderMissingKeyIsZero(t *testing.T) {
+	f := newFixture(t, 1)
+
+	p, err := f.loaders.ProfileByUserID.Load(context.Background(), uuid.New())
+	if err != nil || p != nil {
+		t.Fatalf("profile = %v, err = %v, want nil, nil", p, err)
+	}
+}
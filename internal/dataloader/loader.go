@@ -0,0 +1,113 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWait     = 2 * time.Millisecond // 같은 tick 에 요청된 키를 모으는 시간
+	defaultMaxBatch = 100                  // 한 번의 IN 쿼리에 넣을 최대 키 수
+)
+
+// FetchFunc: keys 를 한 번에 조회합니다. 결과에 없는 키는 zero value 로 처리됩니다.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable] struct {
+	keys       []K
+	dispatched bool
+}
+
+// Loader: 요청 하나 동안 같은 키의 조회를 모아 한 번의 쿼리로 처리하고 결과를 캐시합니다.
+// 요청마다 새로 만들어야 합니다. (캐시 무효화 없음)
+type Loader[K comparable, V any] struct {
+	ctx      context.Context // fetch 에 사용할 요청 Context
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K]
+}
+
+func NewLoader[K comparable, V any](ctx context.Context, fetch FetchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:      ctx,
+		fetch:    fetch,
+		wait:     defaultWait,
+		maxBatch: defaultMaxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load: 현재 배치에 키를 추가하고 결과를 기다립니다.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.cache[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.cache[key] = r
+		l.enqueue(key)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue: l.mu 를 잡은 상태에서 호출합니다.
+func (l *Loader[K, V]) enqueue(key K) {
+	if l.batch == nil {
+		b := &batch[K]{}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.dispatch(b) })
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	if len(l.batch.keys) >= l.maxBatch {
+		b := l.batch
+		l.batch = nil // 이후 키는 새 배치로
+		go l.dispatch(b)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K]) {
+	l.mu.Lock()
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	b.dispatched = true
+	if l.batch == b {
+		l.batch = nil
+	}
+	keys := b.keys
+	results := make([]*result[V], len(keys))
+	for i, k := range keys {
+		results[i] = l.cache[k]
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(l.ctx, keys)
+	for i, k := range keys {
+		if err != nil {
+			results[i].err = err
+		} else {
+			results[i].value = values[k]
+		}
+		close(results[i].done)
+	}
+}
@@ -0,0 +1,120 @@
+package dataloader
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+type ctxKey struct{}
+
+// FollowKey: (조회하는 사용자, 대상 사용자)
+type FollowKey struct {
+	ViewerID uuid.UUID
+	TargetID uuid.UUID
+}
+
+// Loaders: 요청 하나에서 공유하는 DataLoader 묶음
+type Loaders struct {
+	ProfileByUserID *Loader[uuid.UUID, *models.Profile] // 없는 사용자는 nil
+	TodosByEventID  *Loader[uuid.UUID, []models.Todo]
+	FollowState     *Loader[FollowKey, bool]
+}
+
+// Factory: 요청마다 Loaders 를 생성합니다.
+type Factory struct {
+	profilesRepo repository.ProfileRepositoryInterface
+	todosRepo    repository.TodosRepositoryInterface
+	followsRepo  repository.FollowsRepositoryInterface
+}
+
+func NewFactory(
+	profilesRepo repository.ProfileRepositoryInterface,
+	todosRepo repository.TodosRepositoryInterface,
+	followsRepo repository.FollowsRepositoryInterface,
+) *Factory {
+	return &Factory{
+		profilesRepo: profilesRepo,
+		todosRepo:    todosRepo,
+		followsRepo:  followsRepo,
+	}
+}
+
+func (f *Factory) New(ctx context.Context) *Loaders {
+	return &Loaders{
+		ProfileByUserID: NewLoader(ctx, f.fetchProfiles),
+		TodosByEventID:  NewLoader(ctx, f.fetchTodos),
+		FollowState:     NewLoader(ctx, f.fetchFollowState),
+	}
+}
+
+// Middleware: GraphQL 라우트에 등록합니다.
+// websocket 연결은 수명이 길어 캐시가 오래되므로 제외합니다. (resolver 가 호출마다 새로 생성)
+func (f *Factory) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.IsWebsocket() {
+			ctx := c.Request.Context()
+			c.Request = c.Request.WithContext(context.WithValue(ctx, ctxKey{}, f.New(ctx)))
+		}
+		c.Next()
+	}
+}
+
+// For: Middleware 를 거치지 않은 Context 면 nil 을 반환합니다.
+func For(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(ctxKey{}).(*Loaders)
+	return loaders
+}
+
+// -------------------------
+// 배치 조회
+// -------------------------
+
+func (f *Factory) fetchProfiles(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*models.Profile, error) {
+	profiles, err := f.profilesRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]*models.Profile, len(profiles))
+	for _, p := range profiles {
+		result[p.UserID] = p
+	}
+	return result, nil
+}
+
+func (f *Factory) fetchTodos(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID][]models.Todo, error) {
+	todos, err := f.todosRepo.FindByEventIDs(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID][]models.Todo, len(eventIDs))
+	for _, t := range todos {
+		result[t.CalendarEventID] = append(result[t.CalendarEventID], t)
+	}
+	return result, nil
+}
+
+// fetchFollowState: 보통 viewer 는 한 명이므로 viewer 별로 한 번씩 조회합니다.
+func (f *Factory) fetchFollowState(ctx context.Context, keys []FollowKey) (map[FollowKey]bool, error) {
+	targetsByViewer := make(map[uuid.UUID][]uuid.UUID)
+	for _, k := range keys {
+		targetsByViewer[k.ViewerID] = append(targetsByViewer[k.ViewerID], k.TargetID)
+	}
+
+	result := make(map[FollowKey]bool, len(keys))
+	for viewerID, targetIDs := range targetsByViewer {
+		followed, err := f.followsRepo.FindFollowedAmong(ctx, viewerID, targetIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, targetID := range followed {
+			result[FollowKey{ViewerID: viewerID, TargetID: targetID}] = true
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package e2etest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// AuthServerAddr: bufconn 은 주소를 보지 않으므로 아무 값이나 됩니다. (config grpc.auth_server_addr)
+const AuthServerAddr = "bufnet"
+
+const bufSize = 1 << 20
+
+// AuthServer: bufconn 위에서 동작하는 auth gRPC 서버 stub. 네트워크 없이 grpcclient.NewGrpcClients 가 연결됩니다.
+// 기본으로 표준 health 서비스만 등록되어 있습니다.
+type AuthServer struct {
+	Server *grpc.Server
+	Health *health.Server
+
+	lis *bufconn.Listener
+}
+
+// StartAuthServer: register 로 auth 서비스 구현을 추가할 수 있습니다. 서버는 테스트 종료 시 정리됩니다.
+func StartAuthServer(t testing.TB, register ...func(*grpc.Server)) *AuthServer {
+	t.Helper()
+
+	s := &AuthServer{
+		Server: grpc.NewServer(),
+		Health: health.NewServer(),
+		lis:    bufconn.Listen(bufSize),
+	}
+	healthpb.RegisterHealthServer(s.Server, s.Health)
+	for _, r := range register {
+		r(s.Server)
+	}
+
+	go s.Server.Serve(s.lis)
+	t.Cleanup(s.Server.Stop)
+	return s
+}
+
+// DialOption: NewGrpcClients / bootstrap.WithGrpcDialOptions 에 넘길 bufconn dialer
+func (s *AuthServer) DialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.lis.DialContext(ctx)
+	})
+}
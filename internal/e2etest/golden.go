@@ -0,0 +1,110 @@
+package e2etest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// UPDATE_GOLDEN=1 go test ./internal/e2etest/... 로 golden 파일을 다시 씁니다.
+const updateGoldenEnv = "UPDATE_GOLDEN"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// volatileKeys: 요청마다 값이 바뀌는 필드. golden 에는 "<key>" 로 기록합니다.
+var volatileKeys = map[string]bool{
+	"createdAt": true,
+	"updatedAt": true,
+	"requestId": true,
+}
+
+// AssertGolden: body(JSON) 를 정규화해 testdata/<name>.golden 과 비교합니다.
+//
+//   - UUID 는 처음 나온 순서대로 <uuid-1>, <uuid-2> ... 로 바뀌어 같은 ID 끼리의 관계는 유지됩니다.
+//   - volatileKeys 의 값은 "<key>" 로 바뀝니다.
+//   - 객체 키는 정렬되어 기록됩니다.
+func AssertGolden(t testing.TB, name string, body []byte) {
+	t.Helper()
+
+	got, err := normalizeJSON(body)
+	if err != nil {
+		t.Fatalf("normalize response: %v\n%s", err, body)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(updateGoldenEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create testdata dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with %s=1 to create)", path, err, updateGoldenEnv)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("response does not match %s (run with %s=1 to update)\n--- got\n%s\n--- want\n%s", path, updateGoldenEnv, got, want)
+	}
+}
+
+func normalizeJSON(body []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+	v = normalizeValue(v, ids)
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false) // <uuid-1> 그대로 기록
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func normalizeValue(v any, ids map[string]string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		// 번호가 매번 같도록 키 순서대로 방문합니다.
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			child := v[k]
+			if volatileKeys[k] && child != nil {
+				v[k] = "<" + k + ">"
+				continue
+			}
+			v[k] = normalizeValue(child, ids)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = normalizeValue(child, ids)
+		}
+		return v
+	case string:
+		if !uuidPattern.MatchString(v) {
+			return v
+		}
+		if id, ok := ids[v]; ok {
+			return id
+		}
+		id := fmt.Sprintf("<uuid-%d>", len(ids)+1)
+		ids[v] = id
+		return id
+	default:
+		return v
+	}
+}
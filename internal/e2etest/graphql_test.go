@@ -0,0 +1,218 @@
+package e2etest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/e2etest"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+var (
+	aliceID = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	bobID   = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+)
+
+const profileFields = `
+	userID
+	nickname
+	bio
+	theme
+	followerCount
+	followingCount
+	followedByMe
+	version
+`
+
+// seedProfiles: alice, bob 프로필과 bob → alice 팔로우
+func seedProfiles(t *testing.T, h *e2etest.Harness) {
+	t.Helper()
+
+	ctx := context.Background()
+	for _, p := range []*models.Profile{
+		{UserID: aliceID, Nickname: "alice", Bio: "hello", FollowerCount: 1},
+		{UserID: bobID, Nickname: "bob", FollowingCount: 1},
+	} {
+		if err := h.Repos.Profile.Create(ctx, p); err != nil {
+			t.Fatalf("seed profile %s: %v", p.Nickname, err)
+		}
+	}
+	h.Store.AddFollow(bobID, aliceID)
+}
+
+func TestProfileQueries(t *testing.T) {
+	h := e2etest.New(t)
+	seedProfiles(t, h)
+
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]any
+		token string
+	}{
+		{
+			name:  "my_profile",
+			query: `query { myProfile {` + profileFields + `} }`,
+			token: h.Token(t, aliceID),
+		},
+		{
+			name:  "user_profile_followed_by_me",
+			query: `query($userId: ID!) { userProfile(userId: $userId) {` + profileFields + `} }`,
+			vars:  map[string]any{"userId": aliceID.String()},
+			token: h.Token(t, bobID),
+		},
+		{
+			name:  "my_profile_unauthenticated",
+			query: `query { myProfile {` + profileFields + `} }`,
+		},
+		{
+			name:  "nickname_taken",
+			query: `query { checkNicknameAvailability(nickname: "alice") { available message } }`,
+			token: h.Token(t, bobID),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := h.Do(t, e2etest.Request{Query: tt.query, Variables: tt.vars, Token: tt.token})
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", resp.StatusCode, resp.Body)
+			}
+			e2etest.AssertGolden(t, "profile/"+tt.name, resp.Body)
+		})
+	}
+}
+
+// TestRejectsUnverifiedTokens: 서명이 없거나 다른 키로 서명된 토큰은 sub 가 있어도 unauthorized
+func TestRejectsUnverifiedTokens(t *testing.T) {
+	h := e2etest.New(t)
+	seedProfiles(t, h)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   aliceID.String(),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	sign := func(method jwt.SigningMethod, key any) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return token
+	}
+
+	tokens := map[string]string{
+		"unsigned":  sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType),
+		"wrong_key": sign(jwt.SigningMethodHS256, []byte("not-"+e2etest.TestJWTSecret)),
+	}
+	for name, token := range tokens {
+		t.Run(name, func(t *testing.T) {
+			resp := h.Do(t, e2etest.Request{
+				Query: `query { myProfile {` + profileFields + `} }`,
+				Token: token,
+			})
+			e2etest.AssertGolden(t, "auth/"+name, resp.Body)
+		})
+	}
+}
+
+func TestCalendarEventLifecycle(t *testing.T) {
+	h := e2etest.New(t)
+	seedProfiles(t, h)
+	token := h.Token(t, aliceID)
+
+	create := e2etest.Request{
+		Query: `mutation($input: CreateCalendarInput!) {
+			createCalendarEvent(input: $input) {
+				id title emoji startAt endAt visibility version
+				todos { id content isDone }
+			}
+		}`,
+		Variables: map[string]any{
+			"input": map[string]any{
+				"title":   "Team dinner",
+				"emoji":   "🍜",
+				"startAt": "2025-03-14T18:00:00Z",
+				"endAt":   "2025-03-14T20:00:00Z",
+				"todos":   []map[string]any{{"content": "book a table"}, {"content": "invite bob"}},
+			},
+		},
+		Token:          token,
+		IdempotencyKey: "create-dinner",
+	}
+	created := h.Do(t, create)
+	e2etest.AssertGolden(t, "calendar/create", created.Body)
+
+	// 같은 Idempotency-Key 재시도는 최초 응답을 그대로 돌려받습니다.
+	if replay := h.Do(t, create); !bytes.Equal(replay.Body, created.Body) {
+		t.Errorf("idempotent replay differs\n--- first\n%s\n--- replay\n%s", created.Body, replay.Body)
+	}
+
+	list := h.Do(t, e2etest.Request{
+		Query: `query {
+			myCalendarEvents(year: 2025, month: 3) {
+				title startAt endAt visibility
+				todos { content isDone }
+			}
+		}`,
+		Token: token,
+	})
+	e2etest.AssertGolden(t, "calendar/my_month", list.Body)
+
+	day := h.Do(t, e2etest.Request{
+		Query: `query($date: Time!) {
+			myCalendarEventsByDate(date: $date) { id title version createdAt updatedAt }
+		}`,
+		Variables: map[string]any{"date": "2025-03-14T00:00:00Z"},
+		Token:     token,
+	})
+	e2etest.AssertGolden(t, "calendar/my_day", day.Body)
+}
+
+// TestUpdateProfileConflict: 같은 version 을 보고 보낸 두 번째 수정은 서버 최신본과 함께 CONFLICT 로 거절됩니다.
+func TestUpdateProfileConflict(t *testing.T) {
+	h := e2etest.New(t)
+	seedProfiles(t, h)
+	token := h.Token(t, aliceID)
+
+	update := func(bio string) *e2etest.Response {
+		return h.Do(t, e2etest.Request{
+			Query: `mutation($input: UpdateProfileInput!) {
+				updateMyProfile(input: $input, expectedVersion: 1) { bio version }
+			}`,
+			Variables: map[string]any{"input": map[string]any{"bio": bio}},
+			Token:     token,
+		})
+	}
+
+	if resp := update("first"); bytes.Contains(resp.Body, []byte(`"errors"`)) {
+		t.Fatalf("first update: %s", resp.Body)
+	}
+	e2etest.AssertGolden(t, "profile/update_conflict", update("second").Body)
+}
+
+// TestSensitiveFieldRateLimit: 닉네임 확인을 burst 이상 호출하면 RATE_LIMITED 로 실행 전에 거절됩니다.
+func TestSensitiveFieldRateLimit(t *testing.T) {
+	h := e2etest.New(t,
+		e2etest.WithConfig("graphql.sensitive_rate_limit_rps", "0.001"),
+		e2etest.WithConfig("graphql.sensitive_rate_limit_burst", "2"),
+	)
+	seedProfiles(t, h)
+
+	req := e2etest.Request{
+		Query: `query {
+			a: checkNicknameAvailability(nickname: "carol") { available }
+			b: checkNicknameAvailability(nickname: "dave") { available }
+		}`,
+		Token: h.Token(t, bobID),
+	}
+	if resp := h.Do(t, req); bytes.Contains(resp.Body, []byte(`"errors"`)) {
+		t.Fatalf("first request: %s", resp.Body)
+	}
+
+	e2etest.AssertGolden(t, "limits/rate_limited", h.Do(t, req).Body)
+}
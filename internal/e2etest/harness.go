@@ -0,0 +1,232 @@
+// Package e2etest: in-memory repository 와 bufconn auth 서버 위에 전체 Gin 라우터를 띄우고
+// GraphQL 요청을 보내는 end-to-end 테스트 harness 입니다.
+package e2etest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/config"
+	"github.com/rainbow96bear/planet_user_server/internal/bootstrap"
+	"github.com/rainbow96bear/planet_user_server/internal/handler"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/router"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"google.golang.org/grpc"
+)
+
+// TestJWTSecret: harness 가 토큰을 서명하는 auth.jwt_secret_key
+const TestJWTSecret = "e2e-test-secret"
+
+// defaultConfig: 필수 설정과 외부 의존성이 없는 backend (flag 이름 = 값)
+var defaultConfig = map[string]string{
+	"server.port":            "0",
+	"server.grpc_port":       "0",
+	"grpc.service_token":     "e2e-service-token",
+	"grpc.auth_server_addr":  AuthServerAddr,
+	"grpc.db_server_addr":    "unused",
+	"log.level":              "0",
+	"auth.jwt_secret_key":    TestJWTSecret,
+	"db.user":                "unused",
+	"db.password":            "unused",
+	"db.host":                "unused",
+	"db.port":                "5432",
+	"db.name":                "unused",
+	"todo.max_length":        "100",
+	"outbox.publisher":       "log",
+	"graphql.apq_cache":      "memory",
+	"calendar_cache.backend": "memory",
+}
+
+type options struct {
+	config       map[string]string
+	authRegister []func(*grpc.Server)
+}
+
+type Option func(*options)
+
+// WithConfig: 설정 키 (예: graphql.max_depth) 를 덮어씁니다.
+func WithConfig(key, value string) Option {
+	return func(o *options) { o.config[key] = value }
+}
+
+// WithAuthService: stub auth 서버에 서비스 구현을 등록합니다.
+func WithAuthService(register func(*grpc.Server)) Option {
+	return func(o *options) { o.authRegister = append(o.authRegister, register) }
+}
+
+// Harness: 테스트 하나가 사용하는 서버. Store 로 데이터를 미리 넣거나 결과를 확인합니다.
+type Harness struct {
+	Config *config.Config
+	Store  *memory.Store
+	Repos  *bootstrap.Repositories
+	Deps   *bootstrap.Dependencies
+	Auth   *AuthServer
+	Server *httptest.Server
+}
+
+// New: bootstrap.InitDependencies → InitHandlers → router.SetupRouter 를 main 과 같은 순서로 구성합니다.
+// DB 는 nil 이므로 tx.RunInTx 는 트랜잭션 없이 실행됩니다.
+func New(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	o := options{config: make(map[string]string, len(defaultConfig))}
+	for k, v := range defaultConfig {
+		o.config[k] = v
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := loadConfig(t, o.config)
+	auth := StartAuthServer(t, o.authRegister...)
+
+	store := memory.NewStore()
+	repos := NewMemoryRepositories(store)
+
+	deps, err := bootstrap.InitDependencies(nil, cfg,
+		bootstrap.WithRepositories(repos),
+		bootstrap.WithGrpcDialOptions(auth.DialOption()),
+	)
+	if err != nil {
+		t.Fatalf("init dependencies: %v", err)
+	}
+	t.Cleanup(func() { deps.GrpcClients.AuthConn.Close() })
+
+	handlers, err := bootstrap.InitHandlers(deps, handler.BuildInfo{Version: "e2e"})
+	if err != nil {
+		t.Fatalf("init handlers: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := router.SetupRouter(func(r *gin.Engine) {
+		for _, h := range handlers {
+			h.RegisterRoutes(r)
+		}
+	})
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return &Harness{
+		Config: cfg,
+		Store:  store,
+		Repos:  repos,
+		Deps:   deps,
+		Auth:   auth,
+		Server: server,
+	}
+}
+
+// NewMemoryRepositories: 모든 repository 를 같은 Store 위의 in-memory 구현으로 구성합니다.
+func NewMemoryRepositories(store *memory.Store) *bootstrap.Repositories {
+	return &bootstrap.Repositories{
+		Profile:        memory.NewProfileRepository(store),
+		CalendarEvents: memory.NewCalendarEventsRepository(store),
+		Todos:          memory.NewTodosRepository(store),
+		EventTemplates: memory.NewCalendarEventTemplatesRepository(store),
+		Idempotency:    memory.NewIdempotencyKeysRepository(store),
+		Outbox:         memory.NewOutboxEventsRepository(store),
+		Follows:        memory.NewFollowsRepository(store),
+		PersistedQuery: memory.NewPersistedQueriesRepository(store),
+	}
+}
+
+// loadConfig: 실제 서버와 같은 Loader 를 플래그 override 로 사용합니다. (dev 모드)
+func loadConfig(t testing.TB, values map[string]string) *config.Config {
+	t.Helper()
+
+	loader := config.NewLoader()
+	fs := flag.NewFlagSet("e2e", flag.ContinueOnError)
+	loader.RegisterFlags(fs)
+
+	args := make([]string, 0, len(values))
+	for k, v := range values {
+		args = append(args, "-"+k+"="+v)
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("parse config flags: %v", err)
+	}
+
+	cfg, err := loader.Load("dev")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	return cfg
+}
+
+// Token: userID 를 sub 로 갖는 HS256 access token 을 auth.jwt_secret_key 로 서명합니다.
+func (h *Harness) Token(t testing.TB, userID uuid.UUID) string {
+	t.Helper()
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+	})
+	signed, err := token.SignedString([]byte(h.Config.Auth.JWTSecretKey))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+// Request: POST /graphql 요청. Token 이 비어 있으면 비로그인 요청입니다.
+type Request struct {
+	Query          string
+	Variables      map[string]any
+	Token          string
+	IdempotencyKey string
+}
+
+// Response: 상태 코드와 원본 body
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Do: GraphQL 요청을 보내고 응답을 그대로 반환합니다.
+func (h *Harness) Do(t testing.TB, req Request) *Response {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{
+		"query":     req.Query,
+		"variables": req.Variables,
+	})
+	if err != nil {
+		t.Fatalf("marshal graphql request: %v", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, h.Server.URL+"/graphql", bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if req.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.Token)
+	}
+	if req.IdempotencyKey != "" {
+		httpReq.Header.Set(middleware.IdempotencyKeyHeader, req.IdempotencyKey)
+	}
+
+	resp, err := h.Server.Client().Do(httpReq)
+	if err != nil {
+		t.Fatalf("graphql request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read graphql response: %v", err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Body: body}
+}
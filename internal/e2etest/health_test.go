@@ -0,0 +1,37 @@
+package e2etest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/rainbow96bear/planet_user_server/internal/e2etest"
+)
+
+// TestReadyzWithoutDB: harness 는 DB 없이 뜨므로 /readyz 는 panic 없이 503 이어야 합니다.
+func TestReadyzWithoutDB(t *testing.T) {
+	h := e2etest.New(t)
+
+	resp, err := h.Server.Client().Get(h.Server.URL + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	var body struct {
+		Checks map[string]string `json:"checks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Checks["database"] != "unavailable" {
+		t.Errorf("checks.database = %q, want unavailable", body.Checks["database"])
+	}
+	if body.Checks["authGrpc"] == "TRANSIENT_FAILURE" || body.Checks["authGrpc"] == "SHUTDOWN" {
+		t.Errorf("checks.authGrpc = %q, want the bufconn stub to be reachable", body.Checks["authGrpc"])
+	}
+}
@@ -0,0 +1,57 @@
+package gqllimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// FieldCosts: "Type.field" → 배수.
+// 리스트 필드는 예상 항목 수를 넣으면 하위 선택의 비용이 그만큼 곱해집니다. (예: Calendar.todos=10)
+type FieldCosts map[string]int
+
+// ParseFieldCosts: "Calendar.todos=10,Query.myCalendarEvents=31" 형식을 파싱합니다.
+func ParseFieldCosts(s string) (FieldCosts, error) {
+	costs := FieldCosts{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		field, value, ok := strings.Cut(pair, "=")
+		if !ok || !strings.Contains(field, ".") {
+			return nil, fmt.Errorf("invalid field cost %q (want Type.field=N)", pair)
+		}
+		cost, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || cost < 1 {
+			return nil, fmt.Errorf("invalid field cost %q: must be a positive int", pair)
+		}
+		costs[strings.TrimSpace(field)] = cost
+	}
+	return costs, nil
+}
+
+// costSchema: 생성된 스키마의 Complexity 를 설정된 배수로 덮어씁니다.
+type costSchema struct {
+	graphql.ExecutableSchema
+	costs FieldCosts
+}
+
+// WithFieldCosts: complexity 계산에만 영향을 주며 실행은 원래 스키마가 처리합니다.
+func WithFieldCosts(es graphql.ExecutableSchema, costs FieldCosts) graphql.ExecutableSchema {
+	if len(costs) == 0 {
+		return es
+	}
+	return &costSchema{ExecutableSchema: es, costs: costs}
+}
+
+func (s *costSchema) Complexity(ctx context.Context, typeName, field string, childComplexity int, args map[string]any) (int, bool) {
+	if cost, ok := s.costs[typeName+"."+field]; ok {
+		return 1 + cost*childComplexity, true
+	}
+	return s.ExecutableSchema.Complexity(ctx, typeName, field, childComplexity, args)
+}
@@ -0,0 +1,201 @@
+package gqllimit_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/rainbow96bear/planet_user_server/internal/gqllimit"
+	"github.com/rainbow96bear/planet_user_server/internal/ratelimit"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+var testSchema = gqlparser.MustLoadSchema(&ast.Source{Name: "test.graphqls", Input: `
+	type Query {
+		me: User
+		checkNicknameAvailability(nickname: String!): Boolean!
+	}
+	type Mutation {
+		follow(userId: ID!): Boolean!
+	}
+	type User {
+		name: String!
+		friends: [User!]!
+	}
+`})
+
+func operation(t *testing.T, query string) *graphql.OperationContext {
+	t.Helper()
+
+	doc, errs := gqlparser.LoadQuery(testSchema, query)
+	if len(errs) > 0 {
+		t.Fatalf("load query: %v", errs)
+	}
+	return &graphql.OperationContext{Doc: doc, RawQuery: query, Operation: doc.Operations[0]}
+}
+
+func errCode(err *gqlerror.Error) any {
+	if err == nil {
+		return nil
+	}
+	return err.Extensions["code"]
+}
+
+func TestDepthLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  any
+	}{
+		{"flat", `{ me { name } }`, nil},
+		{"at limit", `{ me { friends { name } } }`, nil},
+		{"too deep", `{ me { friends { friends { name } } } }`, "DEPTH_LIMIT_EXCEEDED"},
+		{"introspection ignored", `{ me { friends { __typename } } }`, nil},
+		{"inline fragment", `{ me { ... on User { friends { friends { name } } } } }`, "DEPTH_LIMIT_EXCEEDED"},
+		{
+			"fragment spread",
+			`query { me { ...F } } fragment F on User { friends { friends { name } } }`,
+			"DEPTH_LIMIT_EXCEEDED",
+		},
+	}
+
+	limit := gqllimit.DepthLimit{Max: 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limit.MutateOperationContext(context.Background(), operation(t, tt.query))
+			if got := errCode(err); got != tt.want {
+				t.Fatalf("code = %v, want %v (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseFieldCosts(t *testing.T) {
+	costs, err := gqllimit.ParseFieldCosts(" User.friends=10, Query.me = 2 ,")
+	if err != nil {
+		t.Fatalf("ParseFieldCosts: %v", err)
+	}
+	if len(costs) != 2 || costs["User.friends"] != 10 || costs["Query.me"] != 2 {
+		t.Fatalf("costs = %v", costs)
+	}
+
+	for _, invalid := range []string{"friends=10", "User.friends", "User.friends=0", "User.friends=x"} {
+		if _, err := gqllimit.ParseFieldCosts(invalid); err == nil {
+			t.Errorf("ParseFieldCosts(%q) succeeded, want error", invalid)
+		}
+	}
+}
+
+// fakeSchema: complexity 계산에 필요한 Schema 만 제공하고 기본 비용을 사용합니다.
+type fakeSchema struct {
+	graphql.ExecutableSchema
+}
+
+func (fakeSchema) Schema() *ast.Schema { return testSchema }
+
+func (fakeSchema) Complexity(context.Context, string, string, int, map[string]any) (int, bool) {
+	return 0, false
+}
+
+func TestWithFieldCosts(t *testing.T) {
+	op := operation(t, `{ me { friends { name } } }`).Operation
+
+	if got := complexity.Calculate(context.Background(), gqllimit.WithFieldCosts(fakeSchema{}, nil), op, nil); got != 3 {
+		t.Fatalf("default complexity = %d, want 3", got)
+	}
+
+	// me(1) + friends(1 + 10 * name(1))
+	es := gqllimit.WithFieldCosts(fakeSchema{}, gqllimit.FieldCosts{"User.friends": 10})
+	if got := complexity.Calculate(context.Background(), es, op, nil); got != 12 {
+		t.Fatalf("weighted complexity = %d, want 12", got)
+	}
+}
+
+func clientCtx(ip string) context.Context {
+	return context.WithValue(context.Background(), middleware.ContextKeyClientIP, ip)
+}
+
+// slowLimiter: 테스트 동안 토큰이 다시 채워지지 않는 limiter
+func slowLimiter(burst int) *ratelimit.KeyedLimiter {
+	return ratelimit.NewKeyedLimiter(0.0001, burst, time.Minute)
+}
+
+func TestRateLimitCountsSensitiveFields(t *testing.T) {
+	r := gqllimit.NewRateLimit(gqllimit.RateLimitConfig{
+		Sensitive:       slowLimiter(3),
+		SensitiveFields: []string{"checkNicknameAvailability"},
+	})
+
+	// alias 2 번 + inline fragment 1 번 + fragment spread 1 번 = 4
+	fourCalls := operation(t, `
+		query {
+			a: checkNicknameAvailability(nickname: "a")
+			b: checkNicknameAvailability(nickname: "b")
+			... on Query { c: checkNicknameAvailability(nickname: "c") }
+			...D
+		}
+		fragment D on Query { d: checkNicknameAvailability(nickname: "d") }
+	`)
+	err := r.MutateOperationContext(clientCtx("10.0.0.1"), fourCalls)
+	if errCode(err) != "RATE_LIMIT_EXCEEDS_BURST" || !strings.Contains(err.Message, "4 sensitive") {
+		t.Fatalf("err = %v, want RATE_LIMIT_EXCEEDS_BURST for 4 calls", err)
+	}
+	if _, ok := err.Extensions["retryAfter"]; ok {
+		t.Fatal("burst rejection must not carry retryAfter")
+	}
+
+	threeCalls := operation(t, `{
+		a: checkNicknameAvailability(nickname: "a")
+		b: checkNicknameAvailability(nickname: "b")
+		me { name }
+		... on Query { c: checkNicknameAvailability(nickname: "c") }
+	}`)
+	if err := r.MutateOperationContext(clientCtx("10.0.0.1"), threeCalls); err != nil {
+		t.Fatalf("three calls: %v", err)
+	}
+
+	err = r.MutateOperationContext(clientCtx("10.0.0.1"), operation(t, `{ checkNicknameAvailability(nickname: "e") }`))
+	if errCode(err) != "RATE_LIMITED" || err.Extensions["retryAfter"] == nil {
+		t.Fatalf("err = %v, want RATE_LIMITED with retryAfter", err)
+	}
+
+	// 다른 클라이언트는 별도의 bucket
+	if err := r.MutateOperationContext(clientCtx("10.0.0.2"), threeCalls); err != nil {
+		t.Fatalf("other client: %v", err)
+	}
+}
+
+func TestRateLimitRefundsOnRejection(t *testing.T) {
+	r := gqllimit.NewRateLimit(gqllimit.RateLimitConfig{
+		Operation: slowLimiter(3),
+		Mutation:  slowLimiter(1),
+	})
+	ctx := clientCtx("10.0.0.1")
+	follow := operation(t, `mutation { follow(userId: "1") }`)
+	query := operation(t, `{ me { name } }`)
+
+	if err := r.MutateOperationContext(ctx, follow); err != nil {
+		t.Fatalf("first mutation: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := r.MutateOperationContext(ctx, follow); errCode(err) != "RATE_LIMITED" {
+			t.Fatalf("mutation %d: err = %v, want RATE_LIMITED", i+2, err)
+		}
+	}
+
+	// 거절된 mutation 은 operation bucket 을 쓰지 않았으므로 2 번 더 허용됩니다.
+	for i := 0; i < 2; i++ {
+		if err := r.MutateOperationContext(ctx, query); err != nil {
+			t.Fatalf("query %d: %v", i+1, err)
+		}
+	}
+	if err := r.MutateOperationContext(ctx, query); errCode(err) != "RATE_LIMITED" {
+		t.Fatalf("err = %v, want RATE_LIMITED", err)
+	}
+}
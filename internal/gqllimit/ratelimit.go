@@ -0,0 +1,154 @@
+package gqllimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"github.com/rainbow96bear/planet_user_server/internal/ratelimit"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"github.com/rainbow96bear/planet_user_server/utils"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	errRateLimited  = "RATE_LIMITED"
+	errExceedsBurst = "RATE_LIMIT_EXCEEDS_BURST"
+)
+
+// RateLimitConfig: nil limiter 는 해당 bucket 을 제한하지 않습니다.
+type RateLimitConfig struct {
+	Operation *ratelimit.KeyedLimiter // 모든 operation
+	Mutation  *ratelimit.KeyedLimiter // mutation operation 에 추가로 적용
+
+	// Sensitive: SensitiveFields 에 해당하는 루트 필드 호출 수만큼 토큰을 소비합니다.
+	// (alias 로 한 요청에 여러 번 호출해도 각각 계산 → 닉네임 열거 방지)
+	Sensitive       *ratelimit.KeyedLimiter
+	SensitiveFields []string
+}
+
+// RateLimit: 사용자 ID(토큰이 없으면 클라이언트 IP) 기준으로 operation 을 제한합니다.
+type RateLimit struct {
+	cfg       RateLimitConfig
+	sensitive map[string]struct{}
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = &RateLimit{}
+
+func NewRateLimit(cfg RateLimitConfig) *RateLimit {
+	sensitive := make(map[string]struct{}, len(cfg.SensitiveFields))
+	for _, f := range cfg.SensitiveFields {
+		sensitive[f] = struct{}{}
+	}
+	return &RateLimit{cfg: cfg, sensitive: sensitive}
+}
+
+func (*RateLimit) ExtensionName() string {
+	return "RateLimit"
+}
+
+func (*RateLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// charge: 한 bucket 에서 소비할 토큰
+type charge struct {
+	bucket  string
+	limiter *ratelimit.KeyedLimiter
+	n       int
+}
+
+func (r *RateLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+	key := clientKey(ctx)
+
+	charges := []charge{{bucket: "operation", limiter: r.cfg.Operation, n: 1}}
+	if opCtx.Operation.Operation == ast.Mutation {
+		charges = append(charges, charge{bucket: "mutation", limiter: r.cfg.Mutation, n: 1})
+	}
+	if n := r.countSensitive(opCtx.Operation.SelectionSet, map[string]bool{}); n > 0 {
+		charges = append(charges, charge{bucket: "sensitive", limiter: r.cfg.Sensitive, n: n})
+	}
+
+	for i, c := range charges {
+		wait, err := c.limiter.Allow(key, c.n)
+		if err == nil {
+			continue
+		}
+
+		// 거절된 요청이 앞 bucket 의 한도를 쓰지 않도록 되돌립니다.
+		for _, prev := range charges[:i] {
+			prev.limiter.Refund(key, prev.n)
+		}
+		if errors.Is(err, ratelimit.ErrExceedsBurst) {
+			return exceedsBurst(ctx, c.bucket, c.n)
+		}
+		return rateLimited(ctx, c.bucket, wait)
+	}
+	return nil
+}
+
+// countSensitive: 루트 selection 에서 민감 필드 호출 수를 셉니다. (fragment 포함)
+func (r *RateLimit) countSensitive(set ast.SelectionSet, visiting map[string]bool) int {
+	n := 0
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			if _, ok := r.sensitive[s.Name]; ok {
+				n++
+			}
+		case *ast.InlineFragment:
+			n += r.countSensitive(s.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			if s.Definition == nil || visiting[s.Name] {
+				continue
+			}
+			visiting[s.Name] = true
+			n += r.countSensitive(s.Definition.SelectionSet, visiting)
+			delete(visiting, s.Name)
+		}
+	}
+	return n
+}
+
+// clientKey: 토큰의 사용자 ID, 없으면 클라이언트 IP
+func clientKey(ctx context.Context) string {
+	if token, err := middleware.ExtractAccessToken(ctx); err == nil {
+		if userID, err := utils.GetUserID(token); err == nil {
+			return "user:" + userID.String()
+		}
+	}
+	return "ip:" + middleware.ExtractClientIP(ctx)
+}
+
+func rateLimited(ctx context.Context, bucket string, wait time.Duration) *gqlerror.Error {
+	metrics.GraphQLRateLimited.WithLabelValues(bucket).Inc()
+	logctx.Warnf(ctx, "graphql rate limited bucket=%s retryAfter=%s", bucket, wait)
+
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	err := gqlerror.Errorf("rate limit exceeded, retry after %d seconds", retryAfter)
+	errcode.Set(err, errRateLimited)
+	err.Extensions["retryAfter"] = retryAfter
+	return err
+}
+
+// exceedsBurst: 한 요청이 burst 보다 많은 토큰을 요구하면 기다려도 통과할 수 없으므로 retryAfter 없이 거절합니다.
+func exceedsBurst(ctx context.Context, bucket string, n int) *gqlerror.Error {
+	metrics.GraphQLRateLimited.WithLabelValues(bucket).Inc()
+	logctx.Warnf(ctx, "graphql rate limit burst exceeded bucket=%s tokens=%d", bucket, n)
+
+	err := gqlerror.Errorf("operation uses %d %s calls, which exceeds the rate limit burst", n, bucket)
+	errcode.Set(err, errExceedsBurst)
+	return err
+}
@@ -1,21 +1,44 @@
 package grpcclient
 
 import (
-	"github.com/rainbow96bear/planet_user_server/config"
+	"context"
+
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type GrpcClients struct {
 	Auth AuthAPI
+
+	AuthConn *grpc.ClientConn // 연결 상태 확인(/readyz)용
 }
 
-func NewGrpcClients() (*GrpcClients, error) {
-	authConn, err := grpc.Dial(config.AUTH_GRPC_SERVER_ADDR, grpc.WithInsecure())
+// NewGrpcClients: opts 는 기본 dial option 뒤에 추가됩니다. (테스트의 bufconn dialer 등)
+func NewGrpcClients(authAddr string, opts ...grpc.DialOption) (*GrpcClients, error) {
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()), // trace context 전파
+		grpc.WithChainUnaryInterceptor(requestIDUnaryClientInterceptor()),
+	}, opts...)
+	authConn, err := grpc.Dial(authAddr, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &GrpcClients{
-		Auth: NewAuthAPI(authConn),
+		Auth:     NewAuthAPI(authConn),
+		AuthConn: authConn,
 	}, nil
 }
+
+// requestIDUnaryClientInterceptor: 현재 요청의 request ID 를 x-request-id metadata 로 전달합니다.
+func requestIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if requestID := logctx.RequestID(ctx); requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, logctx.RequestIDMetadataKey, requestID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
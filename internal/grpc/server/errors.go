@@ -0,0 +1,56 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError: 서비스 에러를 gRPC status 코드로 변환합니다.
+// 내부 에러 메시지는 클라이언트에 노출하지 않습니다.
+func toStatusError(ctx context.Context, method string, err error) error {
+	var codeErr *planet_err.CodeError
+	if errors.As(err, &codeErr) && codeErr.Code == planet_err.CodeNicknameTaken {
+		return nicknameTakenStatus(codeErr)
+	}
+
+	switch {
+	case planet_err.IsNotFound(err):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, planet_err.ErrNicknameDuplicate), planet_err.IsAlreadyExists(err):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case planet_err.IsVersionConflict(err):
+		return status.Error(codes.Aborted, "resource was modified by another request")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	}
+
+	logctx.Errorf(ctx, "[gRPC] %s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// nicknameTakenStatus: ALREADY_EXISTS + ErrorInfo(metadata.suggestions) 로 대체 닉네임을 전달합니다.
+func nicknameTakenStatus(codeErr *planet_err.CodeError) error {
+	suggestions, _ := codeErr.Data["suggestions"].([]string)
+
+	st := status.New(codes.AlreadyExists, codeErr.Message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(planet_err.CodeNicknameTaken),
+		Domain: "planet.user",
+		Metadata: map[string]string{
+			"suggestions": strings.Join(suggestions, ","),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
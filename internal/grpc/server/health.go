@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
+)
+
+const (
+	healthCheckInterval = 5 * time.Second
+	healthPingTimeout   = 2 * time.Second
+)
+
+// HealthChecker: grpc.health.v1 상태를 DB ping 결과와 종료 상태에 맞춰 갱신합니다.
+// 서비스 이름 "" 는 서버 전체 상태를 의미합니다.
+type HealthChecker struct {
+	server *health.Server
+	db     *gorm.DB
+}
+
+func NewHealthChecker(db *gorm.DB) *HealthChecker {
+	return &HealthChecker{
+		server: health.NewServer(),
+		db:     db,
+	}
+}
+
+func (h *HealthChecker) Register(s grpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(s, h.server)
+}
+
+// Run: ctx 가 취소될 때까지 주기적으로 DB 를 확인합니다.
+func (h *HealthChecker) Run(ctx context.Context) {
+	h.check(ctx)
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.check(ctx)
+		}
+	}
+}
+
+func (h *HealthChecker) check(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := h.pingDB(ctx); err != nil {
+		logger.Warnf("[gRPC Health] database ping failed: %v", err)
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.server.SetServingStatus("", status)
+}
+
+func (h *HealthChecker) pingDB(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(pingCtx)
+}
+
+// Shutdown: 모든 서비스를 NOT_SERVING 으로 고정합니다. 이후 상태 갱신은 무시됩니다.
+func (h *HealthChecker) Shutdown() {
+	h.server.Shutdown()
+}
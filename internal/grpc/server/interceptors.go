@@ -0,0 +1,269 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceTokenMetadataKey: 서비스 간 인증 토큰 metadata 키
+// ("authorization: Bearer <token>" 도 허용합니다.)
+const ServiceTokenMetadataKey = "x-service-token"
+
+// InterceptorConfig: gRPC 서버 인터셉터 설정
+type InterceptorConfig struct {
+	ServiceToken          string        // 공유 비밀 토큰
+	DefaultTimeout        time.Duration // 클라이언트 deadline 이 없는 unary 호출에 적용
+	DefaultStreamTimeout  time.Duration // 0 이면 stream 에는 deadline 을 걸지 않음
+	UnauthenticatedPrefix []string      // 인증 없이 허용할 메서드 prefix
+}
+
+// ServerOptions: 인터셉터 체인을 gRPC 서버 옵션으로 반환합니다.
+// 순서: metrics → request ID → logging → recovery → auth → deadline → handler
+// (tracing span 은 stats handler 에서 인터셉터보다 먼저 시작됩니다.)
+func ServerOptions(cfg InterceptorConfig) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			metrics.UnaryServerInterceptor(),
+			requestIDUnaryInterceptor(),
+			loggingUnaryInterceptor(),
+			recoveryUnaryInterceptor(),
+			authUnaryInterceptor(cfg),
+			deadlineUnaryInterceptor(cfg.DefaultTimeout),
+		),
+		grpc.ChainStreamInterceptor(
+			metrics.StreamServerInterceptor(),
+			requestIDStreamInterceptor(),
+			loggingStreamInterceptor(),
+			recoveryStreamInterceptor(),
+			authStreamInterceptor(cfg),
+			deadlineStreamInterceptor(cfg.DefaultStreamTimeout),
+		),
+	}
+}
+
+// -------------------------
+// request ID
+// -------------------------
+
+// maxRequestIDLength: metadata 로 받은 request ID 의 최대 길이
+const maxRequestIDLength = 128
+
+func requestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestContext(ctx, info.FullMethod), req)
+	}
+}
+
+func requestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestContext(ss.Context(), info.FullMethod)
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withRequestContext: 호출자가 보낸 x-request-id 를 이어받고(없으면 생성) 응답 헤더로 돌려줍니다.
+func withRequestContext(ctx context.Context, method string) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logctx.RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = uuid.NewString()
+	}
+
+	ctx = logctx.NewContext(ctx, requestID)
+	logctx.SetOperation(ctx, method)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(logctx.RequestIDMetadataKey, requestID))
+	return ctx
+}
+
+// -------------------------
+// 로깅
+// -------------------------
+
+func loggingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logAccess(ctx, info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+func loggingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logAccess(ss.Context(), info.FullMethod, "stream", start, err)
+		return err
+	}
+}
+
+// logAccess: planet_utils logger 는 printf 형식만 지원하므로, request_id 필드와 민감정보 가림이 적용되는
+// logctx(slog JSON) 로 접근 로그를 남깁니다.
+func logAccess(ctx context.Context, method, kind string, start time.Time, err error) {
+	code := status.Code(err)
+	peerAddr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		peerAddr = p.Addr.String()
+	}
+
+	attrs := []any{
+		"method", method,
+		"type", kind,
+		"code", code.String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+		"peer", peerAddr,
+	}
+
+	log := logctx.Logger(ctx)
+	switch code {
+	case codes.OK:
+		log.Info("grpc request", attrs...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		log.Error("grpc request", append(attrs, "error", err.Error())...)
+	default:
+		log.Warn("grpc request", append(attrs, "error", err.Error())...)
+	}
+}
+
+// -------------------------
+// panic 복구
+// -------------------------
+
+func recoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverToStatus(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverToStatus(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverToStatus(ctx context.Context, method string, r interface{}) error {
+	logctx.Errorf(ctx, "[gRPC] panic in %s: %v\n%s", method, r, debug.Stack())
+	return status.Error(codes.Internal, "internal error")
+}
+
+// -------------------------
+// 서비스 간 인증
+// -------------------------
+
+func authUnaryInterceptor(cfg InterceptorConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, info.FullMethod, cfg); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(cfg InterceptorConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), info.FullMethod, cfg); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authorize(ctx context.Context, method string, cfg InterceptorConfig) error {
+	for _, prefix := range cfg.UnauthenticatedPrefix {
+		if strings.HasPrefix(method, prefix) {
+			return nil
+		}
+	}
+
+	token := serviceTokenFromMetadata(ctx)
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "missing service token")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.ServiceToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid service token")
+	}
+	return nil
+}
+
+func serviceTokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(ServiceTokenMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 {
+		return strings.TrimPrefix(values[0], "Bearer ")
+	}
+	return ""
+}
+
+// -------------------------
+// 기본 deadline
+// -------------------------
+
+func deadlineUnaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := withDefaultDeadline(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+func deadlineStreamInterceptor(timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withDefaultDeadline(ss.Context(), timeout)
+		defer cancel()
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withDefaultDeadline: 클라이언트가 deadline 을 보내지 않은 경우에만 timeout 을 적용합니다.
+func withDefaultDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// contextServerStream: stream 핸들러에 변경된 Context 를 전달합니다.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
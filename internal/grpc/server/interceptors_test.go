@@ -0,0 +1,203 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testServiceToken = "test-service-token"
+
+// probeHealth: service 이름이 "panic" 이면 panic 하고, 아니면 핸들러가 받은 deadline 을 기록합니다.
+type probeHealth struct {
+	healthpb.UnimplementedHealthServer
+
+	mu        sync.Mutex
+	remaining time.Duration // 핸들러가 본 남은 시간 (deadline 이 없으면 -1)
+}
+
+func (p *probeHealth) record(ctx context.Context, service string) {
+	if service == "panic" {
+		panic("boom")
+	}
+
+	remaining := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+	p.mu.Lock()
+	p.remaining = remaining
+	p.mu.Unlock()
+}
+
+func (p *probeHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	p.record(ctx, req.Service)
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (p *probeHealth) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	p.record(stream.Context(), req.Service)
+	return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+}
+
+func (p *probeHealth) lastRemaining() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.remaining
+}
+
+// startInterceptedServer: ServerOptions(cfg) 로 만든 서버를 bufconn 위에 띄웁니다.
+func startInterceptedServer(t *testing.T, cfg InterceptorConfig) (healthpb.HealthClient, *probeHealth) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(ServerOptions(cfg)...)
+	probe := &probeHealth{}
+	healthpb.RegisterHealthServer(server, probe)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn), probe
+}
+
+func withToken(key, value string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), key, value)
+}
+
+// watchOnce: Watch stream 의 첫 응답(또는 에러)을 반환합니다.
+func watchOnce(ctx context.Context, client healthpb.HealthClient, service string) error {
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return err
+	}
+	_, err = stream.Recv()
+	return err
+}
+
+func TestAuthorize(t *testing.T) {
+	client, _ := startInterceptedServer(t, InterceptorConfig{ServiceToken: testServiceToken})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"missing token", context.Background(), codes.Unauthenticated},
+		{"wrong token", withToken(ServiceTokenMetadataKey, "not-"+testServiceToken), codes.Unauthenticated},
+		{"wrong bearer", withToken("authorization", "Bearer not-"+testServiceToken), codes.Unauthenticated},
+		{"service token", withToken(ServiceTokenMetadataKey, testServiceToken), codes.OK},
+		{"bearer token", withToken("authorization", "Bearer "+testServiceToken), codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Check(tt.ctx, &healthpb.HealthCheckRequest{})
+			if status.Code(err) != tt.want {
+				t.Fatalf("Check code = %s, want %s (%v)", status.Code(err), tt.want, err)
+			}
+			if err := watchOnce(tt.ctx, client, ""); status.Code(err) != tt.want {
+				t.Fatalf("Watch code = %s, want %s (%v)", status.Code(err), tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUnauthenticatedPrefix(t *testing.T) {
+	client, _ := startInterceptedServer(t, InterceptorConfig{
+		ServiceToken:          testServiceToken,
+		UnauthenticatedPrefix: []string{"/grpc.health.v1.Health/"},
+	})
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check without token: %v", err)
+	}
+	if err := watchOnce(context.Background(), client, ""); err != nil {
+		t.Fatalf("Watch without token: %v", err)
+	}
+}
+
+func TestRecoveryReturnsInternal(t *testing.T) {
+	client, _ := startInterceptedServer(t, InterceptorConfig{ServiceToken: testServiceToken})
+	ctx := withToken(ServiceTokenMetadataKey, testServiceToken)
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "panic"})
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Fatalf("Check panic = %v, want Internal", err)
+	}
+	if err := watchOnce(ctx, client, "panic"); status.Code(err) != codes.Internal {
+		t.Fatalf("Watch panic = %v, want Internal", err)
+	}
+
+	// panic 이후에도 서버는 계속 응답합니다.
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check after panic: %v", err)
+	}
+}
+
+func TestDefaultDeadline(t *testing.T) {
+	client, probe := startInterceptedServer(t, InterceptorConfig{
+		ServiceToken:         testServiceToken,
+		DefaultTimeout:       2 * time.Second,
+		DefaultStreamTimeout: 3 * time.Second,
+	})
+	ctx := withToken(ServiceTokenMetadataKey, testServiceToken)
+
+	// deadline 이 없는 호출에는 기본값 적용
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if got := probe.lastRemaining(); got <= time.Second || got > 2*time.Second {
+		t.Fatalf("unary remaining = %s, want about 2s", got)
+	}
+
+	if err := watchOnce(ctx, client, ""); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if got := probe.lastRemaining(); got <= 2*time.Second || got > 3*time.Second {
+		t.Fatalf("stream remaining = %s, want about 3s", got)
+	}
+
+	// 클라이언트 deadline 은 그대로 유지
+	callCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if _, err := client.Check(callCtx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check with deadline: %v", err)
+	}
+	if got := probe.lastRemaining(); got <= 50*time.Second {
+		t.Fatalf("remaining = %s, want client deadline of about 1m", got)
+	}
+}
+
+func TestNoDefaultStreamDeadline(t *testing.T) {
+	client, probe := startInterceptedServer(t, InterceptorConfig{
+		ServiceToken:   testServiceToken,
+		DefaultTimeout: time.Second,
+	})
+
+	if err := watchOnce(withToken(ServiceTokenMetadataKey, testServiceToken), client, ""); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if got := probe.lastRemaining(); got != -1 {
+		t.Fatalf("stream remaining = %s, want no deadline", got)
+	}
+}
@@ -2,19 +2,35 @@ package grpcserver
 
 import (
 	"context"
+	"encoding/json"
 	"net"
+	"strconv"
 
-	"github.com/rainbow96bear/planet_user_server/config"
 	"github.com/rainbow96bear/planet_user_server/dto"
 	"github.com/rainbow96bear/planet_user_server/internal/bootstrap"
 	grpcclient "github.com/rainbow96bear/planet_user_server/internal/grpc/client"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/service"
 	pb "github.com/rainbow96bear/planet_utils/pb"
 	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
+// CreateUser 요청 / 응답 metadata 키.
+// 고정된 planet_utils/pb 의 CreateUserRequest / CreateUserResponse 에는 아래 필드가 없어 metadata 로 주고받습니다.
+const (
+	// AutoSuffixNicknameMetadataKey: "true" 이면 닉네임이 이미 사용 중일 때 숫자 suffix 를 붙여 생성합니다.
+	AutoSuffixNicknameMetadataKey = "x-nickname-auto-suffix"
+	// ProfileHeaderKey: 생성된(재시도면 기존) 프로필을 dto.UserProfile JSON 으로 담는 응답 헤더
+	ProfileHeaderKey = "x-user-profile-bin"
+)
+
+// UserGrpcServer: planet_utils/pb 의 UserService 구현
 type UserGrpcServer struct {
 	pb.UnimplementedUserServiceServer
 	Clients        *grpcclient.GrpcClients
@@ -31,56 +47,133 @@ func NewUserGrpcServer(
 	}
 }
 
-func RunGrpcServer(db *gorm.DB, deps *bootstrap.Dependencies) error {
-	// 🔥 1) 모든 gRPC 클라이언트 생성
-	clients, err := grpcclient.NewGrpcClients()
-	if err != nil {
-		return err
-	}
+// GrpcServer: User gRPC 서버의 생명주기(Serve / Shutdown)를 관리합니다.
+type GrpcServer struct {
+	server   *grpc.Server
+	listener net.Listener
+	health   *HealthChecker
+}
+
+// NewGrpcServer: 포트를 Listen 하고 서비스를 등록합니다. Listen 실패는 여기서 바로 반환됩니다.
+func NewGrpcServer(db *gorm.DB, deps *bootstrap.Dependencies) (*GrpcServer, error) {
+	cfg := deps.Config
+
+	// 🔥 1) gRPC 클라이언트 (InitDependencies 에서 생성된 것을 공유)
+	clients := deps.GrpcClients
 
 	// 🔥 2) gRPC 서버 Listen 시작
-	listener, err := net.Listen("tcp", ":"+config.USER_GRPC_PORT)
+	listener, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 🔥 3) gRPC 서버 생성
-	grpcServer := grpc.NewServer()
+	// 🔥 3) gRPC 서버 생성 (인증 / 로깅 / panic 복구 / 기본 deadline 인터셉터)
+	grpcServer := grpc.NewServer(ServerOptions(InterceptorConfig{
+		ServiceToken:         cfg.GRPC.ServiceToken,
+		DefaultTimeout:       cfg.GRPC.DefaultTimeout,
+		DefaultStreamTimeout: cfg.GRPC.StreamTimeout,
+		// health 프로브 / grpcurl 은 서비스 토큰 없이 허용
+		UnauthenticatedPrefix: []string{
+			"/grpc.health.v1.Health/",
+			"/grpc.reflection.",
+		},
+	})...)
 
 	// 🔥 4) UserGrpcServer 등록
 	userServer := NewUserGrpcServer(clients, deps.Services.Profile)
 	pb.RegisterUserServiceServer(grpcServer, userServer)
 
-	logger.Debugf("User gRPC Server running on :%s\n", config.USER_GRPC_PORT)
+	// 🔥 5) health / reflection 등록
+	healthChecker := NewHealthChecker(db)
+	healthChecker.Register(grpcServer)
 
-	// 🔥 5) 서버 시작
-	return grpcServer.Serve(listener)
+	if cfg.GRPC.ReflectionEnabled {
+		reflection.Register(grpcServer)
+		logger.Infof("gRPC server reflection enabled")
+	}
+
+	return &GrpcServer{
+		server:   grpcServer,
+		listener: listener,
+		health:   healthChecker,
+	}, nil
+}
+
+// Serve: GracefulStop / Stop 이 호출될 때까지 블록됩니다. ctx 는 health 체크 주기를 제어합니다.
+func (s *GrpcServer) Serve(ctx context.Context) error {
+	go s.health.Run(ctx)
+
+	logger.Infof("User gRPC Server running on %s", s.listener.Addr())
+	return s.server.Serve(s.listener)
+}
+
+// Shutdown: health 를 NOT_SERVING 으로 바꾸고 진행 중인 RPC 를 기다립니다.
+// ctx 가 먼저 만료되면 남은 연결을 강제로 종료합니다.
+func (s *GrpcServer) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
 }
 
 func (s *UserGrpcServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
-	logger.Debugf("Received CreateUser request: userId=%s, nickname=%s", req.UserId, req.Nickname)
+	logctx.Debugf(ctx, "Received CreateUser request: userId=%s, nickname=%s", req.UserId, req.Nickname)
 
-	// 2. Profile 구조체 생성
+	// 1. Profile 구조체 생성
 	profile, err := dto.FromGrpcCreateUserRequest(req)
 	if err != nil {
-		return &pb.CreateUserResponse{
-			Success: false,
-			Message: "invalid userId",
-		}, nil
+		return nil, status.Error(codes.InvalidArgument, "invalid userId")
+	}
+	profile.AutoSuffixNickname = autoSuffixRequested(ctx)
+
+	// 2. DB에 저장 (같은 UserId 재요청이면 기존 프로필 반환)
+	created, err := s.ProfileService.CreateProfile(ctx, profile)
+	if err != nil {
+		return nil, toStatusError(ctx, "CreateUser", err)
+	}
+
+	// 3. 프로필을 응답 헤더로 전달 (재시도한 호출자도 기존 프로필을 받음)
+	user, err := s.ProfileService.GetUserProfileInfo(ctx, created.UserID)
+	if err != nil {
+		return nil, toStatusError(ctx, "CreateUser", err)
 	}
-	// 3. DB에 저장
-	_, err = s.ProfileService.CreateProfile(ctx, profile)
+	body, err := json.Marshal(user)
 	if err != nil {
-		logger.Errorf("Failed to create profile: %v", err)
-		return &pb.CreateUserResponse{
-			Success: false,
-			Message: "failed to create profile",
-		}, nil
+		return nil, toStatusError(ctx, "CreateUser", err)
 	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ProfileHeaderKey, string(body)))
 
 	// 4. 성공 응답
+	message := "profile created successfully"
+	if created.AlreadyExisted {
+		message = "profile already exists"
+	}
 	return &pb.CreateUserResponse{
 		Success: true,
-		Message: "profile created successfully",
+		Message: message,
 	}, nil
 }
+
+func autoSuffixRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(AutoSuffixNicknameMetadataKey)
+	if len(values) == 0 {
+		return false
+	}
+	enabled, _ := strconv.ParseBool(values[0])
+	return enabled
+}
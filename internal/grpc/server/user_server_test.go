@@ -0,0 +1,148 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+	pb "github.com/rainbow96bear/planet_utils/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// headerStream: 핸들러가 grpc.SetHeader 로 보낸 응답 헤더를 기록합니다.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "/planet.user.UserService/CreateUser" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
+
+func newTestUserServer() *UserGrpcServer {
+	store := memory.NewStore()
+	profiles := service.NewProfileService(nil, memory.NewProfileRepository(store), memory.NewCalendarEventsRepository(store),
+		memory.NewCalendarEventTemplatesRepository(store), memory.NewFollowsRepository(store),
+		outbox.NewWriter(memory.NewOutboxEventsRepository(store)), nil)
+	return NewUserGrpcServer(nil, profiles)
+}
+
+// createUser: CreateUser 를 호출하고 응답 헤더의 프로필을 함께 반환합니다.
+func createUser(t *testing.T, s *UserGrpcServer, md metadata.MD, userID uuid.UUID, nickname string) (*pb.CreateUserResponse, *dto.UserProfile, error) {
+	t.Helper()
+
+	stream := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	if md != nil {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	resp, err := s.CreateUser(ctx, &pb.CreateUserRequest{UserId: userID.String(), Nickname: nickname})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := stream.header.Get(ProfileHeaderKey)
+	if len(values) != 1 {
+		t.Fatalf("%s header = %v, want one profile", ProfileHeaderKey, values)
+	}
+	var profile dto.UserProfile
+	if err := json.Unmarshal([]byte(values[0]), &profile); err != nil {
+		t.Fatalf("decode profile header: %v", err)
+	}
+	return resp, &profile, nil
+}
+
+func TestCreateUserRetryReturnsExistingProfile(t *testing.T) {
+	s := newTestUserServer()
+	userID := uuid.New()
+
+	resp, profile, err := createUser(t, s, nil, userID, "alice")
+	if err != nil || !resp.Success || profile.Nickname != "alice" {
+		t.Fatalf("first CreateUser = %+v, %+v, %v", resp, profile, err)
+	}
+
+	resp, profile, err = createUser(t, s, nil, userID, "alice_retry")
+	if err != nil {
+		t.Fatalf("retry CreateUser: %v", err)
+	}
+	if !resp.Success || resp.Message != "profile already exists" {
+		t.Fatalf("retry response = %+v", resp)
+	}
+	if profile.UserID != userID || profile.Nickname != "alice" || profile.Version != 1 {
+		t.Fatalf("retry profile = %+v, want existing alice", profile)
+	}
+}
+
+func TestCreateUserNicknameTakenSuggestions(t *testing.T) {
+	s := newTestUserServer()
+	if _, _, err := createUser(t, s, nil, uuid.New(), "alice"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	_, _, err := createUser(t, s, nil, uuid.New(), "alice")
+	st := status.Convert(err)
+	if st.Code() != codes.AlreadyExists {
+		t.Fatalf("code = %s, want AlreadyExists (%v)", st.Code(), err)
+	}
+
+	var info *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = d
+		}
+	}
+	if info == nil || info.Reason != "NICKNAME_TAKEN" || info.Domain != "planet.user" {
+		t.Fatalf("ErrorInfo = %v, want NICKNAME_TAKEN", info)
+	}
+	suggestions := strings.Split(info.Metadata["suggestions"], ",")
+	if len(suggestions) != 3 {
+		t.Fatalf("suggestions = %v, want 3", suggestions)
+	}
+	for _, nickname := range suggestions {
+		if !strings.HasPrefix(nickname, "alice") || nickname == "alice" {
+			t.Errorf("suggestion %q is not alice<N>", nickname)
+		}
+	}
+}
+
+func TestCreateUserAutoSuffixMetadata(t *testing.T) {
+	s := newTestUserServer()
+	if _, _, err := createUser(t, s, nil, uuid.New(), "alice"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	md := metadata.Pairs(AutoSuffixNicknameMetadataKey, "true")
+	resp, profile, err := createUser(t, s, md, uuid.New(), "alice")
+	if err != nil || !resp.Success {
+		t.Fatalf("CreateUser = %+v, %v", resp, err)
+	}
+	if profile.Nickname == "alice" || !strings.HasPrefix(profile.Nickname, "alice") {
+		t.Fatalf("nickname = %q, want alice<N>", profile.Nickname)
+	}
+}
+
+func TestCreateUserInvalidUserID(t *testing.T) {
+	s := newTestUserServer()
+
+	_, err := s.CreateUser(context.Background(), &pb.CreateUserRequest{UserId: "not-a-uuid", Nickname: "alice"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %s, want InvalidArgument", status.Code(err))
+	}
+}
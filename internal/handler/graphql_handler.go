@@ -1,26 +1,152 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
+	"github.com/rainbow96bear/planet_user_server/config"
 	"github.com/rainbow96bear/planet_user_server/graph"
+	"github.com/rainbow96bear/planet_user_server/internal/dataloader"
+	"github.com/rainbow96bear/planet_user_server/internal/gqllimit"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"github.com/rainbow96bear/planet_user_server/internal/persistedquery"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/ratelimit"
 	"github.com/rainbow96bear/planet_user_server/internal/resolver"
+	"github.com/rainbow96bear/planet_user_server/internal/tracing"
 	"github.com/rainbow96bear/planet_user_server/middleware"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 type GraphqlHandler struct {
-	server *handler.Server
+	server  *handler.Server
+	auth    gin.HandlerFunc // access token 검증
+	loaders gin.HandlerFunc // 요청 단위 DataLoader 설치
 }
 
-func NewGraphqlHandler(r *resolver.Resolver) *GraphqlHandler {
+// NewGraphqlHandler: allowlist 가 있으면 등록된 operation 만 허용하고 APQ 는 사용하지 않습니다.
+func NewGraphqlHandler(
+	cfg *config.Config,
+	r *resolver.Resolver,
+	loaders *dataloader.Factory,
+	apqCache graphql.Cache[string],
+	allowlist *persistedquery.Allowlist,
+) (*GraphqlHandler, error) {
 	exec := graph.NewExecutableSchema(graph.Config{
 		Resolvers: r,
 	})
 
+	limits := cfg.GraphQL
+
+	fieldCosts, err := gqllimit.ParseFieldCosts(limits.FieldCosts)
+	if err != nil {
+		return nil, fmt.Errorf("graphql.field_costs: %w", err)
+	}
+
+	// NewDefaultServer 구성에 websocket(Subscription) 인증을 추가
+	server := handler.New(gqllimit.WithFieldCosts(exec, fieldCosts))
+	server.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              websocketInit(cfg.Auth.JWTSecretKey),
+	})
+	server.AddTransport(transport.Options{})
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.AddTransport(transport.MultipartForm{})
+
+	server.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	server.Use(extension.Introspection{})
+
+	// 깊이 / 복잡도 / 사용자별 요청 수 제한 (실행 전에 거절)
+	server.Use(gqllimit.DepthLimit{Max: limits.MaxDepth})
+	server.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	server.Use(gqllimit.NewRateLimit(gqllimit.RateLimitConfig{
+		Operation:       ratelimit.NewKeyedLimiter(limits.RateLimitRPS, limits.RateLimitBurst, rateLimitIdleTTL),
+		Mutation:        ratelimit.NewKeyedLimiter(limits.MutationRateLimitRPS, limits.MutationRateLimitBurst, rateLimitIdleTTL),
+		Sensitive:       ratelimit.NewKeyedLimiter(limits.SensitiveRateLimitRPS, limits.SensitiveRateLimitBurst, rateLimitIdleTTL),
+		SensitiveFields: splitFields(limits.SensitiveFields),
+	}))
+
+	server.Use(logctx.GraphQLExtension{})
+	server.Use(metrics.GraphQLExtension{})
+	server.Use(tracing.GraphQLExtension{FieldDepth: cfg.Tracing.GraphQLFieldDepth})
+	if allowlist != nil {
+		server.Use(allowlist)
+	} else {
+		// APQ: 클라이언트는 sha256 hash 만 보내고, 캐시에 없을 때만 전체 쿼리를 보냅니다.
+		server.Use(extension.AutomaticPersistedQuery{
+			Cache: apqCache,
+		})
+	}
+
+	server.SetErrorPresenter(errorPresenter)
+
 	return &GraphqlHandler{
-		server: handler.NewDefaultServer(exec),
+		server:  server,
+		auth:    middleware.AuthMiddleware(cfg.Auth.JWTSecretKey),
+		loaders: loaders.Middleware(),
+	}, nil
+}
+
+// rateLimitIdleTTL: 이 시간 동안 요청이 없는 사용자의 bucket 은 정리됩니다.
+const rateLimitIdleTTL = 10 * time.Minute
+
+func splitFields(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// websocketInit: connection_init payload 의 Authorization 으로 인증합니다.
+// 브라우저 websocket 은 헤더를 보낼 수 없으므로 payload 로 토큰을 전달받습니다.
+// 토큰은 secret 으로 서명 / 만료를 검증하며, 실패하면 연결을 거절합니다.
+func websocketInit(secret string) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		// payload 에 토큰이 없으면 업그레이드 요청의 Authorization 헤더 (AuthMiddleware 가 검증)
+		if token := payload.Authorization(); token != "" {
+			ctx = middleware.WithAccessToken(ctx, token, secret)
+		}
+		if _, err := middleware.ExtractAccessToken(ctx); err != nil {
+			return ctx, nil, middleware.ErrUnauthorized
+		}
+		return ctx, &payload, nil
+	}
+}
+
+// errorPresenter: planet_err.CodeError 는 code / data 를 GraphQL extensions 로 노출합니다.
+// (예: CONFLICT 에러의 extensions.current 에 서버 최신본)
+func errorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	var codeErr *planet_err.CodeError
+	if !errors.As(err, &codeErr) {
+		return graphql.DefaultErrorPresenter(ctx, err)
+	}
+
+	gqlErr := gqlerror.WrapPath(graphql.GetPath(ctx), err)
+	gqlErr.Message = codeErr.Message
+	gqlErr.Extensions = map[string]interface{}{
+		"code": codeErr.Code,
+	}
+	for k, v := range codeErr.Data {
+		gqlErr.Extensions[k] = v
 	}
+	return gqlErr
 }
 
 func (h *GraphqlHandler) Graphql() gin.HandlerFunc {
@@ -34,6 +160,7 @@ func (h *GraphqlHandler) Playground() gin.HandlerFunc {
 }
 
 func (h *GraphqlHandler) RegisterRoutes(r *gin.Engine) {
-	r.POST("/graphql", middleware.AuthMiddleware(), h.Graphql())
+	r.POST("/graphql", h.auth, middleware.IdempotencyKeyMiddleware(), h.loaders, h.Graphql())
+	r.GET("/graphql", h.auth, h.loaders, h.Graphql()) // websocket (Subscription)
 	r.GET("/playground", h.Playground())
 }
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+)
+
+func TestWebsocketInitVerifiesToken(t *testing.T) {
+	const secret = "ws-secret"
+
+	sign := func(key string, exp time.Time) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Subject:   "00000000-0000-0000-0000-00000000000a",
+			ExpiresAt: jwt.NewNumericDate(exp),
+		}).SignedString([]byte(key))
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		token  string
+		wantOK bool
+	}{
+		{name: "valid payload token", ctx: context.Background(), token: sign(secret, time.Now().Add(time.Hour)), wantOK: true},
+		{name: "forged payload token", ctx: context.Background(), token: sign("forged", time.Now().Add(time.Hour))},
+		{name: "expired payload token", ctx: context.Background(), token: sign(secret, time.Now().Add(-time.Minute))},
+		{name: "no token", ctx: context.Background()},
+		{
+			// 업그레이드 요청 헤더로 검증된 토큰이 있으면 payload 없이 허용
+			name:   "verified header token",
+			ctx:    middleware.WithAccessToken(context.Background(), sign(secret, time.Now().Add(time.Hour)), secret),
+			wantOK: true,
+		},
+		{
+			// payload 의 위조 토큰이 헤더의 정상 토큰을 덮어써도 거절
+			name:  "forged payload over verified header",
+			ctx:   middleware.WithAccessToken(context.Background(), sign(secret, time.Now().Add(time.Hour)), secret),
+			token: sign("forged", time.Now().Add(time.Hour)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := transport.InitPayload{}
+			if tt.token != "" {
+				payload["Authorization"] = "Bearer " + tt.token
+			}
+
+			_, ack, err := websocketInit(secret)(tt.ctx, payload)
+			if tt.wantOK {
+				if err != nil || ack == nil {
+					t.Fatalf("ack = %v, err = %v, want accepted", ack, err)
+				}
+				return
+			}
+			if !errors.Is(err, middleware.ErrUnauthorized) {
+				t.Fatalf("err = %v, want ErrUnauthorized", err)
+			}
+		})
+	}
+}
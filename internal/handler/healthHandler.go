@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"gorm.io/gorm"
+)
+
+const readinessTimeout = 2 * time.Second
+
+var errDBNotConfigured = errors.New("database not configured")
+
+// BuildInfo: cmd/server/main.go 의 빌드 변수
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	Mode      string `json:"mode"`
+}
+
+// HealthHandler: 오케스트레이터 프로브용 /healthz, /readyz 와 빌드 정보 /version
+type HealthHandler struct {
+	db       *gorm.DB
+	authConn *grpc.ClientConn
+	build    BuildInfo
+}
+
+func NewHealthHandler(db *gorm.DB, authConn *grpc.ClientConn, build BuildInfo) *HealthHandler {
+	return &HealthHandler{
+		db:       db,
+		authConn: authConn,
+		build:    build,
+	}
+}
+
+func (h *HealthHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/healthz", h.Healthz)
+	r.GET("/readyz", h.Readyz)
+	r.GET("/version", h.Version)
+}
+
+// Healthz: 프로세스가 요청을 처리할 수 있으면 항상 200 (liveness)
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz: DB 풀과 auth gRPC 연결 상태를 확인합니다. 하나라도 실패하면 503 (readiness)
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	ready := true
+	checks := gin.H{}
+
+	if err := h.pingDB(ctx); err != nil {
+		logger.Warnf("[Readyz] database not ready: %v", err)
+		checks["database"] = "unavailable"
+		ready = false
+	} else {
+		checks["database"] = "ok"
+	}
+
+	authState, authOK := h.authConnState()
+	checks["authGrpc"] = authState
+	if !authOK {
+		logger.Warnf("[Readyz] auth gRPC not ready: %s", authState)
+		ready = false
+	}
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "checks": checks})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "checks": checks})
+}
+
+func (h *HealthHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, h.build)
+}
+
+// pingDB: db 가 nil 이면 (in-memory repository 로 구성한 테스트 서버) 준비되지 않은 것으로 봅니다.
+func (h *HealthHandler) pingDB(ctx context.Context) error {
+	if h.db == nil {
+		return errDBNotConfigured
+	}
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// authConnState: grpc.Dial 은 지연 연결이므로 IDLE 이면 연결을 시작시키고 준비된 것으로 봅니다.
+// TRANSIENT_FAILURE / SHUTDOWN 만 실패로 판단합니다.
+func (h *HealthHandler) authConnState() (string, bool) {
+	if h.authConn == nil {
+		return "not configured", false
+	}
+
+	state := h.authConn.GetState()
+	switch state {
+	case connectivity.Idle:
+		h.authConn.Connect()
+		return state.String(), true
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return state.String(), false
+	default:
+		return state.String(), true
+	}
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+)
+
+// MetricsHandler: Prometheus scrape 용 /metrics
+type MetricsHandler struct{}
+
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{}
+}
+
+func (h *MetricsHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/metrics", metrics.Handler())
+}
@@ -0,0 +1,37 @@
+package logctx
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// GraphQLExtension: 요청 로그에 GraphQL operation ("query myProfile") 을 기록합니다.
+type GraphQLExtension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = GraphQLExtension{}
+
+func (GraphQLExtension) ExtensionName() string {
+	return "RequestLogContext"
+}
+
+func (GraphQLExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (GraphQLExtension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	name := oc.OperationName
+	if name == "" {
+		name = "anonymous"
+	}
+	if oc.Operation != nil {
+		name = string(oc.Operation.Operation) + " " + name
+	}
+	SetOperation(ctx, name)
+
+	return next(ctx)
+}
@@ -0,0 +1,133 @@
+package logctx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// RequestIDHeader: HTTP 요청 / 응답의 correlation ID 헤더
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMetadataKey: gRPC metadata 로 전파되는 correlation ID 키 (소문자)
+const RequestIDMetadataKey = "x-request-id"
+
+// fields: 요청 하나에 묶이는 로그 필드.
+// user ID / operation 은 요청 처리 중간에 알게 되므로 포인터로 공유하고 나중에 채웁니다.
+type fields struct {
+	mu        sync.RWMutex
+	requestID string
+	userID    string
+	operation string
+}
+
+type ctxKey struct{}
+
+var (
+	level = new(slog.LevelVar)
+	base  = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}))
+)
+
+// SetLevel: JSON 로그 레벨 (debug | info | warn | error)
+func SetLevel(name string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+	level.Set(l)
+	return nil
+}
+
+// NewContext: 요청 시작 시 한 번 호출합니다. 이후 SetUserID / SetOperation 으로 필드를 채웁니다.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &fields{requestID: requestID})
+}
+
+func fromContext(ctx context.Context) *fields {
+	if ctx == nil {
+		return nil
+	}
+	f, _ := ctx.Value(ctxKey{}).(*fields)
+	return f
+}
+
+// RequestID: 요청 범위가 아니면 빈 문자열을 반환합니다.
+func RequestID(ctx context.Context) string {
+	f := fromContext(ctx)
+	if f == nil {
+		return ""
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.requestID
+}
+
+func SetUserID(ctx context.Context, userID string) {
+	if f := fromContext(ctx); f != nil {
+		f.mu.Lock()
+		f.userID = userID
+		f.mu.Unlock()
+	}
+}
+
+// SetOperation: GraphQL operation 이름 또는 gRPC 메서드
+func SetOperation(ctx context.Context, operation string) {
+	if f := fromContext(ctx); f != nil {
+		f.mu.Lock()
+		f.operation = operation
+		f.mu.Unlock()
+	}
+}
+
+// Logger: 요청 필드가 붙은 slog.Logger 를 반환합니다.
+func Logger(ctx context.Context) *slog.Logger {
+	f := fromContext(ctx)
+	if f == nil {
+		return base
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	attrs := make([]any, 0, 6)
+	attrs = append(attrs, "request_id", f.requestID)
+	if f.userID != "" {
+		attrs = append(attrs, "user_id", f.userID)
+	}
+	if f.operation != "" {
+		attrs = append(attrs, "operation", f.operation)
+	}
+	return base.With(attrs...)
+}
+
+// -------------------------
+// printf 스타일 (기존 logger.Xxxf 와 같은 사용법, 메시지도 redactAttr 를 거칩니다)
+// -------------------------
+
+func Debugf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, slog.LevelDebug, format, args...)
+}
+
+func Infof(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, slog.LevelInfo, format, args...)
+}
+
+func Warnf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, slog.LevelWarn, format, args...)
+}
+
+func Errorf(ctx context.Context, format string, args ...interface{}) {
+	logf(ctx, slog.LevelError, format, args...)
+}
+
+func logf(ctx context.Context, l slog.Level, format string, args ...interface{}) {
+	if !base.Enabled(ctx, l) {
+		return
+	}
+	Logger(ctx).Log(ctx, l, fmt.Sprintf(format, args...))
+}
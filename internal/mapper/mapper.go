@@ -3,6 +3,7 @@ package mapper
 import (
 	"github.com/rainbow96bear/planet_user_server/graph/model"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
 )
 
 func ToTodoGraphQL(todo *models.Todo) *models.Todo {
@@ -14,6 +15,7 @@ func ToTodoGraphQL(todo *models.Todo) *models.Todo {
 		ID:        todo.ID,
 		Content:   todo.Content,
 		IsDone:    todo.IsDone,
+		Version:   todo.Version,
 		CreatedAt: todo.CreatedAt,
 		UpdatedAt: todo.UpdatedAt,
 	}
@@ -24,9 +26,13 @@ func ToCalendarGraphQL(event *models.CalendarEvent) *model.Calendar {
 		return nil
 	}
 
-	todos := make([]*models.Todo, 0, len(event.Todos))
-	for i := range event.Todos {
-		todos = append(todos, ToTodoGraphQL(&event.Todos[i]))
+	// Todo 없이 조회된 일정은 nil 로 두어 Calendar.todos resolver 가 DataLoader 로 조회하게 합니다.
+	var todos []*models.Todo
+	if event.Todos != nil {
+		todos = make([]*models.Todo, 0, len(event.Todos))
+		for i := range event.Todos {
+			todos = append(todos, ToTodoGraphQL(&event.Todos[i]))
+		}
 	}
 
 	return &model.Calendar{
@@ -38,6 +44,7 @@ func ToCalendarGraphQL(event *models.CalendarEvent) *model.Calendar {
 		EndAt:       event.EndAt,
 		Visibility:  model.CalendarVisibility(event.Visibility),
 		Todos:       todos,
+		Version:     event.Version,
 		CreatedAt:   event.CreatedAt,
 		UpdatedAt:   event.UpdatedAt,
 	}
@@ -50,3 +57,60 @@ func ToCalendarGraphQLList(events []*models.CalendarEvent) []*model.Calendar {
 	}
 	return result
 }
+
+func ToEventTemplateGraphQL(template *models.CalendarEventTemplate) *model.EventTemplate {
+	if template == nil {
+		return nil
+	}
+
+	todos := make([]*model.EventTemplateTodo, 0, len(template.Todos))
+	for _, t := range template.Todos {
+		todos = append(todos, &model.EventTemplateTodo{
+			Content: t.Content,
+		})
+	}
+
+	return &model.EventTemplate{
+		ID:              template.ID.String(),
+		Name:            template.Name,
+		Title:           template.Title,
+		Emoji:           &template.Emoji,
+		Description:     &template.Description,
+		Visibility:      model.CalendarVisibility(template.Visibility),
+		DurationMinutes: template.DurationMinutes,
+		Todos:           todos,
+		CreatedAt:       template.CreatedAt,
+		UpdatedAt:       template.UpdatedAt,
+	}
+}
+
+func ToEventTemplateGraphQLList(templates []*models.CalendarEventTemplate) []*model.EventTemplate {
+	result := make([]*model.EventTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ToEventTemplateGraphQL(t))
+	}
+	return result
+}
+
+func ToCalendarEventChangeGraphQL(change pubsub.CalendarEventChange) *model.CalendarEventChange {
+	result := &model.CalendarEventChange{
+		Action:  model.ChangeAction(change.Action),
+		EventID: change.EventID.String(),
+	}
+	if change.Action != pubsub.ActionDeleted {
+		result.Event = ToCalendarGraphQL(change.Event)
+	}
+	return result
+}
+
+func ToTodoChangeGraphQL(change pubsub.TodoChange) *model.TodoChange {
+	result := &model.TodoChange{
+		Action:  model.ChangeAction(change.Action),
+		EventID: change.EventID.String(),
+		TodoID:  change.TodoID.String(),
+	}
+	if change.Action != pubsub.ActionDeleted {
+		result.Todo = change.Todo
+	}
+	return result
+}
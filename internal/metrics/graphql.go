@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// GraphQLExtension: gqlgen 서버 확장. operation 별 latency 와 에러 응답 수를 기록합니다.
+type GraphQLExtension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = GraphQLExtension{}
+
+func (GraphQLExtension) ExtensionName() string {
+	return "PrometheusMetrics"
+}
+
+func (GraphQLExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (GraphQLExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	start := time.Now()
+	resp := next(ctx)
+
+	oc := graphql.GetOperationContext(ctx)
+	operation := oc.OperationName
+	if operation == "" {
+		operation = "anonymous"
+	}
+	opType := "unknown"
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+	}
+
+	GraphQLOperationDuration.WithLabelValues(operation, opType).Observe(time.Since(start).Seconds())
+	if resp != nil && len(resp.Errors) > 0 {
+		GraphQLOperationErrors.WithLabelValues(operation, opType).Inc()
+	}
+
+	return resp
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "planet_user"
+
+// Registry: /metrics 로 노출되는 레지스트리 (Go 런타임 / 프로세스 지표 포함)
+var Registry = prometheus.NewRegistry()
+
+var (
+	// ---------- GraphQL ----------
+	GraphQLOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "operation_duration_seconds",
+		Help:      "GraphQL operation latency by operation name and type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "type"})
+
+	GraphQLOperationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "operation_errors_total",
+		Help:      "GraphQL responses containing errors by operation name and type.",
+	}, []string{"operation", "type"})
+
+	GraphQLRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "rate_limited_total",
+		Help:      "GraphQL operations rejected by the rate limiter by bucket (operation, mutation, sensitive).",
+	}, []string{"bucket"})
+
+	// ---------- gRPC ----------
+	GrpcServerHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "Completed gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	GrpcServerHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "gRPC call latency by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// ---------- HTTP (Gin) ----------
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// ---------- 도메인 ----------
+	CalendarEventsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "calendar_events_created_total",
+		Help:      "Calendar events created (including duplicates and template instances).",
+	})
+
+	TodosCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "todos_completed_total",
+		Help:      "Todos marked as done.",
+	})
+
+	// ---------- 캘린더 월별 캐시 ----------
+	CalendarMonthCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "calendar_month_cache",
+		Name:      "requests_total",
+		Help:      "Calendar month cache lookups by backend and result (hit, miss).",
+	}, []string{"backend", "result"})
+
+	CalendarMonthCacheInvalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "calendar_month_cache",
+		Name:      "invalidations_total",
+		Help:      "Calendar month cache keys invalidated by event changes, by backend.",
+	}, []string{"backend"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		GraphQLOperationDuration,
+		GraphQLOperationErrors,
+		GraphQLRateLimited,
+		GrpcServerHandled,
+		GrpcServerHandlingSeconds,
+		HTTPRequests,
+		HTTPRequestDuration,
+		CalendarEventsCreated,
+		TodosCompleted,
+		CalendarMonthCacheRequests,
+		CalendarMonthCacheInvalidations,
+	)
+}
+
+// RegisterDBStats: InitDatabase 의 커넥션 풀 상태(open / in-use / idle / wait)를 노출합니다.
+func RegisterDBStats(db *sql.DB, dbName string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
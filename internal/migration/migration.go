@@ -0,0 +1,333 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// sql/<version>_<name>.up.sql / .down.sql 쌍으로 관리합니다. version 은 단조 증가해야 합니다.
+//
+//go:embed sql/*.sql
+var embedded embed.FS
+
+const (
+	tableName = "schema_migrations"
+
+	// advisoryLockKey: 여러 인스턴스가 동시에 기동해도 migration 은 한 번에 하나씩만 실행됩니다.
+	advisoryLockKey int64 = 0x706c616e65747573 // "planetus"
+)
+
+// ErrBelowBaseline: baseline(첫 migration) 은 기존 배포 환경의 테이블을 그대로 받아들인 것이므로
+// 되돌리면 운영 데이터가 모두 삭제됩니다. WithForce 없이는 거절합니다.
+var ErrBelowBaseline = errors.New("refusing to revert the baseline migration without force")
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status: migrate status 출력용. Known 이 false 면 DB 에만 기록된 (이 바이너리보다 새로운) migration 입니다.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Known     bool
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration // version 오름차순
+	force      bool
+}
+
+type Option func(*Migrator)
+
+// WithForce: baseline migration 까지 되돌릴 수 있게 합니다. (로컬 / 테스트 DB 초기화용)
+func WithForce() Option {
+	return func(m *Migrator) { m.force = true }
+}
+
+func NewMigrator(db *gorm.DB, opts ...Option) (*Migrator, error) {
+	migrations, err := Load(embedded)
+	if err != nil {
+		return nil, err
+	}
+	m := &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+// Load: fsys 의 sql 디렉터리에서 migration 목록을 읽습니다.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s, %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s requires both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up: 적용되지 않은 migration 을 모두 적용하고 적용한 개수를 반환합니다.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.repeat(ctx, -1, func(ctx context.Context) (bool, error) {
+		return m.upOne(ctx, math.MaxInt64)
+	})
+}
+
+// Down: 가장 최근에 적용된 migration 부터 steps 개를 되돌립니다.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	return m.repeat(ctx, steps, func(ctx context.Context) (bool, error) {
+		return m.downOne(ctx, 0)
+	})
+}
+
+// To: version 까지 적용하거나 되돌립니다. 0 이면 모든 migration 을 되돌립니다. (WithForce 필요)
+func (m *Migrator) To(ctx context.Context, version int64) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("unknown migration version: %d", version)
+	}
+	if version == 0 && !m.force {
+		return 0, ErrBelowBaseline
+	}
+
+	applied, err := m.repeat(ctx, -1, func(ctx context.Context) (bool, error) {
+		return m.upOne(ctx, version)
+	})
+	if err != nil {
+		return applied, err
+	}
+
+	reverted, err := m.repeat(ctx, -1, func(ctx context.Context) (bool, error) {
+		return m.downOne(ctx, version)
+	})
+	return applied + reverted, err
+}
+
+// Status: 바이너리에 포함된 migration 과 DB 에 기록된 migration 의 적용 상태
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var rows []struct {
+		Version   int64
+		Name      string
+		AppliedAt time.Time
+	}
+	err := m.locked(ctx, func(tx *gorm.DB, _ map[int64]bool) error {
+		return tx.Table(tableName).Order("version").Find(&rows).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", tableName, err)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+		if m.find(row.Version) == nil {
+			appliedAt := row.AppliedAt
+			statuses = append(statuses, Status{Version: row.Version, Name: row.Name, AppliedAt: &appliedAt})
+		}
+	}
+	for _, mig := range m.migrations {
+		status := Status{Version: mig.Version, Name: mig.Name, Known: true}
+		if appliedAt, ok := applied[mig.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// repeat: step 이 false 를 반환하거나 limit 회 (음수면 무제한) 실행될 때까지 반복합니다.
+func (m *Migrator) repeat(ctx context.Context, limit int, step func(context.Context) (bool, error)) (int, error) {
+	count := 0
+	for limit < 0 || count < limit {
+		done, err := step(ctx)
+		if err != nil {
+			return count, err
+		}
+		if !done {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+// upOne: target 이하의 미적용 migration 중 가장 오래된 하나를 적용합니다.
+// 락을 잡은 뒤 적용 상태를 다시 읽으므로 다른 인스턴스가 먼저 적용한 migration 은 건너뜁니다.
+func (m *Migrator) upOne(ctx context.Context, target int64) (bool, error) {
+	done := false
+	err := m.locked(ctx, func(tx *gorm.DB, applied map[int64]bool) error {
+		for i := range m.migrations {
+			mig := &m.migrations[i]
+			if mig.Version > target {
+				return nil
+			}
+			if applied[mig.Version] {
+				continue
+			}
+
+			logger.Infof("[Migration] applying %d_%s", mig.Version, mig.Name)
+			if err := tx.Exec(mig.Up).Error; err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", mig.Version, mig.Name, err)
+			}
+			if err := tx.Exec("INSERT INTO "+tableName+" (version, name) VALUES (?, ?)", mig.Version, mig.Name).Error; err != nil {
+				return err
+			}
+			done = true
+			return nil
+		}
+		return nil
+	})
+	return done, err
+}
+
+// downOne: target 보다 큰 적용된 migration 중 가장 최근의 하나를 되돌립니다.
+// baseline 은 WithForce 가 있을 때만 되돌립니다.
+func (m *Migrator) downOne(ctx context.Context, target int64) (bool, error) {
+	done := false
+	err := m.locked(ctx, func(tx *gorm.DB, applied map[int64]bool) error {
+		latest := int64(0)
+		for version := range applied {
+			if version > latest {
+				latest = version
+			}
+		}
+		if latest <= target {
+			return nil
+		}
+
+		mig := m.find(latest)
+		if mig == nil {
+			return fmt.Errorf("migration %d is applied but not included in this binary", latest)
+		}
+		if mig.Version == m.migrations[0].Version && !m.force {
+			return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, ErrBelowBaseline)
+		}
+
+		logger.Infof("[Migration] reverting %d_%s", mig.Version, mig.Name)
+		if err := tx.Exec(mig.Down).Error; err != nil {
+			return fmt.Errorf("migration %d_%s down failed: %w", mig.Version, mig.Name, err)
+		}
+		if err := tx.Exec("DELETE FROM "+tableName+" WHERE version = ?", mig.Version).Error; err != nil {
+			return err
+		}
+		done = true
+		return nil
+	})
+	return done, err
+}
+
+// locked: advisory lock 을 잡은 트랜잭션 안에서 fn 을 실행합니다. (migration 하나 = 트랜잭션 하나)
+func (m *Migrator) locked(ctx context.Context, fn func(tx *gorm.DB, applied map[int64]bool) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if err := ensureTable(tx); err != nil {
+			return err
+		}
+
+		var versions []int64
+		if err := tx.Table(tableName).Pluck("version", &versions).Error; err != nil {
+			return fmt.Errorf("failed to read %s: %w", tableName, err)
+		}
+		applied := make(map[int64]bool, len(versions))
+		for _, v := range versions {
+			applied[v] = true
+		}
+
+		return fn(tx, applied)
+	})
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+func ensureTable(tx *gorm.DB) error {
+	err := tx.Exec(`CREATE TABLE IF NOT EXISTS ` + tableName + ` (
+		version    bigint      PRIMARY KEY,
+		name       text        NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`).Error
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tableName, err)
+	}
+	return nil
+}
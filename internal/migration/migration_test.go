@@ -0,0 +1,22 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rainbow96bear/planet_user_server/internal/migration"
+)
+
+// baseline 아래로 되돌리는 요청은 DB 에 접근하기 전에 거절됩니다.
+func TestToZeroRequiresForce(t *testing.T) {
+	m, err := migration.NewMigrator(nil)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+
+	n, err := m.To(context.Background(), 0)
+	if !errors.Is(err, migration.ErrBelowBaseline) || n != 0 {
+		t.Fatalf("To(0) = %d, %v, want ErrBelowBaseline", n, err)
+	}
+}
@@ -0,0 +1,6 @@
+-- baseline: 기존 배포 환경의 테이블을 받아들인 migration 이므로 되돌리면 운영 데이터가 모두 삭제됩니다.
+-- "migrate -force" 로만 실행됩니다.
+DROP TABLE IF EXISTS todos;
+DROP TABLE IF EXISTS calendar_events;
+DROP TABLE IF EXISTS follows;
+DROP TABLE IF EXISTS profiles;
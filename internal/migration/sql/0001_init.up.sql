@@ -0,0 +1,54 @@
+-- 기존 배포 환경에는 이미 테이블이 존재하므로 IF NOT EXISTS 로 baseline 을 맞춥니다.
+-- gen_random_uuid() 는 PostgreSQL 13+ 기본 제공 함수입니다.
+
+CREATE TABLE IF NOT EXISTS profiles (
+    id              uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         uuid         NOT NULL UNIQUE,
+    nickname        varchar(50)  NOT NULL UNIQUE,
+    bio             text         NOT NULL DEFAULT '',
+    profile_image   text         NOT NULL DEFAULT '',
+    theme           varchar(20)  NOT NULL DEFAULT 'light',
+    follower_count  integer      NOT NULL DEFAULT 0,
+    following_count integer      NOT NULL DEFAULT 0,
+    created_at      timestamptz  NOT NULL DEFAULT now(),
+    updated_at      timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS follows (
+    id            uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    follower_uuid uuid        NOT NULL,
+    followee_uuid uuid        NOT NULL,
+    created_at    timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT uq_follows_follower_followee UNIQUE (follower_uuid, followee_uuid)
+);
+
+-- 팔로워 목록 / 탈퇴 시 삭제 (follower_uuid 쪽은 unique 인덱스가 사용됨)
+CREATE INDEX IF NOT EXISTS idx_follows_followee_uuid ON follows (followee_uuid);
+
+CREATE TABLE IF NOT EXISTS calendar_events (
+    id          uuid        PRIMARY KEY,
+    user_id     uuid        NOT NULL,
+    title       text        NOT NULL DEFAULT '',
+    emoji       text        NOT NULL DEFAULT '',
+    description text        NOT NULL DEFAULT '',
+    start_at    timestamptz NOT NULL,
+    end_at      timestamptz NOT NULL,
+    visibility  varchar(20) NOT NULL DEFAULT 'private',
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    updated_at  timestamptz NOT NULL DEFAULT now()
+);
+
+-- 월별 / 일별 캘린더 조회 (user_id = ? AND start_at < ? AND end_at >= ?)
+CREATE INDEX IF NOT EXISTS idx_calendar_events_user_id_start_at ON calendar_events (user_id, start_at);
+
+CREATE TABLE IF NOT EXISTS todos (
+    id                uuid        PRIMARY KEY,
+    calendar_event_id uuid        NOT NULL REFERENCES calendar_events (id) ON DELETE CASCADE,
+    content           text        NOT NULL DEFAULT '',
+    is_done           boolean     NOT NULL DEFAULT false,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now()
+);
+
+-- 일정별 Todo 조회 (Preload / DataLoader)
+CREATE INDEX IF NOT EXISTS idx_todos_calendar_event_id ON todos (calendar_event_id);
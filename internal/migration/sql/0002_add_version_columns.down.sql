@@ -0,0 +1,3 @@
+ALTER TABLE todos           DROP COLUMN IF EXISTS version;
+ALTER TABLE calendar_events DROP COLUMN IF EXISTS version;
+ALTER TABLE profiles        DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- 낙관적 동시성 제어용 version 컬럼
+ALTER TABLE profiles        ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+ALTER TABLE calendar_events ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+ALTER TABLE todos           ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
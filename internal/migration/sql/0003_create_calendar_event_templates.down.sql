@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS calendar_event_template_todos;
+DROP TABLE IF EXISTS calendar_event_templates;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS calendar_event_templates (
+    id               uuid        PRIMARY KEY,
+    user_id          uuid        NOT NULL,
+    name             varchar(50) NOT NULL,
+    title            text        NOT NULL DEFAULT '',
+    emoji            text        NOT NULL DEFAULT '',
+    description      text        NOT NULL DEFAULT '',
+    visibility       varchar(20) NOT NULL DEFAULT 'private',
+    duration_minutes integer     NOT NULL DEFAULT 60,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+    updated_at       timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_event_templates_user_id ON calendar_event_templates (user_id);
+
+CREATE TABLE IF NOT EXISTS calendar_event_template_todos (
+    id          uuid        PRIMARY KEY,
+    template_id uuid        NOT NULL REFERENCES calendar_event_templates (id) ON DELETE CASCADE,
+    content     text        NOT NULL DEFAULT '',
+    position    integer     NOT NULL DEFAULT 0,
+    created_at  timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_event_template_todos_template_id ON calendar_event_template_todos (template_id);
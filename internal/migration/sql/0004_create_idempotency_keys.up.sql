@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id           uuid         PRIMARY KEY,
+    user_id      uuid         NOT NULL,
+    operation    varchar(100) NOT NULL,
+    key          varchar(255) NOT NULL,
+    request_hash varchar(64)  NOT NULL,
+    status       varchar(20)  NOT NULL,
+    response     jsonb,
+    created_at   timestamptz  NOT NULL DEFAULT now(),
+    expires_at   timestamptz  NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_scope ON idempotency_keys (user_id, operation, key);
+-- 만료 키 정리
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id             uuid         PRIMARY KEY,
+    sequence       bigserial    NOT NULL UNIQUE,
+    aggregate_type varchar(50)  NOT NULL,
+    aggregate_id   uuid         NOT NULL,
+    event_type     varchar(100) NOT NULL,
+    payload        jsonb        NOT NULL,
+    status         varchar(20)  NOT NULL,
+    attempts       integer      NOT NULL DEFAULT 0,
+    last_error     text         NOT NULL DEFAULT '',
+    available_at   timestamptz  NOT NULL,
+    created_at     timestamptz  NOT NULL DEFAULT now(),
+    published_at   timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events (aggregate_type, aggregate_id);
+-- relay 폴링 (status = 'pending' AND available_at <= now())
+CREATE INDEX IF NOT EXISTS idx_outbox_events_status_available ON outbox_events (status, available_at);
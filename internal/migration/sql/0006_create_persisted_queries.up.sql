@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS persisted_queries (
+    hash       varchar(64) PRIMARY KEY, -- hex(sha256(query))
+    query      text        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
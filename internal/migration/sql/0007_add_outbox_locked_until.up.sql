@@ -0,0 +1,2 @@
+-- relay 가 발행 중인 이벤트를 선점한 만료 시각 (발행은 트랜잭션 밖에서 수행)
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS locked_until timestamptz;
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarEventTemplate represents a reusable event + todo bundle
+// DB: calendar_event_templates
+type CalendarEventTemplate struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name            string    `gorm:"size:50;not null"`
+	Title           string
+	Emoji           string
+	Description     string
+	Visibility      string
+	DurationMinutes int32 `gorm:"not null;default:60"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	Todos []CalendarEventTemplateTodo `gorm:"foreignKey:TemplateID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (CalendarEventTemplate) TableName() string {
+	return "calendar_event_templates"
+}
+
+// CalendarEventTemplateTodo: 템플릿에 포함된 Todo 항목 (position 순서 유지)
+// DB: calendar_event_template_todos
+type CalendarEventTemplateTodo struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TemplateID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Content    string
+	Position   int32
+	CreatedAt  time.Time
+}
+
+func (CalendarEventTemplateTodo) TableName() string {
+	return "calendar_event_template_todos"
+}
@@ -17,6 +17,7 @@ type CalendarEvent struct {
 	StartAt     time.Time
 	EndAt       time.Time
 	Visibility  string
+	Version     int32 `gorm:"not null;default:1"` // 낙관적 동시성 제어용
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 
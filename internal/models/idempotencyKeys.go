@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	IdempotencyStatusPending   = "pending"
+	IdempotencyStatusCompleted = "completed"
+)
+
+// IdempotencyKey stores the response of a mutation for replaying client retries
+// DB: idempotency_keys
+type IdempotencyKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_keys_scope"`
+	Operation   string    `gorm:"size:100;not null;uniqueIndex:idx_idempotency_keys_scope"`
+	Key         string    `gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_scope"`
+	RequestHash string    `gorm:"size:64;not null"`
+	Status      string    `gorm:"size:20;not null"`
+	Response    []byte    `gorm:"type:jsonb"`
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
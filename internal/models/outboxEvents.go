@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	OutboxStatusFailed    = "failed" // 최대 재시도 초과
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes
+// DB: outbox_events
+type OutboxEvent struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Sequence      int64      `gorm:"autoIncrement;uniqueIndex;not null"` // 집계(aggregate) 내 발행 순서
+	AggregateType string     `gorm:"size:50;not null;index:idx_outbox_events_aggregate"`
+	AggregateID   uuid.UUID  `gorm:"type:uuid;not null;index:idx_outbox_events_aggregate"`
+	EventType     string     `gorm:"size:100;not null"`
+	Payload       []byte     `gorm:"type:jsonb;not null"`
+	Status        string     `gorm:"size:20;not null;index:idx_outbox_events_status_available"`
+	Attempts      int        `gorm:"not null;default:0"`
+	LastError     string     `gorm:"type:text"`
+	AvailableAt   time.Time  `gorm:"not null;index:idx_outbox_events_status_available"` // 재시도 시 다음 발행 가능 시각
+	LockedUntil   *time.Time // relay 가 선점한 이벤트는 이 시각까지 다른 relay 가 가져가지 않음
+	CreatedAt     time.Time
+	PublishedAt   *time.Time
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// PersistedQuery stores a GraphQL query registered through APQ, keyed by its SHA-256 hash
+// DB: persisted_queries
+type PersistedQuery struct {
+	Hash      string `gorm:"size:64;primaryKey"` // hex(sha256(query))
+	Query     string `gorm:"type:text;not null"`
+	CreatedAt time.Time
+}
+
+func (PersistedQuery) TableName() string {
+	return "persisted_queries"
+}
@@ -18,6 +18,9 @@ type Profile struct {
 	FollowerCount  int32 `gorm:"not null;default:0" json:"follower_count"`
 	FollowingCount int32 `gorm:"not null;default:0" json:"following_count"`
 
+	// 낙관적 동시성 제어용
+	Version int32 `gorm:"not null;default:1" json:"version"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
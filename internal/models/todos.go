@@ -7,12 +7,13 @@ import (
 )
 
 type Todo struct {
-	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
-	CalendarEventID uuid.UUID `gorm:"type:uuid;not null"`
-	Content         string
-	IsDone          bool
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	CalendarEventID uuid.UUID `gorm:"type:uuid;not null" json:"calendarEventId"`
+	Content         string    `json:"content"`
+	IsDone          bool      `json:"isDone"`
+	Version         int32     `gorm:"not null;default:1" json:"version"` // 낙관적 동시성 제어용
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
 }
 
 func (Todo) TableName() string {
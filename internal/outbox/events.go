@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+// Aggregate 종류
+const (
+	AggregateProfile       = "profile"
+	AggregateCalendarEvent = "calendar_event"
+)
+
+// 다른 서비스가 구독하는 이벤트 타입
+const (
+	EventProfileCreated         = "profile.created"
+	EventProfileUpdated         = "profile.updated"
+	EventProfileNicknameChanged = "profile.nickname_changed"
+	EventProfileDeleted         = "profile.deleted"
+
+	EventCalendarEventCreated = "calendar_event.created"
+	EventCalendarEventUpdated = "calendar_event.updated"
+	EventCalendarEventDeleted = "calendar_event.deleted"
+
+	// follow.created / follow.deleted 는 FollowService 가 생기면 추가합니다.
+)
+
+type ProfilePayload struct {
+	UserID   uuid.UUID `json:"userId"`
+	Nickname string    `json:"nickname"`
+	Version  int32     `json:"version"`
+}
+
+type NicknameChangedPayload struct {
+	UserID      uuid.UUID `json:"userId"`
+	OldNickname string    `json:"oldNickname"`
+	NewNickname string    `json:"newNickname"`
+}
+
+type CalendarEventPayload struct {
+	EventID    uuid.UUID  `json:"eventId"`
+	UserID     uuid.UUID  `json:"userId"`
+	Title      string     `json:"title,omitempty"`
+	StartAt    *time.Time `json:"startAt,omitempty"`
+	EndAt      *time.Time `json:"endAt,omitempty"`
+	Visibility string     `json:"visibility,omitempty"`
+	Version    int32      `json:"version,omitempty"`
+}
+
+func NewCalendarEventPayload(event *models.CalendarEvent) CalendarEventPayload {
+	return CalendarEventPayload{
+		EventID:    event.ID,
+		UserID:     event.UserID,
+		Title:      event.Title,
+		StartAt:    &event.StartAt,
+		EndAt:      &event.EndAt,
+		Visibility: event.Visibility,
+		Version:    event.Version,
+	}
+}
+
+// Writer: 서비스가 도메인 변경과 같은 트랜잭션에서 이벤트를 기록할 때 사용합니다.
+// ctx 에 tx.WithTx 로 담긴 트랜잭션이 있으면 그 트랜잭션으로 기록됩니다.
+type Writer struct {
+	repo repository.OutboxEventsRepositoryInterface
+}
+
+func NewWriter(repo repository.OutboxEventsRepositoryInterface) *Writer {
+	return &Writer{repo: repo}
+}
+
+// Record: nil Writer 에서는 아무 것도 하지 않습니다.
+func (w *Writer) Record(
+	ctx context.Context,
+	aggregateType string,
+	aggregateID uuid.UUID,
+	eventType string,
+	payload interface{},
+) error {
+	if w == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	now := time.Now()
+	return w.repo.Append(ctx, &models.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       body,
+		Status:        models.OutboxStatusPending,
+		AvailableAt:   now,
+		CreatedAt:     now,
+	})
+}
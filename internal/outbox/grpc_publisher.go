@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultGrpcPublishMethod: 이벤트 수신 서버의 양방향 스트림 메서드
+const DefaultGrpcPublishMethod = "/planet.event.EventService/Publish"
+
+var publishStreamDesc = &grpc.StreamDesc{
+	StreamName:    "Publish",
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+// GrpcStreamPublisher: 하나의 양방향 gRPC 스트림으로 이벤트를 보내고, 이벤트마다 ack 를 기다립니다.
+//
+// 요청 메시지: google.protobuf.Struct (Message 의 JSON 필드와 동일)
+// 응답 메시지: google.protobuf.Empty (수신 확인)
+//
+// 스트림이 끊기면 다음 Publish 에서 다시 연결합니다.
+type GrpcStreamPublisher struct {
+	conn   *grpc.ClientConn
+	method string
+
+	mu     sync.Mutex
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func NewGrpcStreamPublisher(conn *grpc.ClientConn, method string) *GrpcStreamPublisher {
+	if method == "" {
+		method = DefaultGrpcPublishMethod
+	}
+	return &GrpcStreamPublisher{
+		conn:   conn,
+		method: method,
+	}
+}
+
+func (p *GrpcStreamPublisher) Publish(ctx context.Context, msg Message) error {
+	req, err := toStruct(msg)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stream, err := p.openStream()
+	if err != nil {
+		return err
+	}
+
+	// 스트림은 Publish 호출보다 오래 살아 있으므로 ack 대기에만 ctx 를 적용합니다.
+	done := make(chan error, 1)
+	go func() {
+		if err := stream.SendMsg(req); err != nil {
+			done <- fmt.Errorf("failed to send outbox event: %w", err)
+			return
+		}
+		if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+			done <- fmt.Errorf("failed to receive outbox ack: %w", err)
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			p.resetStream()
+		}
+		return err
+	case <-ctx.Done():
+		// 응답을 기다리던 스트림은 순서를 보장할 수 없으므로 버립니다.
+		p.resetStream()
+		return ctx.Err()
+	}
+}
+
+func (p *GrpcStreamPublisher) openStream() (grpc.ClientStream, error) {
+	if p.stream != nil {
+		return p.stream, nil
+	}
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := p.conn.NewStream(streamCtx, publishStreamDesc, p.method)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open outbox stream: %w", err)
+	}
+
+	logger.Infof("[Outbox] gRPC stream opened method=%s", p.method)
+	p.stream = stream
+	p.cancel = cancel
+	return stream, nil
+}
+
+func (p *GrpcStreamPublisher) resetStream() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.stream = nil
+	p.cancel = nil
+}
+
+func (p *GrpcStreamPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stream != nil {
+		_ = p.stream.CloseSend()
+	}
+	p.resetStream()
+	return p.conn.Close()
+}
+
+func toStruct(msg Message) (*structpb.Struct, error) {
+	var payload interface{}
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("invalid outbox payload: %w", err)
+	}
+
+	return structpb.NewStruct(map[string]interface{}{
+		"id":            msg.ID.String(),
+		"sequence":      float64(msg.Sequence),
+		"aggregateType": msg.AggregateType,
+		"aggregateId":   msg.AggregateID.String(),
+		"eventType":     msg.EventType,
+		"payload":       payload,
+		"occurredAt":    msg.OccurredAt.Format(time.RFC3339Nano),
+	})
+}
@@ -0,0 +1,60 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+)
+
+// Message: Publisher 로 전달되는 outbox 이벤트
+type Message struct {
+	ID            uuid.UUID       `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   uuid.UUID       `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+}
+
+func ToMessage(event *models.OutboxEvent) Message {
+	return Message{
+		ID:            event.ID,
+		Sequence:      event.Sequence,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     event.EventType,
+		Payload:       json.RawMessage(event.Payload),
+		OccurredAt:    event.CreatedAt,
+	}
+}
+
+// Publisher: outbox 이벤트를 외부로 내보내는 방식
+// 에러를 반환하면 relay 가 backoff 후 같은 이벤트를 다시 발행합니다. (at-least-once)
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// LogPublisher: 이벤트를 로그로만 남깁니다. (개발 환경 / 구독자가 없는 경우)
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, msg Message) error {
+	logger.Infof(
+		"[Outbox] publish seq=%d type=%s aggregate=%s/%s payload=%s",
+		msg.Sequence, msg.EventType, msg.AggregateType, msg.AggregateID, string(msg.Payload),
+	)
+	return nil
+}
+
+func (p *LogPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBatchSize      = 100
+	defaultPublishTimeout = 5 * time.Second
+	baseRetryBackoff      = time.Second
+	maxRetryBackoff       = 5 * time.Minute
+)
+
+type RelayConfig struct {
+	Interval    time.Duration // pending 이벤트 조회 주기
+	MaxAttempts int           // 초과 시 failed 로 두고 더 이상 발행하지 않음
+	BatchSize   int
+	ClaimLease  time.Duration // 선점한 배치를 다른 relay 가 가져가지 못하는 시간 (기본: 배치 전체 발행 timeout)
+}
+
+// Relay: outbox_events 의 pending 이벤트를 Publisher 로 발행하는 워커
+//
+//   - aggregate 별 순서: 같은 aggregate 의 앞선 이벤트가 발행되기 전에는 뒤 이벤트를 발행하지 않습니다.
+//   - 선점: 이벤트를 짧은 트랜잭션에서 ClaimLease 동안 선점한 뒤 트랜잭션 밖에서 발행하므로
+//     발행이 느려도 DB 연결과 행 잠금을 붙잡지 않습니다. 결과 기록 전에 죽으면 lease 후 다시 발행됩니다.
+//   - 재시도: 실패 시 지수 backoff 후 다시 시도하고, MaxAttempts 를 넘기면 failed 로 남깁니다.
+//     failed 이벤트는 뒤 이벤트를 더 이상 막지 않으므로 운영자가 확인 후 재처리해야 합니다.
+type Relay struct {
+	db        *gorm.DB
+	repo      repository.OutboxEventsRepositoryInterface
+	publisher Publisher
+	cfg       RelayConfig
+}
+
+func NewRelay(
+	db *gorm.DB,
+	repo repository.OutboxEventsRepositoryInterface,
+	publisher Publisher,
+	cfg RelayConfig,
+) *Relay {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = time.Duration(cfg.BatchSize) * defaultPublishTimeout
+	}
+	return &Relay{
+		db:        db,
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+	}
+}
+
+// Run: ctx 가 취소될 때까지 주기적으로 발행합니다.
+func (r *Relay) Run(ctx context.Context) {
+	logger.Infof("[Outbox] relay started interval=%s maxAttempts=%d", r.cfg.Interval, r.cfg.MaxAttempts)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infof("[Outbox] relay stopped")
+			return
+		case <-ticker.C:
+		}
+
+		// 배치가 가득 찼으면 남은 이벤트를 바로 이어서 처리
+		for {
+			n, err := r.ProcessBatch(ctx)
+			if err != nil {
+				logger.Errorf("[Outbox] relay batch failed: %v", err)
+				break
+			}
+			if n < r.cfg.BatchSize || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
+
+// Close: Publisher 연결을 정리합니다. Run 이 끝난 뒤 호출해야 합니다.
+func (r *Relay) Close() error {
+	return r.publisher.Close()
+}
+
+// ProcessBatch: 발행 가능한 이벤트를 선점(트랜잭션 1) → 발행(트랜잭션 밖) → 결과 기록(트랜잭션 2) 순으로 처리합니다.
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	var events []*models.OutboxEvent
+	err := tx.RunInTx(ctx, r.db, func(txCtx context.Context) error {
+		var err error
+		events, err = r.repo.ClaimPending(txCtx, time.Now(), r.cfg.ClaimLease, r.cfg.BatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	results := make([]error, len(events))
+	for i, event := range events {
+		publishCtx, cancel := context.WithTimeout(ctx, defaultPublishTimeout)
+		results[i] = r.publisher.Publish(publishCtx, ToMessage(event))
+		cancel()
+	}
+
+	err = tx.RunInTx(ctx, r.db, func(txCtx context.Context) error {
+		now := time.Now()
+		for i, event := range events {
+			if err := r.markResult(txCtx, event, results[i], now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// markResult: 발행 결과를 기록하고 선점을 해제합니다.
+func (r *Relay) markResult(ctx context.Context, event *models.OutboxEvent, pubErr error, now time.Time) error {
+	if pubErr == nil {
+		return r.repo.MarkPublished(ctx, event.ID, now)
+	}
+
+	attempts := event.Attempts + 1
+	failed := attempts >= r.cfg.MaxAttempts
+	if failed {
+		logger.Errorf("[Outbox] giving up event=%s type=%s after %d attempts: %v", event.ID, event.EventType, attempts, pubErr)
+	} else {
+		logger.Warnf("[Outbox] publish failed event=%s type=%s attempt=%d: %v", event.ID, event.EventType, attempts, pubErr)
+	}
+	return r.repo.MarkRetry(ctx, event.ID, pubErr.Error(), now.Add(retryBackoff(attempts)), failed)
+}
+
+func retryBackoff(attempts int) time.Duration {
+	backoff := baseRetryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return backoff
+}
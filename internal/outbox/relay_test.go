@@ -0,0 +1,173 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/repositorytest"
+	"gorm.io/gorm"
+)
+
+// stubPublisher: 발행한 메시지를 기록하고, onPublish 가 있으면 발행 도중에 호출합니다.
+type stubPublisher struct {
+	published []outbox.Message
+	fail      error
+	onPublish func(msg outbox.Message)
+}
+
+func (p *stubPublisher) Publish(ctx context.Context, msg outbox.Message) error {
+	if p.onPublish != nil {
+		p.onPublish(msg)
+	}
+	if p.fail != nil {
+		return p.fail
+	}
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func (p *stubPublisher) Close() error { return nil }
+
+func appendEvent(t *testing.T, repo repository.OutboxEventsRepositoryInterface, aggregateID uuid.UUID, eventType string) *models.OutboxEvent {
+	t.Helper()
+
+	event := &models.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: "profile",
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       []byte(`{}`),
+		Status:        models.OutboxStatusPending,
+		AvailableAt:   time.Now().Add(-time.Second),
+	}
+	if err := repo.Append(context.Background(), event); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	return event
+}
+
+func TestRelayPublishesClaimedEventsOnce(t *testing.T) {
+	repo := memory.NewOutboxEventsRepository(memory.NewStore())
+	aggregateID := uuid.New()
+	first := appendEvent(t, repo, aggregateID, "profile.created")
+	appendEvent(t, repo, aggregateID, "profile.updated")
+
+	publisher := &stubPublisher{}
+	relay := outbox.NewRelay(nil, repo, publisher, outbox.RelayConfig{})
+	other := outbox.NewRelay(nil, repo, &stubPublisher{}, outbox.RelayConfig{})
+
+	// 발행 도중 다른 relay 는 선점된 이벤트를 가져가지 못합니다.
+	publisher.onPublish = func(outbox.Message) {
+		if n, err := other.ProcessBatch(context.Background()); err != nil || n != 0 {
+			t.Errorf("other relay ProcessBatch = %d, %v, want 0 while claimed", n, err)
+		}
+	}
+
+	n, err := relay.ProcessBatch(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("ProcessBatch = %d, %v, want 1 (one event per aggregate)", n, err)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].ID != first.ID {
+		t.Fatalf("published = %+v, want first event", publisher.published)
+	}
+
+	// 앞 이벤트가 발행된 뒤에야 다음 이벤트가 나갑니다.
+	publisher.onPublish = nil
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("second ProcessBatch = %d, %v, want 1", n, err)
+	}
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 0 {
+		t.Fatalf("third ProcessBatch = %d, %v, want 0", n, err)
+	}
+	if len(publisher.published) != 2 || publisher.published[1].EventType != "profile.updated" {
+		t.Fatalf("published = %+v", publisher.published)
+	}
+}
+
+func TestRelayRetriesFailedPublish(t *testing.T) {
+	repo := memory.NewOutboxEventsRepository(memory.NewStore())
+	appendEvent(t, repo, uuid.New(), "profile.created")
+
+	publisher := &stubPublisher{fail: errors.New("unavailable")}
+	relay := outbox.NewRelay(nil, repo, publisher, outbox.RelayConfig{MaxAttempts: 2})
+
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("ProcessBatch = %d, %v, want 1", n, err)
+	}
+	// 실패한 이벤트는 선점이 풀리지만 backoff 가 지나기 전에는 다시 나가지 않습니다.
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 0 {
+		t.Fatalf("ProcessBatch during backoff = %d, %v, want 0", n, err)
+	}
+
+	events, err := repo.ClaimPending(context.Background(), time.Now().Add(time.Hour), time.Minute, 10)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("ClaimPending after backoff = %v, %v", events, err)
+	}
+	if events[0].Attempts != 1 || events[0].LastError != "unavailable" {
+		t.Fatalf("event = %+v, want attempts=1 with last error", events[0])
+	}
+}
+
+func TestRelayReclaimsAfterLease(t *testing.T) {
+	repo := memory.NewOutboxEventsRepository(memory.NewStore())
+	event := appendEvent(t, repo, uuid.New(), "profile.created")
+
+	// 한 시간 전에 선점하고 결과를 기록하지 못한 relay (lease 만료)
+	if _, err := repo.ClaimPending(context.Background(), time.Now().Add(-time.Hour), time.Minute, 10); err != nil {
+		t.Fatalf("ClaimPending: %v", err)
+	}
+
+	publisher := &stubPublisher{}
+	relay := outbox.NewRelay(nil, repo, publisher, outbox.RelayConfig{})
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("ProcessBatch = %d, %v, want 1", n, err)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].ID != event.ID {
+		t.Fatalf("published = %+v", publisher.published)
+	}
+}
+
+// Postgres 에서 발행하는 동안 outbox 행이 잠겨 있지 않은지 확인합니다.
+func TestRelayPublishesWithoutRowLockPostgres(t *testing.T) {
+	db := repositorytest.OpenPostgres(t)
+	repositorytest.Truncate(t, db, "outbox_events")
+
+	repo := repository.NewOutboxEventsRepository(db)
+	event := appendEvent(t, repo, uuid.New(), "profile.created")
+
+	publisher := &stubPublisher{}
+	publisher.onPublish = func(outbox.Message) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var locked models.OutboxEvent
+			return tx.Raw("SELECT * FROM outbox_events WHERE id = ? FOR UPDATE NOWAIT", event.ID).Scan(&locked).Error
+		})
+		if err != nil {
+			t.Errorf("outbox row locked while publishing: %v", err)
+		}
+
+		var claimed models.OutboxEvent
+		if err := db.First(&claimed, "id = ?", event.ID).Error; err != nil || claimed.LockedUntil == nil {
+			t.Errorf("claimed event = %+v, %v, want locked_until set", claimed, err)
+		}
+	}
+
+	relay := outbox.NewRelay(db, repo, publisher, outbox.RelayConfig{})
+	if n, err := relay.ProcessBatch(context.Background()); err != nil || n != 1 {
+		t.Fatalf("ProcessBatch = %d, %v, want 1", n, err)
+	}
+
+	var published models.OutboxEvent
+	if err := db.First(&published, "id = ?", event.ID).Error; err != nil {
+		t.Fatalf("load event: %v", err)
+	}
+	if published.Status != models.OutboxStatusPublished || published.LockedUntil != nil {
+		t.Fatalf("event = %+v, want published and unlocked", published)
+	}
+}
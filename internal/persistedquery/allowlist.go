@@ -0,0 +1,129 @@
+package persistedquery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+
+// Allowlist: 클라이언트 빌드에서 생성한 manifest 의 operation 만 실행합니다.
+// APQ 대신 사용하며, hash 만 보낸 요청은 manifest 의 쿼리로 채웁니다.
+type Allowlist struct {
+	queries map[string]string // sha256 → query
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = (*Allowlist)(nil)
+
+// apolloManifest: Apollo persisted query manifest 형식
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadAllowlist: Apollo manifest 또는 {"<sha256>": "<query>"} 형식의 JSON 파일을 읽습니다.
+func LoadAllowlist(path string) (*Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operation allowlist: %w", err)
+	}
+
+	a := &Allowlist{queries: map[string]string{}}
+
+	var manifest apolloManifest
+	if err := json.Unmarshal(data, &manifest); err == nil && manifest.Format != "" {
+		for _, op := range manifest.Operations {
+			a.add(op.ID, op.Body)
+		}
+	} else {
+		var flat map[string]string
+		if err := json.Unmarshal(data, &flat); err != nil {
+			return nil, fmt.Errorf("invalid operation allowlist %s: %w", path, err)
+		}
+		for id, body := range flat {
+			a.add(id, body)
+		}
+	}
+
+	if len(a.queries) == 0 {
+		return nil, fmt.Errorf("operation allowlist %s is empty", path)
+	}
+	return a, nil
+}
+
+// add: manifest id 와 본문 hash 를 모두 등록합니다. (id 가 sha256 이 아닌 도구도 있음)
+func (a *Allowlist) add(id, body string) {
+	if body == "" {
+		return
+	}
+	if id != "" {
+		a.queries[id] = body
+	}
+	a.queries[hashQuery(body)] = body
+}
+
+func (a *Allowlist) Len() int {
+	return len(a.queries)
+}
+
+func (*Allowlist) ExtensionName() string {
+	return "OperationAllowlist"
+}
+
+func (*Allowlist) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a *Allowlist) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	if params.Query == "" {
+		hash := persistedHash(params.Extensions)
+		query, ok := a.queries[hash]
+		if !ok {
+			return notAllowed(ctx, hash)
+		}
+		params.Query = query
+		return nil
+	}
+
+	hash := hashQuery(params.Query)
+	if _, ok := a.queries[hash]; !ok {
+		return notAllowed(ctx, hash)
+	}
+	return nil
+}
+
+// persistedHash: extensions.persistedQuery.sha256Hash
+func persistedHash(extensions map[string]any) string {
+	pq, _ := extensions["persistedQuery"].(map[string]any)
+	hash, _ := pq["sha256Hash"].(string)
+	return hash
+}
+
+func hashQuery(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func notAllowed(ctx context.Context, hash string) *gqlerror.Error {
+	logctx.Warnf(ctx, "[Allowlist] rejected operation hash=%s", hash)
+
+	err := gqlerror.Errorf("operation is not in the allowlist")
+	errcode.Set(err, errOperationNotAllowed)
+	return err
+}
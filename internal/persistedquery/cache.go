@@ -0,0 +1,65 @@
+package persistedquery
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+const (
+	CacheMemory = "memory" // 인스턴스별 LRU (재시작 시 클라이언트가 전체 쿼리를 다시 보냄)
+	CacheDB     = "db"     // persisted_queries 테이블 공유 (앞단에 LRU)
+)
+
+// NewMemoryCache: APQ 기본 캐시
+func NewMemoryCache(size int) graphql.Cache[string] {
+	return lru.New[string](size)
+}
+
+// DBCache: 여러 인스턴스가 APQ 등록 결과를 공유합니다.
+// 조회가 매 요청마다 일어나므로 앞단에 LRU 를 둡니다.
+type DBCache struct {
+	repo  repository.PersistedQueriesRepositoryInterface
+	local graphql.Cache[string]
+}
+
+var _ graphql.Cache[string] = (*DBCache)(nil)
+
+func NewDBCache(repo repository.PersistedQueriesRepositoryInterface, size int) *DBCache {
+	return &DBCache{
+		repo:  repo,
+		local: lru.New[string](size),
+	}
+}
+
+func (c *DBCache) Get(ctx context.Context, hash string) (string, bool) {
+	if query, ok := c.local.Get(ctx, hash); ok {
+		return query, true
+	}
+
+	record, err := c.repo.Find(ctx, hash)
+	if err != nil {
+		// DB 장애 시 miss 로 처리 → 클라이언트가 전체 쿼리로 재시도
+		logctx.Warnf(ctx, "[APQ] lookup failed hash=%s err=%v", hash, err)
+		return "", false
+	}
+	if record == nil {
+		return "", false
+	}
+
+	c.local.Add(ctx, hash, record.Query)
+	return record.Query, true
+}
+
+// Add: APQ extension 이 hash 검증을 마친 뒤 호출합니다.
+func (c *DBCache) Add(ctx context.Context, hash string, query string) {
+	c.local.Add(ctx, hash, query)
+
+	if err := c.repo.Save(ctx, &models.PersistedQuery{Hash: hash, Query: query}); err != nil {
+		logctx.Warnf(ctx, "[APQ] save failed hash=%s err=%v", hash, err)
+	}
+}
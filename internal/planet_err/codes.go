@@ -1,10 +1,24 @@
 package planet_err
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 // ErrorCode는 사용자 정의 에러 코드를 나타냅니다.
 type ErrorCode string
 
+const (
+	// CodeConflict: 낙관적 동시성 제어(version) 충돌
+	CodeConflict ErrorCode = "CONFLICT"
+	// CodeIdempotencyInProgress: 같은 Idempotency-Key 요청이 아직 처리 중
+	CodeIdempotencyInProgress ErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	// CodeIdempotencyKeyReused: 같은 Idempotency-Key 로 다른 요청 본문을 보냄
+	CodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	// CodeNicknameTaken: 다른 사용자가 이미 사용 중인 닉네임
+	CodeNicknameTaken ErrorCode = "NICKNAME_TAKEN"
+)
+
 // CodeError는 에러 코드와 메시지, HTTP 상태 코드, 원본 오류를 포함
 type CodeError struct {
 	Code    ErrorCode              `json:"code"`
@@ -34,3 +48,32 @@ func (e *CodeError) WithData(data map[string]interface{}) *CodeError {
 	e.Data = data
 	return e
 }
+
+// Unwrap: errors.Is / errors.As 로 원본 오류를 확인할 수 있도록 합니다.
+func (e *CodeError) Unwrap() error {
+	return e.Err
+}
+
+// NewConflictError: version 충돌 시 서버의 최신본(current)을 담은 CONFLICT 에러를 생성합니다.
+func NewConflictError(current interface{}) *CodeError {
+	return NewCodeError(
+		CodeConflict,
+		"resource was modified by another request",
+		http.StatusConflict,
+		ErrVersionConflict,
+	).WithData(map[string]interface{}{
+		"current": current,
+	})
+}
+
+// NewNicknameTakenError: 사용 가능한 대체 닉네임(suggestions)을 담은 NICKNAME_TAKEN 에러를 생성합니다.
+func NewNicknameTakenError(suggestions []string) *CodeError {
+	return NewCodeError(
+		CodeNicknameTaken,
+		ErrNicknameDuplicate.Error(),
+		http.StatusConflict,
+		ErrNicknameDuplicate,
+	).WithData(map[string]interface{}{
+		"suggestions": suggestions,
+	})
+}
@@ -13,6 +13,13 @@ var ErrAlreadyExists = errors.New("resource already exists")
 // 닉네임 중복 오류
 var ErrNicknameDuplicate = errors.New("nickname is already in use")
 
+// 낙관적 동시성 제어(version) 충돌 오류
+var ErrVersionConflict = errors.New("version conflict")
+
+// Idempotency-Key 관련 오류
+var ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used with a different request")
+
 // GORM 및 일반 오류 체크 헬퍼
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound) || errors.Is(err, gorm.ErrRecordNotFound)
@@ -21,3 +28,7 @@ func IsNotFound(err error) bool {
 func IsAlreadyExists(err error) bool {
 	return errors.Is(err, ErrAlreadyExists)
 }
+
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
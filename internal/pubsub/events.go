@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+type ChangeAction string
+
+const (
+	ActionCreated ChangeAction = "created"
+	ActionUpdated ChangeAction = "updated"
+	ActionDeleted ChangeAction = "deleted"
+)
+
+// visibilityPublic: 소유자가 아닌 구독자에게 전달되는 공개 범위
+const visibilityPublic = "public"
+
+// CalendarEventChange: 사용자 캘린더(= 소유자 userID)의 일정 변경 알림
+// 삭제 시 Event 는 삭제 직전 스냅샷이며, 공개 범위 필터링에만 사용하고 클라이언트에는 내려주지 않습니다.
+type CalendarEventChange struct {
+	Action  ChangeAction
+	UserID  uuid.UUID
+	EventID uuid.UUID
+	Event   *models.CalendarEvent
+
+	PreviousVisibility string // 수정 시 변경 전 공개 범위 (public 에서 바뀌면 다른 구독자에게는 삭제로 전달)
+}
+
+// ForViewer: 구독자에게 전달할 알림을 반환합니다. 전달하지 않으면 false.
+//
+//   - 소유자는 모든 알림을 그대로 받습니다.
+//   - 다른 사용자는 public 일정의 알림만 받습니다.
+//   - public 이던 일정이 비공개로 바뀌면 내용 없이 삭제 알림을 받아 화면에서 제거합니다.
+func (c CalendarEventChange) ForViewer(isOwner bool) (CalendarEventChange, bool) {
+	if isOwner {
+		return c, true
+	}
+	if c.Event != nil && c.Event.Visibility == visibilityPublic {
+		return c, true
+	}
+	if c.Action == ActionUpdated && c.PreviousVisibility == visibilityPublic {
+		return CalendarEventChange{
+			Action:  ActionDeleted,
+			UserID:  c.UserID,
+			EventID: c.EventID,
+		}, true
+	}
+	return CalendarEventChange{}, false
+}
+
+// TodoChange: 일정에 속한 Todo 변경 알림
+// 삭제 시 Todo 는 nil 입니다.
+type TodoChange struct {
+	Action  ChangeAction
+	UserID  uuid.UUID
+	EventID uuid.UUID
+	TodoID  uuid.UUID
+	Todo    *models.Todo
+}
+
+func CalendarTopic(userID uuid.UUID) string {
+	return "calendar:" + userID.String()
+}
+
+func TodoTopic(eventID uuid.UUID) string {
+	return "todo:" + eventID.String()
+}
+
+func (h *Hub) PublishCalendarEventChange(change CalendarEventChange) {
+	h.Publish(CalendarTopic(change.UserID), change)
+}
+
+func (h *Hub) PublishTodoChange(change TodoChange) {
+	h.Publish(TodoTopic(change.EventID), change)
+}
@@ -0,0 +1,95 @@
+package pubsub_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+)
+
+func TestCalendarEventChangeForViewer(t *testing.T) {
+	event := func(visibility string) *models.CalendarEvent {
+		return &models.CalendarEvent{ID: uuid.New(), Title: "secret", Visibility: visibility}
+	}
+
+	tests := []struct {
+		name       string
+		change     pubsub.CalendarEventChange
+		isOwner    bool
+		wantOK     bool
+		wantAction pubsub.ChangeAction
+		wantEvent  bool
+	}{
+		{
+			name:       "owner sees private update",
+			change:     pubsub.CalendarEventChange{Action: pubsub.ActionUpdated, Event: event("private")},
+			isOwner:    true,
+			wantOK:     true,
+			wantAction: pubsub.ActionUpdated,
+			wantEvent:  true,
+		},
+		{
+			name:       "public event",
+			change:     pubsub.CalendarEventChange{Action: pubsub.ActionCreated, Event: event("public")},
+			wantOK:     true,
+			wantAction: pubsub.ActionCreated,
+			wantEvent:  true,
+		},
+		{
+			name:   "private event",
+			change: pubsub.CalendarEventChange{Action: pubsub.ActionCreated, Event: event("private")},
+		},
+		{
+			name:   "friends event",
+			change: pubsub.CalendarEventChange{Action: pubsub.ActionUpdated, Event: event("friends"), PreviousVisibility: "friends"},
+		},
+		{
+			name:       "public to private is redacted removal",
+			change:     pubsub.CalendarEventChange{Action: pubsub.ActionUpdated, Event: event("private"), PreviousVisibility: "public"},
+			wantOK:     true,
+			wantAction: pubsub.ActionDeleted,
+		},
+		{
+			name:       "private to public",
+			change:     pubsub.CalendarEventChange{Action: pubsub.ActionUpdated, Event: event("public"), PreviousVisibility: "private"},
+			wantOK:     true,
+			wantAction: pubsub.ActionUpdated,
+			wantEvent:  true,
+		},
+		{
+			name:       "public event deleted",
+			change:     pubsub.CalendarEventChange{Action: pubsub.ActionDeleted, Event: event("public")},
+			wantOK:     true,
+			wantAction: pubsub.ActionDeleted,
+			wantEvent:  true, // 스냅샷은 유지되고 mapper 가 내려주지 않음
+		},
+		{
+			name:   "private event deleted",
+			change: pubsub.CalendarEventChange{Action: pubsub.ActionDeleted, Event: event("private")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change.EventID = uuid.New()
+
+			got, ok := tt.change.ForViewer(tt.isOwner)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got.Action != tt.wantAction {
+				t.Errorf("action = %s, want %s", got.Action, tt.wantAction)
+			}
+			if got.EventID != tt.change.EventID {
+				t.Errorf("eventID = %s, want %s", got.EventID, tt.change.EventID)
+			}
+			if (got.Event != nil) != tt.wantEvent {
+				t.Errorf("event = %v, want present=%v", got.Event, tt.wantEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+)
+
+// subscriberBufferSize: 느린 구독자가 발행자를 막지 않도록 채널 버퍼를 둡니다.
+// 버퍼가 가득 차면 해당 구독자에게는 메시지를 버립니다.
+const subscriberBufferSize = 16
+
+type subscriber struct {
+	ch chan interface{}
+}
+
+// Hub: 프로세스 내부 pub/sub. topic 단위로 구독자에게 메시지를 전달합니다.
+// 서비스는 커밋 이후 Publish 하고, GraphQL Subscription 리졸버가 Subscribe 합니다.
+type Hub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*subscriber]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		topics: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe: topic 을 구독합니다. 반환된 unsubscribe 를 호출하면 채널이 닫힙니다.
+func (h *Hub) Subscribe(topic string) (<-chan interface{}, func()) {
+	sub := &subscriber{
+		ch: make(chan interface{}, subscriberBufferSize),
+	}
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*subscriber]struct{})
+	}
+	h.topics[topic][sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.topics[topic], sub)
+			if len(h.topics[topic]) == 0 {
+				delete(h.topics, topic)
+			}
+			close(sub.ch)
+			h.mu.Unlock()
+		})
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Publish: topic 의 모든 구독자에게 msg 를 전달합니다. (non-blocking)
+// nil Hub 에서는 아무 것도 하지 않습니다.
+func (h *Hub) Publish(topic string, msg interface{}) {
+	if h == nil {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.topics[topic] {
+		select {
+		case sub.ch <- msg:
+		default:
+			logger.Warnf("[PubSub] subscriber buffer full, dropping message topic=%s", topic)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLimited: 토큰이 부족합니다. 함께 반환된 시간 뒤에 다시 시도할 수 있습니다.
+	ErrLimited = errors.New("ratelimit: limited")
+	// ErrExceedsBurst: 요청한 토큰 수가 burst 보다 커서 기다려도 허용되지 않습니다.
+	ErrExceedsBurst = errors.New("ratelimit: request exceeds burst")
+)
+
+// sweepEvery: Allow 호출 N 번마다 오래 쓰이지 않은 bucket 을 정리합니다.
+const sweepEvery = 1024
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// KeyedLimiter: 키(user ID / IP 등)별 token bucket.
+// rate 개/초로 토큰이 채워지고 최대 burst 개까지 쌓입니다.
+type KeyedLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	idleTTL time.Duration
+	buckets map[string]*bucket
+	calls   int
+
+	now func() time.Time
+}
+
+// NewKeyedLimiter: rate <= 0 이면 nil 을 반환합니다. (nil limiter 는 항상 허용)
+func NewKeyedLimiter(rate float64, burst int, idleTTL time.Duration) *KeyedLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &KeyedLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		idleTTL: idleTTL,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow: 토큰 n 개를 소비합니다. 거절되면 ErrLimited 와 다시 시도할 수 있을 때까지의 시간을 반환합니다.
+// n 이 burst 보다 크면 bucket 을 건드리지 않고 ErrExceedsBurst 를 반환합니다.
+func (l *KeyedLimiter) Allow(key string, n int) (time.Duration, error) {
+	if l == nil || n <= 0 {
+		return 0, nil
+	}
+	need := float64(n)
+	if need > l.burst {
+		return 0, ErrExceedsBurst
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b := l.refill(key, now)
+	if b.tokens >= need {
+		b.tokens -= need
+		return 0, nil
+	}
+
+	wait := time.Duration((need - b.tokens) / l.rate * float64(time.Second))
+	return wait, ErrLimited
+}
+
+// Refund: Allow 로 소비한 토큰 n 개를 되돌립니다. (같은 요청의 다른 bucket 에서 거절된 경우)
+func (l *KeyedLimiter) Refund(key string, n int) {
+	if l == nil || n <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(key, l.now())
+	b.tokens = math.Min(l.burst, b.tokens+float64(n))
+}
+
+// refill: key 의 bucket 에 경과 시간만큼 토큰을 보충합니다. 없으면 가득 찬 bucket 을 만듭니다.
+func (l *KeyedLimiter) refill(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+	b.lastSeen = now
+	return b
+}
+
+func (l *KeyedLimiter) sweep(now time.Time) {
+	l.calls++
+	if l.calls < sweepEvery {
+		return
+	}
+	l.calls = 0
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestLimiter: now 를 고정한 limiter. advance 로 시간을 흘립니다.
+func newTestLimiter(rate float64, burst int) (*KeyedLimiter, func(time.Duration)) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewKeyedLimiter(rate, burst, time.Minute)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAllowConsumesBurstThenLimits(t *testing.T) {
+	l, advance := newTestLimiter(2, 3)
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Allow("alice", 1); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+
+	wait, err := l.Allow("alice", 1)
+	if !errors.Is(err, ErrLimited) || wait != 500*time.Millisecond {
+		t.Fatalf("Allow = %s, %v, want 500ms ErrLimited", wait, err)
+	}
+
+	// 다른 키는 별도의 bucket
+	if _, err := l.Allow("bob", 1); err != nil {
+		t.Fatalf("bob: %v", err)
+	}
+
+	advance(500 * time.Millisecond)
+	if _, err := l.Allow("alice", 1); err != nil {
+		t.Fatalf("after refill: %v", err)
+	}
+}
+
+func TestAllowRefillCapsAtBurst(t *testing.T) {
+	l, advance := newTestLimiter(1, 2)
+
+	if _, err := l.Allow("alice", 2); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	advance(time.Hour)
+
+	if _, err := l.Allow("alice", 2); err != nil {
+		t.Fatalf("Allow after idle: %v", err)
+	}
+	if wait, err := l.Allow("alice", 1); !errors.Is(err, ErrLimited) || wait != time.Second {
+		t.Fatalf("Allow = %s, %v, want 1s ErrLimited (refill capped at burst)", wait, err)
+	}
+}
+
+func TestAllowExceedsBurst(t *testing.T) {
+	l, _ := newTestLimiter(1, 5)
+
+	wait, err := l.Allow("alice", 6)
+	if !errors.Is(err, ErrExceedsBurst) || wait != 0 {
+		t.Fatalf("Allow(6) = %s, %v, want ErrExceedsBurst", wait, err)
+	}
+
+	// 거절된 요청은 토큰을 소비하지 않습니다.
+	if _, err := l.Allow("alice", 5); err != nil {
+		t.Fatalf("Allow(5) after reject: %v", err)
+	}
+}
+
+func TestRefund(t *testing.T) {
+	l, _ := newTestLimiter(1, 2)
+
+	if _, err := l.Allow("alice", 2); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	l.Refund("alice", 1)
+	if _, err := l.Allow("alice", 1); err != nil {
+		t.Fatalf("Allow after refund: %v", err)
+	}
+
+	// burst 를 넘겨 되돌리지 않습니다.
+	l.Refund("alice", 10)
+	if _, err := l.Allow("alice", 2); err != nil {
+		t.Fatalf("Allow(2): %v", err)
+	}
+	if _, err := l.Allow("alice", 1); !errors.Is(err, ErrLimited) {
+		t.Fatalf("Allow = %v, want ErrLimited", err)
+	}
+}
+
+func TestNilLimiterAllows(t *testing.T) {
+	l := NewKeyedLimiter(0, 10, time.Minute)
+	if l != nil {
+		t.Fatalf("NewKeyedLimiter(rate=0) = %v, want nil", l)
+	}
+	if _, err := l.Allow("alice", 100); err != nil {
+		t.Fatalf("nil limiter Allow: %v", err)
+	}
+	l.Refund("alice", 1)
+}
+
+func TestSweepDropsIdleBuckets(t *testing.T) {
+	l, advance := newTestLimiter(1, 1)
+
+	l.Allow("idle", 1)
+	advance(2 * time.Minute)
+	for i := 0; i < sweepEvery; i++ {
+		l.Allow("active", 1)
+	}
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Fatal("idle bucket was not swept")
+	}
+	if _, ok := l.buckets["active"]; !ok {
+		t.Fatal("active bucket was swept")
+	}
+}
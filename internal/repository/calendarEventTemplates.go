@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+)
+
+type CalendarEventTemplatesRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarEventTemplatesRepository(db *gorm.DB) *CalendarEventTemplatesRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &CalendarEventTemplatesRepository{
+		db: db,
+	}
+}
+
+func (r *CalendarEventTemplatesRepository) getDB(ctx context.Context) *gorm.DB {
+	// tx 패키지를 사용하여 Context에서 트랜잭션을 추출합니다.
+	if tx := tx.GetTx(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx) // 기본 DB 연결 반환
+}
+
+// Todos는 position 순서대로 Preload 합니다.
+func preloadTemplateTodos(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC")
+}
+
+// -------------------------
+// 템플릿 생성 (Todos 포함)
+// -------------------------
+func (r *CalendarEventTemplatesRepository) Create(
+	ctx context.Context,
+	template *models.CalendarEventTemplate,
+) (*models.CalendarEventTemplate, error) {
+
+	db := r.getDB(ctx)
+
+	if err := db.Create(template).Error; err != nil {
+		logctx.Errorf(ctx,
+			"[TemplateRepo] insert failed user=%s err=%v",
+			template.UserID,
+			err,
+		)
+		return nil, fmt.Errorf("failed to insert event template: %w", err)
+	}
+
+	logctx.Infof(ctx,
+		"[TemplateRepo] created template id=%s user=%s",
+		template.ID,
+		template.UserID,
+	)
+
+	return template, nil
+}
+
+// -------------------------
+// 단일 조회 (Todos 포함)
+// -------------------------
+func (r *CalendarEventTemplatesRepository) FindByID(
+	ctx context.Context,
+	templateID uuid.UUID,
+) (*models.CalendarEventTemplate, error) {
+	db := r.getDB(ctx)
+
+	var template models.CalendarEventTemplate
+	if err := db.
+		Preload("Todos", preloadTemplateTodos).
+		First(&template, "id = ?", templateID).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to find event template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// -------------------------
+// 사용자별 목록 조회 (Todos 포함)
+// -------------------------
+func (r *CalendarEventTemplatesRepository) FindByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.CalendarEventTemplate, error) {
+	db := r.getDB(ctx)
+
+	var templates []*models.CalendarEventTemplate
+	if err := db.
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Preload("Todos", preloadTemplateTodos).
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to query event templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// -------------------------
+// 템플릿 업데이트 (Todos 전체 교체)
+// -------------------------
+func (r *CalendarEventTemplatesRepository) Update(
+	ctx context.Context,
+	template *models.CalendarEventTemplate,
+) error {
+
+	return tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
+		if err := db.Omit("Todos").Save(template).Error; err != nil {
+			return err
+		}
+
+		if err := db.
+			Where("template_id = ?", template.ID).
+			Delete(&models.CalendarEventTemplateTodo{}).
+			Error; err != nil {
+			return err
+		}
+
+		if len(template.Todos) > 0 {
+			if err := db.Create(&template.Todos).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// -------------------------
+// 템플릿 삭제 (Todos 포함)
+// -------------------------
+func (r *CalendarEventTemplatesRepository) Delete(ctx context.Context, templateID uuid.UUID) error {
+	logctx.Infof(ctx, "Deleting event template: %s", templateID)
+
+	return tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
+		if err := db.Where("template_id = ?", templateID).Delete(&models.CalendarEventTemplateTodo{}).Error; err != nil {
+			return fmt.Errorf("failed to delete template todos: %w", err)
+		}
+		if err := db.Where("id = ?", templateID).Delete(&models.CalendarEventTemplate{}).Error; err != nil {
+			return fmt.Errorf("failed to delete event template: %w", err)
+		}
+		return nil
+	})
+}
+
+// 사용자의 모든 템플릿 삭제 (탈퇴 시)
+func (r *CalendarEventTemplatesRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	logctx.Infof(ctx, "Deleting all event templates of user: %s", userID)
+
+	return tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
+		if err := db.
+			Where("template_id IN (SELECT id FROM calendar_event_templates WHERE user_id = ?)", userID).
+			Delete(&models.CalendarEventTemplateTodo{}).Error; err != nil {
+			return fmt.Errorf("failed to delete template todos: %w", err)
+		}
+		if err := db.Where("user_id = ?", userID).Delete(&models.CalendarEventTemplate{}).Error; err != nil {
+			return fmt.Errorf("failed to delete event templates: %w", err)
+		}
+		return nil
+	})
+}
@@ -7,9 +7,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
 	"github.com/rainbow96bear/planet_user_server/internal/tx"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -34,6 +34,24 @@ func (r *CalendarEventsRepository) getDB(ctx context.Context) *gorm.DB {
 	return r.db.WithContext(ctx) // 기본 DB 연결 반환
 }
 
+// 사용자의 모든 일정과 Todo 삭제 (탈퇴 시)
+func (r *CalendarEventsRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	logctx.Infof(ctx, "Deleting all calendar events of user: %s", userID)
+
+	return tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
+		if err := db.
+			Where("calendar_event_id IN (SELECT id FROM calendar_events WHERE user_id = ?)", userID).
+			Delete(&models.Todo{}).Error; err != nil {
+			return fmt.Errorf("failed to delete todos: %w", err)
+		}
+		if err := db.Where("user_id = ?", userID).Delete(&models.CalendarEvent{}).Error; err != nil {
+			return fmt.Errorf("failed to delete calendar events: %w", err)
+		}
+		return nil
+	})
+}
+
 // -------------------------
 // 캘린더 이벤트 생성 (Todos 포함)
 // -------------------------
@@ -44,29 +62,23 @@ func (r *CalendarEventsRepository) CreateCalendarEvent(
 
 	db := r.getDB(ctx)
 
-	logger.Debugf(
+	logctx.Debugf(ctx,
 		"[CalendarRepo] create start user=%s title=%s",
 		event.UserID,
 		event.Title,
 	)
 
-	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(event).Error; err != nil {
-			logger.Errorf(
-				"[CalendarRepo] insert failed user=%s err=%v",
-				event.UserID,
-				err,
-			)
-			return fmt.Errorf("failed to insert calendar event: %w", err)
-		}
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
+	// Todos 는 association 으로 함께 저장됩니다. 호출자의 트랜잭션이 없어도 GORM 기본 트랜잭션으로 묶입니다.
+	if err := db.Create(event).Error; err != nil {
+		logctx.Errorf(ctx,
+			"[CalendarRepo] insert failed user=%s err=%v",
+			event.UserID,
+			err,
+		)
+		return nil, fmt.Errorf("failed to insert calendar event: %w", err)
 	}
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[CalendarRepo] created event id=%s user=%s",
 		event.ID,
 		event.UserID,
@@ -103,42 +115,58 @@ func (r *CalendarEventsRepository) FindByID(
 // // 캘린더 이벤트 삭제 (Todos 포함)
 // // -------------------------
 func (r *CalendarEventsRepository) DeleteCalendarEvent(ctx context.Context, eventID uuid.UUID) error {
-	db := r.getDB(ctx)
-	logger.Infof("Deleting calendar event: %s", eventID)
+	logctx.Infof(ctx, "Deleting calendar event: %s", eventID)
 
-	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
 		// Todos 먼저 삭제 (Foreign Key 제약 조건)
-		if err := tx.Where("calendar_event_id = ?", eventID).Delete(&models.Todo{}).Error; err != nil {
+		if err := db.Where("calendar_event_id = ?", eventID).Delete(&models.Todo{}).Error; err != nil {
 			return fmt.Errorf("failed to delete todos: %w", err)
 		}
 		// Event 삭제
-		if err := tx.Where("id = ?", eventID).Delete(&models.CalendarEvent{}).Error; err != nil {
+		if err := db.Where("id = ?", eventID).Delete(&models.CalendarEvent{}).Error; err != nil {
 			return fmt.Errorf("failed to delete calendar event: %w", err)
 		}
-		logger.Infof("Deleted calendar event %s and its todos", eventID)
+		logctx.Infof(ctx, "Deleted calendar event %s and its todos", eventID)
 		return nil
 	})
 }
 
 // -------------------------
 // 캘린더 이벤트 업데이트 (Todos 포함)
+// expectedVersion 과 DB의 version 이 다르면 ErrVersionConflict 를 반환합니다.
 // -------------------------
 func (r *CalendarEventsRepository) Update(
 	ctx context.Context,
 	event *models.CalendarEvent,
+	expectedVersion int32,
 ) error {
 
-	db := r.getDB(ctx)
-
-	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-
-		// 🔹 CalendarEvent 업데이트
-		if err := tx.Save(event).Error; err != nil {
-			return err
+	err := tx.RunInTx(ctx, r.db, func(ctx context.Context) error {
+		db := r.getDB(ctx)
+
+		// 🔹 CalendarEvent 업데이트 (version 일치 시에만)
+		result := db.Model(&models.CalendarEvent{}).
+			Where("id = ? AND version = ?", event.ID, expectedVersion).
+			Updates(map[string]interface{}{
+				"title":       event.Title,
+				"emoji":       event.Emoji,
+				"description": event.Description,
+				"start_at":    event.StartAt,
+				"end_at":      event.EndAt,
+				"visibility":  event.Visibility,
+				"updated_at":  event.UpdatedAt,
+				"version":     gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
 		}
 
 		// 🔹 기존 Todos 전체 삭제
-		if err := tx.
+		if err := db.
 			Where("calendar_event_id = ?", event.ID).
 			Delete(&models.Todo{}).
 			Error; err != nil {
@@ -147,13 +175,19 @@ func (r *CalendarEventsRepository) Update(
 
 		// 🔹 새 Todos 삽입
 		if len(event.Todos) > 0 {
-			if err := tx.Create(&event.Todos).Error; err != nil {
+			if err := db.Create(&event.Todos).Error; err != nil {
 				return err
 			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	event.Version = expectedVersion + 1
+	return nil
 }
 
 // // ------------------------------------------
@@ -162,14 +196,15 @@ func (r *CalendarEventsRepository) Update(
 
 // // FindEventsWithoutTodosByVisibility: 특정 기간 동안의 Event를 Todo 없이 조회합니다.
 // // CalendarService의 GetEventsWithoutTodos에서 사용됩니다. (캐싱 목적)
+// 결과가 캐시되어 어차피 약간 지연될 수 있으므로 replica 에서 읽습니다.
 func (r *CalendarEventsRepository) FindEventsWithoutTodosByVisibility(
 	ctx context.Context,
 	UserID uuid.UUID,
 	visibilities []string,
 	startAt, endAt time.Time,
 ) ([]*models.CalendarEvent, error) {
-	db := r.getDB(ctx)
-	logger.Infof("Fetching events (without todos) for user=%s with visibilities=%v", UserID, visibilities)
+	db := readDB(ctx, r.db)
+	logctx.Infof(ctx, "Fetching events (without todos) for user=%s with visibilities=%v", UserID, visibilities)
 
 	if len(visibilities) == 0 {
 		return []*models.CalendarEvent{}, nil
@@ -184,7 +219,7 @@ func (r *CalendarEventsRepository) FindEventsWithoutTodosByVisibility(
 		return nil, fmt.Errorf("failed to query events without todos by visibility: %w", err)
 	}
 
-	logger.Infof("Found %d calendar events (without todos) for user %s with visibility filter", len(events), UserID)
+	logctx.Infof(ctx, "Found %d calendar events (without todos) for user %s with visibility filter", len(events), UserID)
 	return events, nil
 }
 
@@ -199,7 +234,7 @@ func (r *CalendarEventsRepository) FindCalendarsWithTodos(
 	startAt, endAt time.Time,
 ) ([]*models.CalendarEvent, error) {
 	db := r.getDB(ctx)
-	logger.Infof("Fetching calendars (with todos) for user=%s with visibilities=%v", UserID, visibilities)
+	logctx.Infof(ctx, "Fetching calendars (with todos) for user=%s with visibilities=%v", UserID, visibilities)
 
 	if len(visibilities) == 0 {
 		return []*models.CalendarEvent{}, nil
@@ -214,7 +249,25 @@ func (r *CalendarEventsRepository) FindCalendarsWithTodos(
 		Find(&events).Error; err != nil {
 		return nil, fmt.Errorf("failed to query calendars with todos by visibility: %w", err)
 	}
-	logger.Infof("Found %d calendar events (with todos) for user %s with visibility filter", len(events), UserID)
+	logctx.Infof(ctx, "Found %d calendar events (with todos) for user %s with visibility filter", len(events), UserID)
+	return events, nil
+}
+
+// FindAllWithTodosByUserID: 사용자의 모든 일정을 Todo 와 함께 조회합니다. (export-user 용)
+func (r *CalendarEventsRepository) FindAllWithTodosByUserID(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.CalendarEvent, error) {
+	db := r.getDB(ctx)
+
+	var events []*models.CalendarEvent
+	if err := db.
+		Where("user_id = ?", userID).
+		Order("start_at ASC").
+		Preload("Todos").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to query calendar events of user: %w", err)
+	}
 	return events, nil
 }
 
@@ -0,0 +1,41 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/repositorytest"
+)
+
+func TestContractPostgres(t *testing.T) {
+	db := repositorytest.OpenPostgres(t)
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repos {
+		repositorytest.Truncate(t, db,
+			"profiles", "follows", "calendar_events", "todos",
+			"calendar_event_templates", "calendar_event_template_todos",
+			"idempotency_keys", "outbox_events", "persisted_queries",
+		)
+		return repositorytest.Repos{
+			Profiles:         repository.NewProfilesRepository(db),
+			Events:           repository.NewCalendarEventsRepository(db),
+			Todos:            repository.NewTodosRepository(db),
+			Follows:          repository.NewFollowsRepository(db),
+			Templates:        repository.NewCalendarEventTemplatesRepository(db),
+			IdempotencyKeys:  repository.NewIdempotencyKeysRepository(db),
+			Outbox:           repository.NewOutboxEventsRepository(db),
+			PersistedQueries: repository.NewPersistedQueriesRepository(db),
+			DB:               db,
+			Follow: func(t *testing.T, followerID, followeeID uuid.UUID) {
+				t.Helper()
+				if err := db.Exec(
+					"INSERT INTO follows (follower_uuid, followee_uuid) VALUES (?, ?)",
+					followerID, followeeID,
+				).Error; err != nil {
+					t.Fatalf("insert follow: %v", err)
+				}
+			},
+		}
+	})
+}
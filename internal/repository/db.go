@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaResolver: InitDatabase 가 읽기 전용 replica 를 등록하는 dbresolver 이름
+const ReplicaResolver = "replica"
+
+// readDB: 약간 지연되어도 괜찮은 읽기 전용 조회용 연결을 반환합니다.
+// Context 에 트랜잭션이 있으면 항상 그 트랜잭션(primary)을 사용하고,
+// replica 가 설정되지 않았으면 dbresolver 절은 무시되어 primary 로 갑니다.
+func readDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx := tx.GetTx(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx).Clauses(dbresolver.Use(ReplicaResolver))
+}
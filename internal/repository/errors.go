@@ -6,4 +6,5 @@ var (
 	ErrNotFound          = planet_err.ErrNotFound
 	ErrAlreadyExists     = planet_err.ErrAlreadyExists
 	ErrNicknameDuplicate = planet_err.ErrNicknameDuplicate
+	ErrVersionConflict   = planet_err.ErrVersionConflict
 )
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_utils/models"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -14,21 +14,30 @@ type FollowsRepository struct {
 	DB *gorm.DB
 }
 
+func NewFollowsRepository(db *gorm.DB) *FollowsRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &FollowsRepository{
+		DB: db,
+	}
+}
+
 func (r *FollowsRepository) BeginTx(ctx context.Context) (*gorm.DB, error) {
-	logger.Infof("starting transaction for FollowsRepository")
+	logctx.Infof(ctx, "starting transaction for FollowsRepository")
 	tx := r.DB.WithContext(ctx).Begin()
 	if tx.Error != nil {
-		logger.Errorf("failed to start transaction: %v", tx.Error)
+		logctx.Errorf(ctx, "failed to start transaction: %v", tx.Error)
 		return nil, tx.Error
 	}
-	logger.Infof("transaction started successfully")
+	logctx.Infof(ctx, "transaction started successfully")
 	return tx, nil
 }
 
 // 팔로우 관계 존재 여부 확인
 func (r *FollowsRepository) IsFollow(ctx context.Context, followerUUID, followeeUUID uuid.UUID) (bool, error) {
-	logger.Infof("start to check %s follow %s", followerUUID, followeeUUID)
-	defer logger.Infof("end to check %s follow %s", followerUUID, followeeUUID)
+	logctx.Infof(ctx, "start to check %s follow %s", followerUUID, followeeUUID)
+	defer logctx.Infof(ctx, "end to check %s follow %s", followerUUID, followeeUUID)
 
 	var count int64
 	err := r.DB.WithContext(ctx).
@@ -42,10 +51,52 @@ func (r *FollowsRepository) IsFollow(ctx context.Context, followerUUID, followee
 	return count > 0, nil
 }
 
+// FindFollowedAmong: followeeUUIDs 중 followerUUID 가 팔로우 중인 사용자만 반환합니다. (DataLoader 용)
+func (r *FollowsRepository) FindFollowedAmong(ctx context.Context, followerUUID uuid.UUID, followeeUUIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(followeeUUIDs) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	var followed []uuid.UUID
+	err := r.DB.WithContext(ctx).
+		Model(&models.Follows{}).
+		Where("follower_uuid = ? AND followee_uuid IN ?", followerUUID, followeeUUIDs).
+		Pluck("followee_uuid", &followed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find follow state: %w", err)
+	}
+
+	return followed, nil
+}
+
+// FindFolloweeIDs: userUUID 가 팔로우 중인 사용자 목록
+func (r *FollowsRepository) FindFolloweeIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error) {
+	var followees []uuid.UUID
+	if err := r.DB.WithContext(ctx).
+		Model(&models.Follows{}).
+		Where("follower_uuid = ?", userUUID).
+		Pluck("followee_uuid", &followees).Error; err != nil {
+		return nil, fmt.Errorf("failed to find followees: %w", err)
+	}
+	return followees, nil
+}
+
+// FindFollowerIDs: userUUID 를 팔로우하는 사용자 목록
+func (r *FollowsRepository) FindFollowerIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error) {
+	var followers []uuid.UUID
+	if err := r.DB.WithContext(ctx).
+		Model(&models.Follows{}).
+		Where("followee_uuid = ?", userUUID).
+		Pluck("follower_uuid", &followers).Error; err != nil {
+		return nil, fmt.Errorf("failed to find followers: %w", err)
+	}
+	return followers, nil
+}
+
 // 팔로우 생성 (트랜잭션 지원)
 func (r *FollowsRepository) FollowTx(ctx context.Context, tx *gorm.DB, followerID, followingID uuid.UUID) error {
-	logger.Infof("start %s follow %s", followerID, followingID)
-	defer logger.Infof("end %s follow %s", followerID, followingID)
+	logctx.Infof(ctx, "start %s follow %s", followerID, followingID)
+	defer logctx.Infof(ctx, "end %s follow %s", followerID, followingID)
 
 	follow := &models.Follows{
 		FollowerID:  followerID,
@@ -61,8 +112,8 @@ func (r *FollowsRepository) FollowTx(ctx context.Context, tx *gorm.DB, followerI
 
 // 팔로우 삭제 (트랜잭션 지원)
 func (r *FollowsRepository) UnfollowTx(ctx context.Context, tx *gorm.DB, followerUUID, followeeUUID uuid.UUID) error {
-	logger.Infof("start %s unfollow %s", followerUUID, followeeUUID)
-	defer logger.Infof("end %s unfollow %s", followerUUID, followeeUUID)
+	logctx.Infof(ctx, "start %s unfollow %s", followerUUID, followeeUUID)
+	defer logctx.Infof(ctx, "end %s unfollow %s", followerUUID, followeeUUID)
 
 	result := tx.WithContext(ctx).
 		Where("follower_uuid = ? AND followee_uuid = ?", followerUUID, followeeUUID).
@@ -78,3 +129,17 @@ func (r *FollowsRepository) UnfollowTx(ctx context.Context, tx *gorm.DB, followe
 
 	return nil
 }
+
+// 사용자의 모든 팔로우 관계 삭제 (탈퇴 시, 트랜잭션 지원)
+func (r *FollowsRepository) DeleteAllByUserTx(ctx context.Context, tx *gorm.DB, userUUID uuid.UUID) error {
+	logctx.Infof(ctx, "start delete all follows of %s", userUUID)
+	defer logctx.Infof(ctx, "end delete all follows of %s", userUUID)
+
+	if err := tx.WithContext(ctx).
+		Where("follower_uuid = ? OR followee_uuid = ?", userUUID, userUUID).
+		Delete(&models.Follows{}).Error; err != nil {
+		return fmt.Errorf("failed to delete follows: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeysRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeysRepository(db *gorm.DB) *IdempotencyKeysRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &IdempotencyKeysRepository{
+		db: db,
+	}
+}
+
+func (r *IdempotencyKeysRepository) getDB(ctx context.Context) *gorm.DB {
+	// tx 패키지를 사용하여 Context에서 트랜잭션을 추출합니다.
+	if tx := tx.GetTx(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx) // 기본 DB 연결 반환
+}
+
+// Reserve: (user_id, operation, key) 로 pending 레코드를 선점합니다.
+// 이미 같은 키가 있으면 false 를 반환합니다.
+func (r *IdempotencyKeysRepository) Reserve(
+	ctx context.Context,
+	record *models.IdempotencyKey,
+) (bool, error) {
+	db := r.getDB(ctx)
+
+	result := db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(record)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *IdempotencyKeysRepository) Find(
+	ctx context.Context,
+	userID uuid.UUID,
+	operation string,
+	key string,
+) (*models.IdempotencyKey, error) {
+	db := r.getDB(ctx)
+
+	var record models.IdempotencyKey
+	if err := db.
+		Where("user_id = ? AND operation = ? AND key = ?", userID, operation, key).
+		First(&record).Error; err != nil {
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Complete: 실행 결과(JSON)를 저장하고 completed 상태로 변경합니다.
+func (r *IdempotencyKeysRepository) Complete(
+	ctx context.Context,
+	id uuid.UUID,
+	response []byte,
+) error {
+	db := r.getDB(ctx)
+
+	return db.
+		Model(&models.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":   models.IdempotencyStatusCompleted,
+			"response": response,
+		}).Error
+}
+
+func (r *IdempotencyKeysRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	db := r.getDB(ctx)
+	return db.Where("id = ?", id).Delete(&models.IdempotencyKey{}).Error
+}
+
+// DeleteExpired: TTL 이 지난 키를 정리합니다.
+func (r *IdempotencyKeysRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	db := r.getDB(ctx)
+
+	result := db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", result.Error)
+	}
+
+	logctx.Infof(ctx, "[IdempotencyRepo] deleted %d expired keys", result.RowsAffected)
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"gorm.io/gorm"
+)
+
+// 서비스는 아래 인터페이스에만 의존합니다.
+// PostgreSQL 구현(*ProfileRepository 등)과 테스트용 in-memory 구현(repository/memory)이 같은 동작을 보장하며,
+// repository/repositorytest 의 contract test 가 두 구현을 함께 검증합니다.
+
+type ProfileRepositoryInterface interface {
+	// Create: user_id 가 이미 있으면 ErrAlreadyExists, 닉네임이 이미 있으면 ErrNicknameDuplicate
+	Create(ctx context.Context, profile *models.Profile) error
+	// UpdateProfile: ExpectedVersion 이 DB 와 다르면 ErrVersionConflict, 닉네임이 이미 있으면 ErrNicknameDuplicate
+	UpdateProfile(ctx context.Context, profile *dto.ProfileUpdate) error
+	IsMyProfile(ctx context.Context, userID uuid.UUID) (bool, error)
+	ExistsByNickname(ctx context.Context, nickname string) (bool, error)
+	// GetMyProfileInfo / GetUserProfileInfo: 없으면 gorm.ErrRecordNotFound (planet_err.IsNotFound 로 확인)
+	GetMyProfileInfo(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
+	GetUserProfileInfo(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
+	// FindByUserIDs / FindByNicknames: 없는 사용자는 결과에서 제외 (순서 보장 없음)
+	FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.Profile, error)
+	FindByNicknames(ctx context.Context, nicknames []string) ([]*models.Profile, error)
+	DecrementFollowCountsOfUser(ctx context.Context, userID uuid.UUID) error
+	RecountFollowCounts(ctx context.Context) (int64, error)
+	// DeleteByUserID: 없으면 ErrNotFound
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type CalendarEventsRepositoryInterface interface {
+	// CreateCalendarEvent: event.Todos 도 함께 저장합니다.
+	CreateCalendarEvent(ctx context.Context, event *models.CalendarEvent) (*models.CalendarEvent, error)
+	// FindByID: 없으면 (nil, nil)
+	FindByID(ctx context.Context, eventID uuid.UUID) (*models.CalendarEvent, error)
+	// GetEventWithTodosByID: 없으면 gorm.ErrRecordNotFound 를 감싼 에러
+	GetEventWithTodosByID(ctx context.Context, eventID uuid.UUID) (*models.CalendarEvent, error)
+	// Update: Todo 는 전체 교체. expectedVersion 이 DB 와 다르면 ErrVersionConflict
+	Update(ctx context.Context, event *models.CalendarEvent, expectedVersion int32) error
+	// DeleteCalendarEvent / DeleteByUserID: 일정의 Todo 도 함께 삭제합니다.
+	DeleteCalendarEvent(ctx context.Context, eventID uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+	// [startAt, endAt) 와 겹치는 일정을 start_at 순으로 조회합니다. visibilities 가 비어 있으면 빈 결과
+	FindEventsWithoutTodosByVisibility(ctx context.Context, userID uuid.UUID, visibilities []string, startAt, endAt time.Time) ([]*models.CalendarEvent, error)
+	FindCalendarsWithTodos(ctx context.Context, userID uuid.UUID, visibilities []string, startAt, endAt time.Time) ([]*models.CalendarEvent, error)
+	FindAllWithTodosByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CalendarEvent, error)
+}
+
+type TodosRepositoryInterface interface {
+	CreateTodos(ctx context.Context, todos []models.Todo) error
+	// UpdateTodoStatus: 다른 사용자의 Todo 이거나 없으면 에러,
+	// version 이 맞지 않으면 서버 최신본과 ErrVersionConflict 를 함께 반환합니다.
+	UpdateTodoStatus(ctx context.Context, userID uuid.UUID, todoID uuid.UUID, isDone bool, expectedVersion *int32) (*models.Todo, error)
+	// FindByID: 없으면 (nil, nil)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Todo, error)
+	// FindByEventIDs: created_at 순
+	FindByEventIDs(ctx context.Context, eventIDs []uuid.UUID) ([]models.Todo, error)
+}
+
+type CalendarEventTemplatesRepositoryInterface interface {
+	Create(ctx context.Context, template *models.CalendarEventTemplate) (*models.CalendarEventTemplate, error)
+	// FindByID: 없으면 (nil, nil). Todos 는 position 순
+	FindByID(ctx context.Context, templateID uuid.UUID) (*models.CalendarEventTemplate, error)
+	// FindByUserID: name 순
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CalendarEventTemplate, error)
+	// Update: Todo 는 전체 교체
+	Update(ctx context.Context, template *models.CalendarEventTemplate) error
+	Delete(ctx context.Context, templateID uuid.UUID) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// FollowsRepositoryInterface: *Tx 메서드는 호출자가 넘긴 트랜잭션에서 실행됩니다. (in-memory 구현은 tx 를 무시)
+type FollowsRepositoryInterface interface {
+	IsFollow(ctx context.Context, followerUUID, followeeUUID uuid.UUID) (bool, error)
+	FindFollowedAmong(ctx context.Context, followerUUID uuid.UUID, followeeUUIDs []uuid.UUID) ([]uuid.UUID, error)
+	FindFolloweeIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error)
+	FindFollowerIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error)
+	FollowTx(ctx context.Context, tx *gorm.DB, followerID, followingID uuid.UUID) error
+	UnfollowTx(ctx context.Context, tx *gorm.DB, followerUUID, followeeUUID uuid.UUID) error
+	DeleteAllByUserTx(ctx context.Context, tx *gorm.DB, userUUID uuid.UUID) error
+}
+
+type IdempotencyKeysRepositoryInterface interface {
+	// Reserve: 같은 (user_id, operation, key) 가 이미 있으면 false
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error)
+	// Find: 없으면 (nil, nil)
+	Find(ctx context.Context, userID uuid.UUID, operation string, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, id uuid.UUID, response []byte) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type OutboxEventsRepositoryInterface interface {
+	Append(ctx context.Context, event *models.OutboxEvent) error
+	// ClaimPending: aggregate 별로 가장 앞선 pending 이벤트만 sequence 순으로 골라 now+lease 까지 선점합니다.
+	ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error)
+	// MarkPublished / MarkRetry 는 선점을 해제합니다.
+	MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error
+	MarkRetry(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time, failed bool) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PersistedQueriesRepositoryInterface interface {
+	// Find: 없으면 (nil, nil)
+	Find(ctx context.Context, hash string) (*models.PersistedQuery, error)
+	// Save: 같은 hash 가 이미 있으면 무시
+	Save(ctx context.Context, record *models.PersistedQuery) error
+}
+
+var (
+	_ ProfileRepositoryInterface                = (*ProfileRepository)(nil)
+	_ CalendarEventsRepositoryInterface         = (*CalendarEventsRepository)(nil)
+	_ TodosRepositoryInterface                  = (*TodosRepository)(nil)
+	_ CalendarEventTemplatesRepositoryInterface = (*CalendarEventTemplatesRepository)(nil)
+	_ FollowsRepositoryInterface                = (*FollowsRepository)(nil)
+	_ IdempotencyKeysRepositoryInterface        = (*IdempotencyKeysRepository)(nil)
+	_ OutboxEventsRepositoryInterface           = (*OutboxEventsRepository)(nil)
+	_ PersistedQueriesRepositoryInterface       = (*PersistedQueriesRepository)(nil)
+)
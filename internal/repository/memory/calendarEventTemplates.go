@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+type CalendarEventTemplatesRepository struct {
+	store *Store
+}
+
+var _ repository.CalendarEventTemplatesRepositoryInterface = (*CalendarEventTemplatesRepository)(nil)
+
+func NewCalendarEventTemplatesRepository(store *Store) *CalendarEventTemplatesRepository {
+	return &CalendarEventTemplatesRepository{store: store}
+}
+
+func (r *CalendarEventTemplatesRepository) Create(ctx context.Context, template *models.CalendarEventTemplate) (*models.CalendarEventTemplate, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.templateIndex(template.ID) >= 0 {
+		return nil, fmt.Errorf("failed to insert event template: %w", errDuplicateKey("calendar_event_templates", "id", template.ID))
+	}
+
+	now := time.Now()
+	if template.DurationMinutes == 0 {
+		template.DurationMinutes = 60
+	}
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = now
+	}
+	if template.UpdatedAt.IsZero() {
+		template.UpdatedAt = now
+	}
+
+	stored := *template
+	stored.Todos = nil
+	s.templates = append(s.templates, &stored)
+	for i := range template.Todos {
+		template.Todos[i].TemplateID = template.ID
+		s.insertTemplateTodo(&template.Todos[i], now)
+	}
+	return template, nil
+}
+
+func (r *CalendarEventTemplatesRepository) FindByID(ctx context.Context, templateID uuid.UUID) (*models.CalendarEventTemplate, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.templateIndex(templateID)
+	if i < 0 {
+		return nil, nil
+	}
+	return s.templateWithTodos(s.templates[i]), nil
+}
+
+func (r *CalendarEventTemplatesRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CalendarEventTemplate, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	templates := make([]*models.CalendarEventTemplate, 0)
+	for _, t := range s.templates {
+		if t.UserID == userID {
+			templates = append(templates, s.templateWithTodos(t))
+		}
+	}
+	slices.SortStableFunc(templates, func(a, b *models.CalendarEventTemplate) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return templates, nil
+}
+
+// Update: GORM Save 처럼 없으면 새로 저장합니다. Todo 는 전체 교체
+func (r *CalendarEventTemplatesRepository) Update(ctx context.Context, template *models.CalendarEventTemplate) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	template.UpdatedAt = now
+	stored := *template
+	stored.Todos = nil
+	if i := s.templateIndex(template.ID); i >= 0 {
+		s.templates[i] = &stored
+	} else {
+		s.templates = append(s.templates, &stored)
+	}
+
+	s.templateTodos = slices.DeleteFunc(s.templateTodos, func(t *models.CalendarEventTemplateTodo) bool {
+		return t.TemplateID == template.ID
+	})
+	for i := range template.Todos {
+		s.insertTemplateTodo(&template.Todos[i], now)
+	}
+	return nil
+}
+
+func (r *CalendarEventTemplatesRepository) Delete(ctx context.Context, templateID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteTemplatesWhere(func(t *models.CalendarEventTemplate) bool { return t.ID == templateID })
+	return nil
+}
+
+func (r *CalendarEventTemplatesRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteTemplatesWhere(func(t *models.CalendarEventTemplate) bool { return t.UserID == userID })
+	return nil
+}
+
+// 아래 helper 는 모두 s.mu 를 잡은 상태에서 호출합니다.
+
+func (s *Store) templateIndex(id uuid.UUID) int {
+	return slices.IndexFunc(s.templates, func(t *models.CalendarEventTemplate) bool { return t.ID == id })
+}
+
+func (s *Store) insertTemplateTodo(todo *models.CalendarEventTemplateTodo, now time.Time) {
+	if todo.CreatedAt.IsZero() {
+		todo.CreatedAt = now
+	}
+	stored := *todo
+	s.templateTodos = append(s.templateTodos, &stored)
+}
+
+// templateWithTodos: Todos 는 position 순
+func (s *Store) templateWithTodos(t *models.CalendarEventTemplate) *models.CalendarEventTemplate {
+	found := *t
+	found.Todos = make([]models.CalendarEventTemplateTodo, 0)
+	for _, todo := range s.templateTodos {
+		if todo.TemplateID == t.ID {
+			found.Todos = append(found.Todos, *todo)
+		}
+	}
+	slices.SortStableFunc(found.Todos, func(a, b models.CalendarEventTemplateTodo) int {
+		return int(a.Position) - int(b.Position)
+	})
+	return &found
+}
+
+// deleteTemplatesWhere: 템플릿 Todo 까지 함께 삭제합니다. (ON DELETE CASCADE)
+func (s *Store) deleteTemplatesWhere(match func(*models.CalendarEventTemplate) bool) {
+	deletedIDs := make(map[uuid.UUID]struct{})
+	s.templates = slices.DeleteFunc(s.templates, func(t *models.CalendarEventTemplate) bool {
+		if match(t) {
+			deletedIDs[t.ID] = struct{}{}
+			return true
+		}
+		return false
+	})
+	s.templateTodos = slices.DeleteFunc(s.templateTodos, func(t *models.CalendarEventTemplateTodo) bool {
+		_, ok := deletedIDs[t.TemplateID]
+		return ok
+	})
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type CalendarEventsRepository struct {
+	store *Store
+}
+
+var _ repository.CalendarEventsRepositoryInterface = (*CalendarEventsRepository)(nil)
+
+func NewCalendarEventsRepository(store *Store) *CalendarEventsRepository {
+	return &CalendarEventsRepository{store: store}
+}
+
+// CreateCalendarEvent: GORM association 저장처럼 Todo 의 CalendarEventID 를 채워 함께 저장합니다.
+func (r *CalendarEventsRepository) CreateCalendarEvent(ctx context.Context, event *models.CalendarEvent) (*models.CalendarEvent, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.eventByID(event.ID) != nil {
+		return nil, fmt.Errorf("failed to insert calendar event: %w", errDuplicateKey("calendar_events", "id", event.ID))
+	}
+	for _, todo := range event.Todos {
+		if s.todoByID(todo.ID) != nil {
+			return nil, fmt.Errorf("failed to insert calendar event: %w", errDuplicateKey("todos", "id", todo.ID))
+		}
+	}
+
+	now := time.Now()
+	if event.Version == 0 {
+		event.Version = 1
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = now
+	}
+	if event.UpdatedAt.IsZero() {
+		event.UpdatedAt = now
+	}
+
+	stored := *event
+	stored.Todos = nil
+	s.events = append(s.events, &stored)
+
+	for i := range event.Todos {
+		event.Todos[i].CalendarEventID = event.ID
+		if err := s.insertTodo(&event.Todos[i], now); err != nil {
+			return nil, fmt.Errorf("failed to insert calendar event: %w", err)
+		}
+	}
+	return event, nil
+}
+
+func (r *CalendarEventsRepository) FindByID(ctx context.Context, eventID uuid.UUID) (*models.CalendarEvent, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.eventByID(eventID)
+	if e == nil {
+		return nil, nil
+	}
+	return s.withTodos(e), nil
+}
+
+func (r *CalendarEventsRepository) GetEventWithTodosByID(ctx context.Context, eventID uuid.UUID) (*models.CalendarEvent, error) {
+	event, err := r.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("failed to query event by ID: %w", gorm.ErrRecordNotFound)
+	}
+	return event, nil
+}
+
+// Update: user_id / created_at 은 그대로 두고, Todo 는 event.Todos 로 전체 교체합니다.
+func (r *CalendarEventsRepository) Update(ctx context.Context, event *models.CalendarEvent, expectedVersion int32) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.eventByID(event.ID)
+	if e == nil || e.Version != expectedVersion {
+		return repository.ErrVersionConflict
+	}
+	// 검증을 먼저 끝내 실패 시 아무것도 바뀌지 않게 합니다. (PostgreSQL 에서는 트랜잭션 롤백)
+	for _, todo := range event.Todos {
+		if s.eventByID(todo.CalendarEventID) == nil {
+			return errForeignKey("todos", "calendar_event_id", todo.CalendarEventID)
+		}
+		if t := s.todoByID(todo.ID); t != nil && t.CalendarEventID != event.ID {
+			return errDuplicateKey("todos", "id", todo.ID)
+		}
+	}
+
+	e.Title = event.Title
+	e.Emoji = event.Emoji
+	e.Description = event.Description
+	e.StartAt = event.StartAt
+	e.EndAt = event.EndAt
+	e.Visibility = event.Visibility
+	e.UpdatedAt = event.UpdatedAt
+	e.Version++
+
+	now := time.Now()
+	s.deleteTodosWhere(func(t *models.Todo) bool { return t.CalendarEventID == event.ID })
+	for i := range event.Todos {
+		if err := s.insertTodo(&event.Todos[i], now); err != nil {
+			return err
+		}
+	}
+
+	event.Version = expectedVersion + 1
+	return nil
+}
+
+func (r *CalendarEventsRepository) DeleteCalendarEvent(ctx context.Context, eventID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteEventsWhere(func(e *models.CalendarEvent) bool { return e.ID == eventID })
+	return nil
+}
+
+func (r *CalendarEventsRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteEventsWhere(func(e *models.CalendarEvent) bool { return e.UserID == userID })
+	return nil
+}
+
+func (r *CalendarEventsRepository) FindEventsWithoutTodosByVisibility(
+	ctx context.Context,
+	userID uuid.UUID,
+	visibilities []string,
+	startAt, endAt time.Time,
+) ([]*models.CalendarEvent, error) {
+	return r.findInRange(userID, visibilities, startAt, endAt, false), nil
+}
+
+func (r *CalendarEventsRepository) FindCalendarsWithTodos(
+	ctx context.Context,
+	userID uuid.UUID,
+	visibilities []string,
+	startAt, endAt time.Time,
+) ([]*models.CalendarEvent, error) {
+	return r.findInRange(userID, visibilities, startAt, endAt, true), nil
+}
+
+func (r *CalendarEventsRepository) FindAllWithTodosByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CalendarEvent, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedEvents(func(e *models.CalendarEvent) bool { return e.UserID == userID }, true), nil
+}
+
+// findInRange: start_at < endAt AND end_at >= startAt (PostgreSQL 구현과 같은 겹침 조건)
+func (r *CalendarEventsRepository) findInRange(
+	userID uuid.UUID,
+	visibilities []string,
+	startAt, endAt time.Time,
+	preloadTodos bool,
+) []*models.CalendarEvent {
+	if len(visibilities) == 0 {
+		return []*models.CalendarEvent{}
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sortedEvents(func(e *models.CalendarEvent) bool {
+		return e.UserID == userID &&
+			slices.Contains(visibilities, e.Visibility) &&
+			e.StartAt.Before(endAt) &&
+			!e.EndAt.Before(startAt)
+	}, preloadTodos)
+}
+
+// sortedEvents: 조건에 맞는 일정 복사본을 start_at 순으로 반환합니다. (s.mu 를 잡은 상태에서 호출)
+func (s *Store) sortedEvents(match func(*models.CalendarEvent) bool, preloadTodos bool) []*models.CalendarEvent {
+	events := make([]*models.CalendarEvent, 0)
+	for _, e := range s.events {
+		if !match(e) {
+			continue
+		}
+		if preloadTodos {
+			events = append(events, s.withTodos(e))
+			continue
+		}
+		found := *e
+		events = append(events, &found)
+	}
+	slices.SortStableFunc(events, func(a, b *models.CalendarEvent) int {
+		return a.StartAt.Compare(b.StartAt)
+	})
+	return events
+}
+
+func (s *Store) withTodos(e *models.CalendarEvent) *models.CalendarEvent {
+	found := *e
+	found.Todos = s.todosOf(e.ID)
+	return &found
+}
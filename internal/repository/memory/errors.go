@@ -0,0 +1,17 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// PostgreSQL 에서 드라이버 에러로 올라오는 제약 위반. repository 에러로 번역되지 않는 경우에만 사용합니다.
+
+func errForeignKey(table, column string, value uuid.UUID) error {
+	return fmt.Errorf("memory: insert into %s violates foreign key %s=%s", table, column, value)
+}
+
+func errDuplicateKey(table, column string, value any) error {
+	return fmt.Errorf("memory: duplicate key %s=%v in %s", column, value, table)
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+// FollowsRepository: Store.AddFollow 와 같은 follows 테이블을 사용합니다. *Tx 메서드의 tx 는 무시합니다.
+type FollowsRepository struct {
+	store *Store
+}
+
+var _ repository.FollowsRepositoryInterface = (*FollowsRepository)(nil)
+
+func NewFollowsRepository(store *Store) *FollowsRepository {
+	return &FollowsRepository{store: store}
+}
+
+func (r *FollowsRepository) IsFollow(ctx context.Context, followerUUID, followeeUUID uuid.UUID) (bool, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.followIndex(followerUUID, followeeUUID) >= 0, nil
+}
+
+func (r *FollowsRepository) FindFollowedAmong(ctx context.Context, followerUUID uuid.UUID, followeeUUIDs []uuid.UUID) ([]uuid.UUID, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	followed := make([]uuid.UUID, 0)
+	for _, f := range s.follows {
+		if f.followerID == followerUUID && slices.Contains(followeeUUIDs, f.followeeID) {
+			followed = append(followed, f.followeeID)
+		}
+	}
+	return followed, nil
+}
+
+func (r *FollowsRepository) FindFolloweeIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	followees := make([]uuid.UUID, 0)
+	for _, f := range s.follows {
+		if f.followerID == userUUID {
+			followees = append(followees, f.followeeID)
+		}
+	}
+	return followees, nil
+}
+
+func (r *FollowsRepository) FindFollowerIDs(ctx context.Context, userUUID uuid.UUID) ([]uuid.UUID, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	followers := make([]uuid.UUID, 0)
+	for _, f := range s.follows {
+		if f.followeeID == userUUID {
+			followers = append(followers, f.followerID)
+		}
+	}
+	return followers, nil
+}
+
+func (r *FollowsRepository) FollowTx(ctx context.Context, _ *gorm.DB, followerID, followingID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.followIndex(followerID, followingID) >= 0 {
+		return fmt.Errorf("failed to insert follow: %w", errDuplicateKey("follows", "follower_uuid, followee_uuid", followerID.String()+", "+followingID.String()))
+	}
+	s.follows = append(s.follows, follow{followerID: followerID, followeeID: followingID})
+	return nil
+}
+
+func (r *FollowsRepository) UnfollowTx(ctx context.Context, _ *gorm.DB, followerUUID, followeeUUID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.followIndex(followerUUID, followeeUUID)
+	if i < 0 {
+		return fmt.Errorf("no follow relation found to delete")
+	}
+	s.follows = slices.Delete(s.follows, i, i+1)
+	return nil
+}
+
+func (r *FollowsRepository) DeleteAllByUserTx(ctx context.Context, _ *gorm.DB, userUUID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.follows = slices.DeleteFunc(s.follows, func(f follow) bool {
+		return f.followerID == userUUID || f.followeeID == userUUID
+	})
+	return nil
+}
+
+// followIndex: 없으면 -1 (s.mu 를 잡은 상태에서 호출)
+func (s *Store) followIndex(followerID, followeeID uuid.UUID) int {
+	return slices.IndexFunc(s.follows, func(f follow) bool {
+		return f.followerID == followerID && f.followeeID == followeeID
+	})
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+type IdempotencyKeysRepository struct {
+	store *Store
+}
+
+var _ repository.IdempotencyKeysRepositoryInterface = (*IdempotencyKeysRepository)(nil)
+
+func NewIdempotencyKeysRepository(store *Store) *IdempotencyKeysRepository {
+	return &IdempotencyKeysRepository{store: store}
+}
+
+func (r *IdempotencyKeysRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ON CONFLICT DO NOTHING: id 또는 (user_id, operation, key) 중복
+	for _, k := range s.idempotencyKeys {
+		if k.ID == record.ID || (k.UserID == record.UserID && k.Operation == record.Operation && k.Key == record.Key) {
+			return false, nil
+		}
+	}
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	stored := *record
+	s.idempotencyKeys = append(s.idempotencyKeys, &stored)
+	return true, nil
+}
+
+func (r *IdempotencyKeysRepository) Find(ctx context.Context, userID uuid.UUID, operation string, key string) (*models.IdempotencyKey, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.idempotencyKeys {
+		if k.UserID == userID && k.Operation == operation && k.Key == key {
+			found := *k
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *IdempotencyKeysRepository) Complete(ctx context.Context, id uuid.UUID, response []byte) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.idempotencyKeys {
+		if k.ID == id {
+			k.Status = models.IdempotencyStatusCompleted
+			k.Response = slices.Clone(response)
+		}
+	}
+	return nil
+}
+
+func (r *IdempotencyKeysRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.idempotencyKeys = slices.DeleteFunc(s.idempotencyKeys, func(k *models.IdempotencyKey) bool { return k.ID == id })
+	return nil
+}
+
+func (r *IdempotencyKeysRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.idempotencyKeys)
+	s.idempotencyKeys = slices.DeleteFunc(s.idempotencyKeys, func(k *models.IdempotencyKey) bool {
+		return k.ExpiresAt.Before(now)
+	})
+	return int64(before - len(s.idempotencyKeys)), nil
+}
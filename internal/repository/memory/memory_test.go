@@ -0,0 +1,28 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/repositorytest"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repos {
+		store := memory.NewStore()
+		return repositorytest.Repos{
+			Profiles:         memory.NewProfileRepository(store),
+			Events:           memory.NewCalendarEventsRepository(store),
+			Todos:            memory.NewTodosRepository(store),
+			Follows:          memory.NewFollowsRepository(store),
+			Templates:        memory.NewCalendarEventTemplatesRepository(store),
+			IdempotencyKeys:  memory.NewIdempotencyKeysRepository(store),
+			Outbox:           memory.NewOutboxEventsRepository(store),
+			PersistedQueries: memory.NewPersistedQueriesRepository(store),
+			Follow: func(t *testing.T, followerID, followeeID uuid.UUID) {
+				store.AddFollow(followerID, followeeID)
+			},
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+// OutboxEventsRepository: locked_until 선점은 흉내 내지만 store 락 하나로 직렬화되므로 SKIP LOCKED 와 달리 동시성은 없습니다.
+type OutboxEventsRepository struct {
+	store *Store
+}
+
+var _ repository.OutboxEventsRepositoryInterface = (*OutboxEventsRepository)(nil)
+
+func NewOutboxEventsRepository(store *Store) *OutboxEventsRepository {
+	return &OutboxEventsRepository{store: store}
+}
+
+func (r *OutboxEventsRepository) Append(ctx context.Context, event *models.OutboxEvent) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if slices.ContainsFunc(s.outboxEvents, func(e *models.OutboxEvent) bool { return e.ID == event.ID }) {
+		return fmt.Errorf("failed to append outbox event: %w", errDuplicateKey("outbox_events", "id", event.ID))
+	}
+
+	s.outboxSequence++
+	event.Sequence = s.outboxSequence
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	stored := *event
+	s.outboxEvents = append(s.outboxEvents, &stored)
+	return nil
+}
+
+func (r *OutboxEventsRepository) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// outboxEvents 는 sequence 순으로 쌓이므로 aggregate 별 첫 pending 이벤트만 고르면 됩니다.
+	type aggregate struct {
+		typ string
+		id  uuid.UUID
+	}
+	seen := make(map[aggregate]struct{})
+	lockedUntil := now.Add(lease)
+	events := make([]*models.OutboxEvent, 0)
+	for _, e := range s.outboxEvents {
+		if e.Status != models.OutboxStatusPending {
+			continue
+		}
+		key := aggregate{typ: e.AggregateType, id: e.AggregateID}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if e.AvailableAt.After(now) || (e.LockedUntil != nil && e.LockedUntil.After(now)) {
+			continue
+		}
+		e.LockedUntil = &lockedUntil
+		found := *e
+		events = append(events, &found)
+		if len(events) == limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+func (r *OutboxEventsRepository) MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	return r.update(id, func(e *models.OutboxEvent) {
+		e.Status = models.OutboxStatusPublished
+		e.Attempts++
+		e.LastError = ""
+		e.PublishedAt = &publishedAt
+		e.LockedUntil = nil
+	})
+}
+
+func (r *OutboxEventsRepository) MarkRetry(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time, failed bool) error {
+	return r.update(id, func(e *models.OutboxEvent) {
+		e.Status = models.OutboxStatusPending
+		if failed {
+			e.Status = models.OutboxStatusFailed
+		}
+		e.Attempts++
+		e.LastError = lastError
+		e.AvailableAt = nextAttemptAt
+		e.LockedUntil = nil
+	})
+}
+
+func (r *OutboxEventsRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.outboxEvents)
+	s.outboxEvents = slices.DeleteFunc(s.outboxEvents, func(e *models.OutboxEvent) bool {
+		return e.Status == models.OutboxStatusPublished && e.PublishedAt != nil && e.PublishedAt.Before(before)
+	})
+	return int64(n - len(s.outboxEvents)), nil
+}
+
+func (r *OutboxEventsRepository) update(id uuid.UUID, apply func(*models.OutboxEvent)) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.outboxEvents {
+		if e.ID == id {
+			apply(e)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+type PersistedQueriesRepository struct {
+	store *Store
+}
+
+var _ repository.PersistedQueriesRepositoryInterface = (*PersistedQueriesRepository)(nil)
+
+func NewPersistedQueriesRepository(store *Store) *PersistedQueriesRepository {
+	return &PersistedQueriesRepository{store: store}
+}
+
+func (r *PersistedQueriesRepository) Find(ctx context.Context, hash string) (*models.PersistedQuery, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.persistedQueries[hash]
+	if !ok {
+		return nil, nil
+	}
+	found := *q
+	return &found, nil
+}
+
+func (r *PersistedQueriesRepository) Save(ctx context.Context, record *models.PersistedQuery) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.persistedQueries[record.Hash]; ok {
+		return nil
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	stored := *record
+	s.persistedQueries[record.Hash] = &stored
+	return nil
+}
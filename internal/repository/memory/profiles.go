@@ -0,0 +1,231 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ProfileRepository struct {
+	store *Store
+}
+
+var _ repository.ProfileRepositoryInterface = (*ProfileRepository)(nil)
+
+func NewProfileRepository(store *Store) *ProfileRepository {
+	return &ProfileRepository{store: store}
+}
+
+func (r *ProfileRepository) Create(ctx context.Context, profile *models.Profile) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.profiles {
+		if p.UserID == profile.UserID {
+			return repository.ErrAlreadyExists
+		}
+		if p.Nickname == profile.Nickname {
+			return repository.ErrNicknameDuplicate
+		}
+	}
+
+	// DB / GORM 기본값
+	now := time.Now()
+	if profile.ID == "" {
+		profile.ID = uuid.NewString()
+	}
+	if profile.Theme == "" {
+		profile.Theme = "light"
+	}
+	if profile.Version == 0 {
+		profile.Version = 1
+	}
+	if profile.CreatedAt.IsZero() {
+		profile.CreatedAt = now
+	}
+	if profile.UpdatedAt.IsZero() {
+		profile.UpdatedAt = now
+	}
+
+	stored := *profile
+	s.profiles = append(s.profiles, &stored)
+	return nil
+}
+
+func (r *ProfileRepository) UpdateProfile(ctx context.Context, update *dto.ProfileUpdate) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.profileByUserID(update.UserID)
+	if update.ExpectedVersion != nil && (p == nil || p.Version != *update.ExpectedVersion) {
+		return repository.ErrVersionConflict
+	}
+	// PostgreSQL 구현처럼 ExpectedVersion 이 없으면 대상이 없어도 에러가 아닙니다.
+	if p == nil {
+		return nil
+	}
+
+	if update.Nickname != nil {
+		for _, other := range s.profiles {
+			if other != p && other.Nickname == *update.Nickname {
+				return repository.ErrNicknameDuplicate
+			}
+		}
+		p.Nickname = *update.Nickname
+	}
+	if update.Bio != nil {
+		p.Bio = *update.Bio
+	}
+	if update.ProfileImage != nil {
+		p.ProfileImage = *update.ProfileImage
+	}
+	if update.Theme != nil {
+		p.Theme = *update.Theme
+	}
+	// user_id 는 항상 update map 에 포함되므로 변경 필드가 없어도 version 이 올라갑니다.
+	p.Version++
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *ProfileRepository) IsMyProfile(ctx context.Context, userID uuid.UUID) (bool, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.profileByUserID(userID) != nil, nil
+}
+
+func (r *ProfileRepository) ExistsByNickname(ctx context.Context, nickname string) (bool, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.profiles {
+		if p.Nickname == nickname {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ProfileRepository) GetMyProfileInfo(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.profileByUserID(userID)
+	if p == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *p
+	return &found, nil
+}
+
+func (r *ProfileRepository) GetUserProfileInfo(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	return r.GetMyProfileInfo(ctx, userID)
+}
+
+func (r *ProfileRepository) FindByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.Profile, error) {
+	wanted := make(map[uuid.UUID]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = struct{}{}
+	}
+	return r.findWhere(func(p *models.Profile) bool {
+		_, ok := wanted[p.UserID]
+		return ok
+	}), nil
+}
+
+func (r *ProfileRepository) FindByNicknames(ctx context.Context, nicknames []string) ([]*models.Profile, error) {
+	wanted := make(map[string]struct{}, len(nicknames))
+	for _, n := range nicknames {
+		wanted[n] = struct{}{}
+	}
+	return r.findWhere(func(p *models.Profile) bool {
+		_, ok := wanted[p.Nickname]
+		return ok
+	}), nil
+}
+
+func (r *ProfileRepository) findWhere(match func(*models.Profile) bool) []*models.Profile {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles := make([]*models.Profile, 0)
+	for _, p := range s.profiles {
+		if match(p) {
+			found := *p
+			profiles = append(profiles, &found)
+		}
+	}
+	return profiles
+}
+
+func (r *ProfileRepository) DecrementFollowCountsOfUser(ctx context.Context, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range s.follows {
+		// 내가 팔로우하던 사용자 → follower_count 감소
+		if f.followerID == userID {
+			if p := s.profileByUserID(f.followeeID); p != nil {
+				p.FollowerCount = max(p.FollowerCount-1, 0)
+			}
+		}
+		// 나를 팔로우하던 사용자 → following_count 감소
+		if f.followeeID == userID {
+			if p := s.profileByUserID(f.followerID); p != nil {
+				p.FollowingCount = max(p.FollowingCount-1, 0)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *ProfileRepository) RecountFollowCounts(ctx context.Context) (int64, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	followers := make(map[uuid.UUID]int32)
+	followings := make(map[uuid.UUID]int32)
+	for _, f := range s.follows {
+		followers[f.followeeID]++
+		followings[f.followerID]++
+	}
+
+	var changed int64
+	for _, p := range s.profiles {
+		if p.FollowerCount == followers[p.UserID] && p.FollowingCount == followings[p.UserID] {
+			continue
+		}
+		p.FollowerCount = followers[p.UserID]
+		p.FollowingCount = followings[p.UserID]
+		changed++
+	}
+	return changed, nil
+}
+
+func (r *ProfileRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.profiles {
+		if p.UserID == userID {
+			s.profiles = append(s.profiles[:i], s.profiles[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
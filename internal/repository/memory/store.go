@@ -0,0 +1,150 @@
+// Package memory: repository 인터페이스의 in-memory 구현 (테스트용)
+//
+// PostgreSQL 구현과 같은 결과를 내도록 repository/repositorytest 의 contract test 로 검증합니다.
+// 트랜잭션은 흉내 내지 않으므로 tx.RunInTx 안에서 실패해도 이미 반영된 변경은 되돌아가지 않습니다.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
+
+// Store: 여러 repository 가 공유하는 테이블
+// 같은 Store 로 만든 repository 끼리는 DB 처럼 서로의 변경이 보입니다.
+type Store struct {
+	mu sync.Mutex
+
+	// 삽입 순서를 유지하기 위해 slice 로 보관합니다.
+	profiles      []*models.Profile
+	events        []*models.CalendarEvent // Todos 는 비워 두고 todos 에서 조립
+	todos         []*models.Todo
+	follows       []follow
+	templates     []*models.CalendarEventTemplate // Todos 는 비워 두고 templateTodos 에서 조립
+	templateTodos []*models.CalendarEventTemplateTodo
+
+	idempotencyKeys  []*models.IdempotencyKey
+	outboxEvents     []*models.OutboxEvent
+	outboxSequence   int64
+	persistedQueries map[string]*models.PersistedQuery
+}
+
+type follow struct {
+	followerID uuid.UUID
+	followeeID uuid.UUID
+}
+
+func NewStore() *Store {
+	return &Store{
+		persistedQueries: make(map[string]*models.PersistedQuery),
+	}
+}
+
+// AddFollow: follows 행을 추가합니다. 프로필의 follower / following 카운트는 바꾸지 않습니다.
+func (s *Store) AddFollow(followerID, followeeID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range s.follows {
+		if f.followerID == followerID && f.followeeID == followeeID {
+			return
+		}
+	}
+	s.follows = append(s.follows, follow{followerID: followerID, followeeID: followeeID})
+}
+
+// 아래 helper 는 모두 s.mu 를 잡은 상태에서 호출합니다.
+
+func (s *Store) profileByUserID(userID uuid.UUID) *models.Profile {
+	for _, p := range s.profiles {
+		if p.UserID == userID {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *Store) eventByID(id uuid.UUID) *models.CalendarEvent {
+	for _, e := range s.events {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *Store) todoByID(id uuid.UUID) *models.Todo {
+	for _, t := range s.todos {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// todosOf: 일정의 Todo 복사본 (삽입 순서)
+func (s *Store) todosOf(eventID uuid.UUID) []models.Todo {
+	todos := make([]models.Todo, 0)
+	for _, t := range s.todos {
+		if t.CalendarEventID == eventID {
+			todos = append(todos, *t)
+		}
+	}
+	return todos
+}
+
+// deleteTodosWhere / deleteEventsWhere: 조건에 맞는 행을 지우고 지운 수를 반환합니다.
+func (s *Store) deleteTodosWhere(match func(*models.Todo) bool) int {
+	kept := s.todos[:0]
+	for _, t := range s.todos {
+		if !match(t) {
+			kept = append(kept, t)
+		}
+	}
+	deleted := len(s.todos) - len(kept)
+	s.todos = kept
+	return deleted
+}
+
+// calendar_events 삭제는 todos 의 ON DELETE CASCADE 까지 반영합니다.
+func (s *Store) deleteEventsWhere(match func(*models.CalendarEvent) bool) int {
+	deletedIDs := make(map[uuid.UUID]struct{})
+	kept := s.events[:0]
+	for _, e := range s.events {
+		if match(e) {
+			deletedIDs[e.ID] = struct{}{}
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.events = kept
+	s.deleteTodosWhere(func(t *models.Todo) bool {
+		_, ok := deletedIDs[t.CalendarEventID]
+		return ok
+	})
+	return len(deletedIDs)
+}
+
+// insertTodo: GORM / DB 기본값을 채워 저장합니다. 없는 일정을 가리키면 FK 위반처럼 실패합니다.
+func (s *Store) insertTodo(todo *models.Todo, now time.Time) error {
+	if s.eventByID(todo.CalendarEventID) == nil {
+		return errForeignKey("todos", "calendar_event_id", todo.CalendarEventID)
+	}
+	if s.todoByID(todo.ID) != nil {
+		return errDuplicateKey("todos", "id", todo.ID)
+	}
+	if todo.Version == 0 {
+		todo.Version = 1
+	}
+	if todo.CreatedAt.IsZero() {
+		todo.CreatedAt = now
+	}
+	if todo.UpdatedAt.IsZero() {
+		todo.UpdatedAt = now
+	}
+	stored := *todo
+	s.todos = append(s.todos, &stored)
+	return nil
+}
@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+)
+
+type TodosRepository struct {
+	store *Store
+}
+
+var _ repository.TodosRepositoryInterface = (*TodosRepository)(nil)
+
+func NewTodosRepository(store *Store) *TodosRepository {
+	return &TodosRepository{store: store}
+}
+
+// CreateTodos: 하나라도 실패하면 아무것도 저장하지 않습니다. (PostgreSQL 의 단일 INSERT 와 동일)
+func (r *TodosRepository) CreateTodos(ctx context.Context, todos []models.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[uuid.UUID]struct{}, len(todos))
+	for _, todo := range todos {
+		if _, ok := seen[todo.ID]; ok || s.todoByID(todo.ID) != nil {
+			return fmt.Errorf("failed to create todos: %w", errDuplicateKey("todos", "id", todo.ID))
+		}
+		seen[todo.ID] = struct{}{}
+		if s.eventByID(todo.CalendarEventID) == nil {
+			return fmt.Errorf("failed to create todos: %w", errForeignKey("todos", "calendar_event_id", todo.CalendarEventID))
+		}
+	}
+
+	now := time.Now()
+	for i := range todos {
+		if err := s.insertTodo(&todos[i], now); err != nil {
+			return fmt.Errorf("failed to create todos: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *TodosRepository) UpdateTodoStatus(
+	ctx context.Context,
+	userID uuid.UUID,
+	todoID uuid.UUID,
+	isDone bool,
+	expectedVersion *int32,
+) (*models.Todo, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.todoByID(todoID)
+	if t == nil {
+		return nil, fmt.Errorf("unauthorized or todo not found")
+	}
+	if e := s.eventByID(t.CalendarEventID); e == nil || e.UserID != userID {
+		return nil, fmt.Errorf("unauthorized or todo not found")
+	}
+
+	if expectedVersion != nil && *expectedVersion != t.Version {
+		current := *t
+		return &current, repository.ErrVersionConflict
+	}
+
+	t.IsDone = isDone
+	t.UpdatedAt = time.Now()
+	t.Version++
+
+	updated := *t
+	return &updated, nil
+}
+
+func (r *TodosRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Todo, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.todoByID(id)
+	if t == nil {
+		return nil, nil
+	}
+	found := *t
+	return &found, nil
+}
+
+func (r *TodosRepository) FindByEventIDs(ctx context.Context, eventIDs []uuid.UUID) ([]models.Todo, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todos := make([]models.Todo, 0)
+	for _, t := range s.todos {
+		if slices.Contains(eventIDs, t.CalendarEventID) {
+			todos = append(todos, *t)
+		}
+	}
+	slices.SortStableFunc(todos, func(a, b models.Todo) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return todos, nil
+}
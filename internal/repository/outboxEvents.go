@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxEventsRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxEventsRepository(db *gorm.DB) *OutboxEventsRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &OutboxEventsRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxEventsRepository) getDB(ctx context.Context) *gorm.DB {
+	// tx 패키지를 사용하여 Context에서 트랜잭션을 추출합니다.
+	if tx := tx.GetTx(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx) // 기본 DB 연결 반환
+}
+
+// Append: 도메인 변경과 같은 트랜잭션(ctx)에서 outbox 이벤트를 기록합니다.
+func (r *OutboxEventsRepository) Append(ctx context.Context, event *models.OutboxEvent) error {
+	db := r.getDB(ctx)
+
+	if err := db.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimPending: 발행 가능한 pending 이벤트를 sequence 순으로 골라 now+lease 까지 선점합니다.
+// 같은 aggregate 에 더 앞선 pending 이벤트가 있으면 (선점된 것 포함) 제외하여 aggregate 별 순서를 보장합니다.
+// 고르는 동안만 SKIP LOCKED 로 행을 잠그고, 발행은 locked_until 로 막으므로 짧은 트랜잭션 ctx 에서 호출하세요.
+// 발행 결과를 기록하지 못한 채 lease 가 지나면 다른 relay 가 다시 가져갑니다. (at-least-once)
+func (r *OutboxEventsRepository) ClaimPending(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*models.OutboxEvent, error) {
+	db := r.getDB(ctx)
+
+	var events []*models.OutboxEvent
+	err := db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND available_at <= ?", models.OutboxStatusPending, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM outbox_events prev
+			WHERE prev.aggregate_type = outbox_events.aggregate_type
+			  AND prev.aggregate_id = outbox_events.aggregate_id
+			  AND prev.status = ?
+			  AND prev.sequence < outbox_events.sequence
+		)`, models.OutboxStatusPending).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending outbox events: %w", err)
+	}
+	if len(events) == 0 {
+		return events, nil
+	}
+
+	lockedUntil := now.Add(lease)
+	ids := make([]uuid.UUID, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+		event.LockedUntil = &lockedUntil
+	}
+	err = db.
+		Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Update("locked_until", lockedUntil).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxEventsRepository) MarkPublished(ctx context.Context, id uuid.UUID, publishedAt time.Time) error {
+	db := r.getDB(ctx)
+
+	return db.
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       models.OutboxStatusPublished,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   "",
+			"published_at": publishedAt,
+			"locked_until": nil,
+		}).Error
+}
+
+// MarkRetry: 발행 실패를 기록하고 nextAttemptAt 이후에 다시 시도하게 합니다.
+// failed=true 이면 더 이상 재시도하지 않습니다.
+func (r *OutboxEventsRepository) MarkRetry(
+	ctx context.Context,
+	id uuid.UUID,
+	lastError string,
+	nextAttemptAt time.Time,
+	failed bool,
+) error {
+	db := r.getDB(ctx)
+
+	status := models.OutboxStatusPending
+	if failed {
+		status = models.OutboxStatusFailed
+	}
+
+	return db.
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   lastError,
+			"available_at": nextAttemptAt,
+			"locked_until": nil,
+		}).Error
+}
+
+// DeletePublishedBefore: 발행이 끝난 오래된 이벤트를 정리합니다.
+func (r *OutboxEventsRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := r.getDB(ctx)
+
+	result := db.
+		Where("status = ? AND published_at < ?", models.OutboxStatusPublished, before).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PersistedQueriesRepository struct {
+	db *gorm.DB
+}
+
+func NewPersistedQueriesRepository(db *gorm.DB) *PersistedQueriesRepository {
+	if db == nil {
+		panic("database connection is required")
+	}
+	return &PersistedQueriesRepository{
+		db: db,
+	}
+}
+
+func (r *PersistedQueriesRepository) getDB(ctx context.Context) *gorm.DB {
+	// tx 패키지를 사용하여 Context에서 트랜잭션을 추출합니다.
+	if tx := tx.GetTx(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx) // 기본 DB 연결 반환
+}
+
+// Find: 없으면 nil, nil 을 반환합니다.
+func (r *PersistedQueriesRepository) Find(ctx context.Context, hash string) (*models.PersistedQuery, error) {
+	db := r.getDB(ctx)
+
+	var record models.PersistedQuery
+	if err := db.Where("hash = ?", hash).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find persisted query: %w", err)
+	}
+
+	return &record, nil
+}
+
+// Save: 같은 hash 는 같은 쿼리이므로 이미 있으면 무시합니다.
+func (r *PersistedQueriesRepository) Save(ctx context.Context, record *models.PersistedQuery) error {
+	db := r.getDB(ctx)
+
+	if err := db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(record).Error; err != nil {
+		return fmt.Errorf("failed to save persisted query: %w", err)
+	}
+	return nil
+}
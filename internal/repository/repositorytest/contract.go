@@ -0,0 +1,1162 @@
+// Package repositorytest: repository 인터페이스 구현이 지켜야 할 동작을 검증하는 공통 contract test
+//
+// PostgreSQL 구현(repository 패키지 테스트)과 in-memory 구현(repository/memory 테스트)이 같은 Run 을 실행합니다.
+// in-memory 구현에서만 통과하는 테스트를 추가하지 않도록, 새 케이스는 두 구현 모두에서 확인하세요.
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Repos: 같은 저장소를 공유하는 repository 묶음
+type Repos struct {
+	Profiles repository.ProfileRepositoryInterface
+	Events   repository.CalendarEventsRepositoryInterface
+	Todos    repository.TodosRepositoryInterface
+
+	Follows          repository.FollowsRepositoryInterface
+	Templates        repository.CalendarEventTemplatesRepositoryInterface
+	IdempotencyKeys  repository.IdempotencyKeysRepositoryInterface
+	Outbox           repository.OutboxEventsRepositoryInterface
+	PersistedQueries repository.PersistedQueriesRepositoryInterface
+
+	// DB: Follows 의 *Tx 메서드에 넘길 연결 (in-memory 는 nil)
+	DB *gorm.DB
+
+	// Follow: follows 행을 직접 추가합니다. 프로필 카운트는 바꾸지 않습니다.
+	Follow func(t *testing.T, followerID, followeeID uuid.UUID)
+}
+
+// Factory: 호출마다 비어 있는 저장소로 Repos 를 만듭니다.
+type Factory func(t *testing.T) Repos
+
+// 비교가 DB 정밀도(마이크로초)와 무관하도록 초 단위 시각만 사용합니다.
+var baseTime = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+// Run: 모든 contract test 를 subtest 로 실행합니다.
+func Run(t *testing.T, newRepos Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Repos)
+	}{
+		{"ProfileCreateAndGet", testProfileCreateAndGet},
+		{"ProfileCreateDuplicate", testProfileCreateDuplicate},
+		{"ProfileUpdate", testProfileUpdate},
+		{"ProfileUpdateVersionConflict", testProfileUpdateVersionConflict},
+		{"ProfileUpdateNicknameDuplicate", testProfileUpdateNicknameDuplicate},
+		{"ProfileFindByUserIDsAndNicknames", testProfileFindMany},
+		{"ProfileFollowCounts", testProfileFollowCounts},
+		{"ProfileDelete", testProfileDelete},
+		{"EventCreateWithTodos", testEventCreateWithTodos},
+		{"EventNotFound", testEventNotFound},
+		{"EventUpdateReplacesTodos", testEventUpdateReplacesTodos},
+		{"EventUpdateVersionConflict", testEventUpdateVersionConflict},
+		{"EventRangeAndVisibility", testEventRangeAndVisibility},
+		{"EventDeleteCascadesTodos", testEventDeleteCascadesTodos},
+		{"EventDeleteByUserID", testEventDeleteByUserID},
+		{"TodoCreateAndFind", testTodoCreateAndFind},
+		{"TodoUpdateStatus", testTodoUpdateStatus},
+		{"TodoUpdateStatusOwnership", testTodoUpdateStatusOwnership},
+		{"FollowAndUnfollow", testFollowAndUnfollow},
+		{"FollowDeleteAllByUser", testFollowDeleteAllByUser},
+		{"TemplateCreateAndFind", testTemplateCreateAndFind},
+		{"TemplateUpdateReplacesTodos", testTemplateUpdateReplacesTodos},
+		{"TemplateDelete", testTemplateDelete},
+		{"IdempotencyReserve", testIdempotencyReserve},
+		{"IdempotencyCompleteAndDelete", testIdempotencyCompleteAndDelete},
+		{"IdempotencyDeleteExpired", testIdempotencyDeleteExpired},
+		{"OutboxClaimPendingOrder", testOutboxClaimPendingOrder},
+		{"OutboxClaimLease", testOutboxClaimLease},
+		{"OutboxMarkRetry", testOutboxMarkRetry},
+		{"OutboxDeletePublishedBefore", testOutboxDeletePublishedBefore},
+		{"PersistedQuerySaveAndFind", testPersistedQuerySaveAndFind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepos(t))
+		})
+	}
+}
+
+// -------------------------
+// helpers
+// -------------------------
+
+func mustCreateProfile(t *testing.T, r Repos, nickname string) *models.Profile {
+	t.Helper()
+	profile := &models.Profile{UserID: uuid.New(), Nickname: nickname}
+	if err := r.Profiles.Create(context.Background(), profile); err != nil {
+		t.Fatalf("Create profile %q: %v", nickname, err)
+	}
+	return profile
+}
+
+func mustGetProfile(t *testing.T, r Repos, userID uuid.UUID) *models.Profile {
+	t.Helper()
+	profile, err := r.Profiles.GetMyProfileInfo(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetMyProfileInfo: %v", err)
+	}
+	return profile
+}
+
+func newEvent(userID uuid.UUID, title, visibility string, startAt time.Time, duration time.Duration, todos ...string) *models.CalendarEvent {
+	event := &models.CalendarEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Title:      title,
+		StartAt:    startAt,
+		EndAt:      startAt.Add(duration),
+		Visibility: visibility,
+		CreatedAt:  baseTime,
+		UpdatedAt:  baseTime,
+	}
+	for i, content := range todos {
+		event.Todos = append(event.Todos, models.Todo{
+			ID:        uuid.New(),
+			Content:   content,
+			CreatedAt: baseTime.Add(time.Duration(i) * time.Second),
+			UpdatedAt: baseTime,
+		})
+	}
+	return event
+}
+
+func mustCreateEvent(t *testing.T, r Repos, event *models.CalendarEvent) *models.CalendarEvent {
+	t.Helper()
+	created, err := r.Events.CreateCalendarEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("CreateCalendarEvent %q: %v", event.Title, err)
+	}
+	return created
+}
+
+func titles(events []*models.CalendarEvent) []string {
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		result = append(result, e.Title)
+	}
+	return result
+}
+
+func contents(todos []models.Todo) []string {
+	result := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		result = append(result, todo.Content)
+	}
+	return result
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// -------------------------
+// ProfileRepository
+// -------------------------
+
+func testProfileCreateAndGet(t *testing.T, r Repos) {
+	ctx := context.Background()
+	created := mustCreateProfile(t, r, "alice")
+	if created.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	got := mustGetProfile(t, r, created.UserID)
+	if got.ID != created.ID || got.Nickname != "alice" {
+		t.Fatalf("got %+v, want id=%s nickname=alice", got, created.ID)
+	}
+	if got.Version != 1 || got.Theme != "light" || got.FollowerCount != 0 {
+		t.Fatalf("defaults not applied: %+v", got)
+	}
+
+	if ok, err := r.Profiles.IsMyProfile(ctx, created.UserID); err != nil || !ok {
+		t.Fatalf("IsMyProfile = %v, %v; want true", ok, err)
+	}
+	if ok, err := r.Profiles.ExistsByNickname(ctx, "alice"); err != nil || !ok {
+		t.Fatalf("ExistsByNickname(alice) = %v, %v; want true", ok, err)
+	}
+	if ok, err := r.Profiles.ExistsByNickname(ctx, "nobody"); err != nil || ok {
+		t.Fatalf("ExistsByNickname(nobody) = %v, %v; want false", ok, err)
+	}
+
+	_, err := r.Profiles.GetUserProfileInfo(ctx, uuid.New())
+	if !planet_err.IsNotFound(err) {
+		t.Fatalf("GetUserProfileInfo(unknown) err = %v, want not found", err)
+	}
+}
+
+func testProfileCreateDuplicate(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+
+	err := r.Profiles.Create(ctx, &models.Profile{UserID: alice.UserID, Nickname: "alice2"})
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Fatalf("duplicate user_id err = %v, want ErrAlreadyExists", err)
+	}
+
+	err = r.Profiles.Create(ctx, &models.Profile{UserID: uuid.New(), Nickname: "alice"})
+	if !errors.Is(err, repository.ErrNicknameDuplicate) {
+		t.Fatalf("duplicate nickname err = %v, want ErrNicknameDuplicate", err)
+	}
+}
+
+func testProfileUpdate(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+
+	err := r.Profiles.UpdateProfile(ctx, &dto.ProfileUpdate{
+		UserID:          alice.UserID,
+		Nickname:        ptr("alice_new"),
+		Bio:             ptr("hello"),
+		ExpectedVersion: ptr(int32(1)),
+	})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+
+	got := mustGetProfile(t, r, alice.UserID)
+	if got.Nickname != "alice_new" || got.Bio != "hello" || got.Theme != "light" {
+		t.Fatalf("unexpected profile after update: %+v", got)
+	}
+	if got.Version != 2 {
+		t.Fatalf("version = %d, want 2", got.Version)
+	}
+
+	// ExpectedVersion 없이 없는 사용자를 업데이트해도 에러가 아님
+	if err := r.Profiles.UpdateProfile(ctx, &dto.ProfileUpdate{UserID: uuid.New(), Bio: ptr("x")}); err != nil {
+		t.Fatalf("UpdateProfile(unknown) = %v, want nil", err)
+	}
+}
+
+func testProfileUpdateVersionConflict(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+
+	err := r.Profiles.UpdateProfile(ctx, &dto.ProfileUpdate{
+		UserID:          alice.UserID,
+		Bio:             ptr("stale"),
+		ExpectedVersion: ptr(int32(7)),
+	})
+	if !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("err = %v, want ErrVersionConflict", err)
+	}
+	if got := mustGetProfile(t, r, alice.UserID); got.Bio != "" || got.Version != 1 {
+		t.Fatalf("profile changed on conflict: %+v", got)
+	}
+
+	// 두 요청이 모두 version 1 을 읽은 경우: 먼저 쓴 쪽만 반영
+	first := &dto.ProfileUpdate{UserID: alice.UserID, Bio: ptr("first"), ExpectedVersion: ptr(int32(1))}
+	if err := r.Profiles.UpdateProfile(ctx, first); err != nil {
+		t.Fatalf("first UpdateProfile: %v", err)
+	}
+	second := &dto.ProfileUpdate{UserID: alice.UserID, Bio: ptr("second"), ExpectedVersion: ptr(int32(1))}
+	if err := r.Profiles.UpdateProfile(ctx, second); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale UpdateProfile err = %v, want ErrVersionConflict", err)
+	}
+	if got := mustGetProfile(t, r, alice.UserID); got.Bio != "first" || got.Version != 2 {
+		t.Fatalf("profile after stale update = %+v, want first v2", got)
+	}
+}
+
+func testProfileUpdateNicknameDuplicate(t *testing.T, r Repos) {
+	ctx := context.Background()
+	mustCreateProfile(t, r, "alice")
+	bob := mustCreateProfile(t, r, "bob")
+
+	err := r.Profiles.UpdateProfile(ctx, &dto.ProfileUpdate{UserID: bob.UserID, Nickname: ptr("alice")})
+	if !errors.Is(err, repository.ErrNicknameDuplicate) {
+		t.Fatalf("err = %v, want ErrNicknameDuplicate", err)
+	}
+
+	// 자기 자신의 닉네임으로 바꾸는 것은 허용
+	if err := r.Profiles.UpdateProfile(ctx, &dto.ProfileUpdate{UserID: bob.UserID, Nickname: ptr("bob")}); err != nil {
+		t.Fatalf("UpdateProfile(same nickname) = %v", err)
+	}
+}
+
+func testProfileFindMany(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+	bob := mustCreateProfile(t, r, "bob")
+	mustCreateProfile(t, r, "carol")
+
+	byID, err := r.Profiles.FindByUserIDs(ctx, []uuid.UUID{alice.UserID, bob.UserID, uuid.New()})
+	if err != nil {
+		t.Fatalf("FindByUserIDs: %v", err)
+	}
+	if len(byID) != 2 {
+		t.Fatalf("FindByUserIDs returned %d profiles, want 2", len(byID))
+	}
+
+	byNickname, err := r.Profiles.FindByNicknames(ctx, []string{"carol", "nobody"})
+	if err != nil {
+		t.Fatalf("FindByNicknames: %v", err)
+	}
+	if len(byNickname) != 1 || byNickname[0].Nickname != "carol" {
+		t.Fatalf("FindByNicknames = %+v, want [carol]", byNickname)
+	}
+
+	empty, err := r.Profiles.FindByUserIDs(ctx, nil)
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Fatalf("FindByUserIDs(nil) = %v, %v; want empty non-nil slice", empty, err)
+	}
+}
+
+func testProfileFollowCounts(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+	bob := mustCreateProfile(t, r, "bob")
+	carol := mustCreateProfile(t, r, "carol")
+
+	// alice → bob, alice → carol, bob → alice
+	r.Follow(t, alice.UserID, bob.UserID)
+	r.Follow(t, alice.UserID, carol.UserID)
+	r.Follow(t, bob.UserID, alice.UserID)
+
+	changed, err := r.Profiles.RecountFollowCounts(ctx)
+	if err != nil {
+		t.Fatalf("RecountFollowCounts: %v", err)
+	}
+	if changed != 3 {
+		t.Fatalf("RecountFollowCounts changed %d, want 3", changed)
+	}
+	if got := mustGetProfile(t, r, alice.UserID); got.FollowerCount != 1 || got.FollowingCount != 2 {
+		t.Fatalf("alice counts = %d/%d, want 1/2", got.FollowerCount, got.FollowingCount)
+	}
+	if changed, _ := r.Profiles.RecountFollowCounts(ctx); changed != 0 {
+		t.Fatalf("second RecountFollowCounts changed %d, want 0", changed)
+	}
+
+	// alice 탈퇴 → 상대방 카운트 감소
+	if err := r.Profiles.DecrementFollowCountsOfUser(ctx, alice.UserID); err != nil {
+		t.Fatalf("DecrementFollowCountsOfUser: %v", err)
+	}
+	if got := mustGetProfile(t, r, bob.UserID); got.FollowerCount != 0 || got.FollowingCount != 0 {
+		t.Fatalf("bob counts = %d/%d, want 0/0", got.FollowerCount, got.FollowingCount)
+	}
+	if got := mustGetProfile(t, r, carol.UserID); got.FollowerCount != 0 || got.FollowingCount != 0 {
+		t.Fatalf("carol counts = %d/%d, want 0/0", got.FollowerCount, got.FollowingCount)
+	}
+}
+
+func testProfileDelete(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice := mustCreateProfile(t, r, "alice")
+
+	if err := r.Profiles.DeleteByUserID(ctx, alice.UserID); err != nil {
+		t.Fatalf("DeleteByUserID: %v", err)
+	}
+	if ok, _ := r.Profiles.IsMyProfile(ctx, alice.UserID); ok {
+		t.Fatal("profile still exists after delete")
+	}
+	if err := r.Profiles.DeleteByUserID(ctx, alice.UserID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("second delete err = %v, want ErrNotFound", err)
+	}
+}
+
+// -------------------------
+// CalendarEventsRepository
+// -------------------------
+
+func testEventCreateWithTodos(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(userID, "standup", "public", baseTime, time.Hour, "notes", "agenda"))
+
+	for _, todo := range event.Todos {
+		if todo.CalendarEventID != event.ID {
+			t.Fatalf("todo %s calendar_event_id = %s, want %s", todo.ID, todo.CalendarEventID, event.ID)
+		}
+	}
+
+	got, err := r.Events.FindByID(ctx, event.ID)
+	if err != nil || got == nil {
+		t.Fatalf("FindByID = %v, %v", got, err)
+	}
+	if got.Version != 1 || got.Title != "standup" || !got.StartAt.Equal(baseTime) {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+	if len(got.Todos) != 2 {
+		t.Fatalf("FindByID todos = %d, want 2", len(got.Todos))
+	}
+
+	withTodos, err := r.Events.GetEventWithTodosByID(ctx, event.ID)
+	if err != nil || len(withTodos.Todos) != 2 {
+		t.Fatalf("GetEventWithTodosByID = %+v, %v", withTodos, err)
+	}
+}
+
+func testEventNotFound(t *testing.T, r Repos) {
+	ctx := context.Background()
+	got, err := r.Events.FindByID(ctx, uuid.New())
+	if err != nil || got != nil {
+		t.Fatalf("FindByID(unknown) = %v, %v; want nil, nil", got, err)
+	}
+	if _, err := r.Events.GetEventWithTodosByID(ctx, uuid.New()); !planet_err.IsNotFound(err) {
+		t.Fatalf("GetEventWithTodosByID(unknown) err = %v, want not found", err)
+	}
+}
+
+func testEventUpdateReplacesTodos(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(userID, "standup", "public", baseTime, time.Hour, "old1", "old2"))
+
+	update := newEvent(userID, "retro", "private", baseTime.Add(time.Hour), 2*time.Hour, "new1")
+	update.ID = event.ID
+	update.Todos[0].CalendarEventID = event.ID
+	if err := r.Events.Update(ctx, update, 1); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if update.Version != 2 {
+		t.Fatalf("update.Version = %d, want 2", update.Version)
+	}
+
+	got, err := r.Events.GetEventWithTodosByID(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("GetEventWithTodosByID: %v", err)
+	}
+	if got.Title != "retro" || got.Visibility != "private" || got.Version != 2 || got.UserID != userID {
+		t.Fatalf("unexpected event after update: %+v", got)
+	}
+	if !equalStrings(contents(got.Todos), []string{"new1"}) {
+		t.Fatalf("todos after update = %v, want [new1]", contents(got.Todos))
+	}
+	if old, _ := r.Todos.FindByID(ctx, event.Todos[0].ID); old != nil {
+		t.Fatal("replaced todo still exists")
+	}
+}
+
+func testEventUpdateVersionConflict(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(userID, "standup", "public", baseTime, time.Hour, "keep"))
+
+	update := newEvent(userID, "stale", "public", baseTime, time.Hour)
+	update.ID = event.ID
+	if err := r.Events.Update(ctx, update, 5); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("Update err = %v, want ErrVersionConflict", err)
+	}
+
+	got, _ := r.Events.FindByID(ctx, event.ID)
+	if got.Title != "standup" || got.Version != 1 || len(got.Todos) != 1 {
+		t.Fatalf("event changed on conflict: %+v", got)
+	}
+
+	missing := newEvent(userID, "missing", "public", baseTime, time.Hour)
+	if err := r.Events.Update(ctx, missing, 1); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("Update(unknown) err = %v, want ErrVersionConflict", err)
+	}
+
+	// 두 요청이 모두 version 1 을 읽은 경우: 먼저 쓴 쪽만 반영
+	first := newEvent(userID, "first", "public", baseTime, time.Hour)
+	first.ID = event.ID
+	if err := r.Events.Update(ctx, first, 1); err != nil {
+		t.Fatalf("first Update: %v", err)
+	}
+	second := newEvent(userID, "second", "public", baseTime, time.Hour)
+	second.ID = event.ID
+	if err := r.Events.Update(ctx, second, 1); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale Update err = %v, want ErrVersionConflict", err)
+	}
+	got, _ = r.Events.FindByID(ctx, event.ID)
+	if got.Title != "first" || got.Version != 2 {
+		t.Fatalf("event after stale update = %+v, want first v2", got)
+	}
+}
+
+func testEventRangeAndVisibility(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	day := 24 * time.Hour
+
+	// 조회 범위: [baseTime, baseTime+1일)
+	mustCreateEvent(t, r, newEvent(userID, "before", "public", baseTime.Add(-2*day), day))               // 범위 이전에 끝남
+	mustCreateEvent(t, r, newEvent(userID, "touching", "public", baseTime.Add(-day), day))               // end_at == startAt → 포함
+	mustCreateEvent(t, r, newEvent(userID, "inside", "public", baseTime.Add(time.Hour), time.Hour, "t")) // 범위 안
+	mustCreateEvent(t, r, newEvent(userID, "private", "private", baseTime.Add(2*time.Hour), time.Hour))
+	mustCreateEvent(t, r, newEvent(userID, "at-end", "public", baseTime.Add(day), time.Hour)) // start_at == endAt → 제외
+	mustCreateEvent(t, r, newEvent(uuid.New(), "other-user", "public", baseTime, time.Hour))
+
+	startAt, endAt := baseTime, baseTime.Add(day)
+
+	public, err := r.Events.FindEventsWithoutTodosByVisibility(ctx, userID, []string{"public"}, startAt, endAt)
+	if err != nil {
+		t.Fatalf("FindEventsWithoutTodosByVisibility: %v", err)
+	}
+	if want := []string{"touching", "inside"}; !equalStrings(titles(public), want) {
+		t.Fatalf("public events = %v, want %v", titles(public), want)
+	}
+	for _, e := range public {
+		if len(e.Todos) != 0 {
+			t.Fatalf("event %q has todos without preload", e.Title)
+		}
+	}
+
+	all, err := r.Events.FindCalendarsWithTodos(ctx, userID, []string{"public", "private"}, startAt, endAt)
+	if err != nil {
+		t.Fatalf("FindCalendarsWithTodos: %v", err)
+	}
+	if want := []string{"touching", "inside", "private"}; !equalStrings(titles(all), want) {
+		t.Fatalf("all events = %v, want %v", titles(all), want)
+	}
+	if len(all[1].Todos) != 1 {
+		t.Fatalf("inside todos = %d, want 1", len(all[1].Todos))
+	}
+
+	none, err := r.Events.FindCalendarsWithTodos(ctx, userID, nil, startAt, endAt)
+	if err != nil || len(none) != 0 {
+		t.Fatalf("FindCalendarsWithTodos(no visibility) = %v, %v; want empty", titles(none), err)
+	}
+
+	everything, err := r.Events.FindAllWithTodosByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindAllWithTodosByUserID: %v", err)
+	}
+	if want := []string{"before", "touching", "inside", "private", "at-end"}; !equalStrings(titles(everything), want) {
+		t.Fatalf("FindAllWithTodosByUserID = %v, want %v", titles(everything), want)
+	}
+}
+
+func testEventDeleteCascadesTodos(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(userID, "standup", "public", baseTime, time.Hour, "a", "b"))
+
+	if err := r.Events.DeleteCalendarEvent(ctx, event.ID); err != nil {
+		t.Fatalf("DeleteCalendarEvent: %v", err)
+	}
+	if got, _ := r.Events.FindByID(ctx, event.ID); got != nil {
+		t.Fatal("event still exists after delete")
+	}
+	todos, err := r.Todos.FindByEventIDs(ctx, []uuid.UUID{event.ID})
+	if err != nil || len(todos) != 0 {
+		t.Fatalf("todos after delete = %v, %v; want none", contents(todos), err)
+	}
+
+	// 없는 일정 삭제는 에러가 아님
+	if err := r.Events.DeleteCalendarEvent(ctx, uuid.New()); err != nil {
+		t.Fatalf("DeleteCalendarEvent(unknown) = %v", err)
+	}
+}
+
+func testEventDeleteByUserID(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+	mine := mustCreateEvent(t, r, newEvent(userID, "mine", "public", baseTime, time.Hour, "a"))
+	other := mustCreateEvent(t, r, newEvent(otherID, "other", "public", baseTime, time.Hour, "b"))
+
+	if err := r.Events.DeleteByUserID(ctx, userID); err != nil {
+		t.Fatalf("DeleteByUserID: %v", err)
+	}
+	if got, _ := r.Events.FindByID(ctx, mine.ID); got != nil {
+		t.Fatal("user's event still exists")
+	}
+	if todo, _ := r.Todos.FindByID(ctx, mine.Todos[0].ID); todo != nil {
+		t.Fatal("user's todo still exists")
+	}
+	if got, _ := r.Events.FindByID(ctx, other.ID); got == nil || len(got.Todos) != 1 {
+		t.Fatalf("other user's event affected: %+v", got)
+	}
+}
+
+// -------------------------
+// TodosRepository
+// -------------------------
+
+func testTodoCreateAndFind(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := mustCreateEvent(t, r, newEvent(userID, "first", "public", baseTime, time.Hour))
+	second := mustCreateEvent(t, r, newEvent(userID, "second", "public", baseTime, time.Hour))
+
+	todos := []models.Todo{
+		{ID: uuid.New(), CalendarEventID: second.ID, Content: "late", CreatedAt: baseTime.Add(2 * time.Minute), UpdatedAt: baseTime},
+		{ID: uuid.New(), CalendarEventID: first.ID, Content: "early", CreatedAt: baseTime, UpdatedAt: baseTime},
+		{ID: uuid.New(), CalendarEventID: first.ID, Content: "middle", CreatedAt: baseTime.Add(time.Minute), UpdatedAt: baseTime},
+	}
+	if err := r.Todos.CreateTodos(ctx, todos); err != nil {
+		t.Fatalf("CreateTodos: %v", err)
+	}
+	if err := r.Todos.CreateTodos(ctx, nil); err != nil {
+		t.Fatalf("CreateTodos(nil) = %v", err)
+	}
+
+	got, err := r.Todos.FindByEventIDs(ctx, []uuid.UUID{first.ID, second.ID})
+	if err != nil {
+		t.Fatalf("FindByEventIDs: %v", err)
+	}
+	if want := []string{"early", "middle", "late"}; !equalStrings(contents(got), want) {
+		t.Fatalf("FindByEventIDs = %v, want %v", contents(got), want)
+	}
+
+	found, err := r.Todos.FindByID(ctx, todos[0].ID)
+	if err != nil || found == nil || found.Content != "late" || found.Version != 1 {
+		t.Fatalf("FindByID = %+v, %v", found, err)
+	}
+	if missing, err := r.Todos.FindByID(ctx, uuid.New()); err != nil || missing != nil {
+		t.Fatalf("FindByID(unknown) = %v, %v; want nil, nil", missing, err)
+	}
+
+	// 없는 일정을 가리키는 Todo 는 저장되지 않음
+	orphan := []models.Todo{{ID: uuid.New(), CalendarEventID: uuid.New(), Content: "orphan"}}
+	if err := r.Todos.CreateTodos(ctx, orphan); err == nil {
+		t.Fatal("CreateTodos with unknown event succeeded")
+	}
+}
+
+func testTodoUpdateStatus(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(userID, "standup", "public", baseTime, time.Hour, "todo"))
+	todoID := event.Todos[0].ID
+
+	updated, err := r.Todos.UpdateTodoStatus(ctx, userID, todoID, true, ptr(int32(1)))
+	if err != nil {
+		t.Fatalf("UpdateTodoStatus: %v", err)
+	}
+	if !updated.IsDone || updated.Version != 2 {
+		t.Fatalf("updated = %+v, want done v2", updated)
+	}
+
+	// 오래된 version → 서버 최신본과 함께 충돌
+	current, err := r.Todos.UpdateTodoStatus(ctx, userID, todoID, false, ptr(int32(1)))
+	if !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale update err = %v, want ErrVersionConflict", err)
+	}
+	if current == nil || !current.IsDone || current.Version != 2 {
+		t.Fatalf("conflict returned %+v, want current done v2", current)
+	}
+
+	// version 없이 업데이트하면 항상 성공
+	updated, err = r.Todos.UpdateTodoStatus(ctx, userID, todoID, false, nil)
+	if err != nil || updated.IsDone || updated.Version != 3 {
+		t.Fatalf("unconditional update = %+v, %v", updated, err)
+	}
+}
+
+func testTodoUpdateStatusOwnership(t *testing.T, r Repos) {
+	ctx := context.Background()
+	owner := uuid.New()
+	event := mustCreateEvent(t, r, newEvent(owner, "standup", "public", baseTime, time.Hour, "todo"))
+	todoID := event.Todos[0].ID
+
+	if _, err := r.Todos.UpdateTodoStatus(ctx, uuid.New(), todoID, true, nil); err == nil {
+		t.Fatal("UpdateTodoStatus by another user succeeded")
+	}
+	if _, err := r.Todos.UpdateTodoStatus(ctx, owner, uuid.New(), true, nil); err == nil {
+		t.Fatal("UpdateTodoStatus of unknown todo succeeded")
+	}
+	if todo, _ := r.Todos.FindByID(ctx, todoID); todo.IsDone || todo.Version != 1 {
+		t.Fatalf("todo changed by unauthorized update: %+v", todo)
+	}
+}
+
+// -------------------------
+// FollowsRepository
+// -------------------------
+
+// sameIDs: 순서와 무관하게 비교합니다.
+func sameIDs(got, want []uuid.UUID) bool {
+	a, b := slices.Clone(got), slices.Clone(want)
+	compare := func(x, y uuid.UUID) int { return strings.Compare(x.String(), y.String()) }
+	slices.SortFunc(a, compare)
+	slices.SortFunc(b, compare)
+	return slices.Equal(a, b)
+}
+
+func mustFollow(t *testing.T, r Repos, followerID, followeeID uuid.UUID) {
+	t.Helper()
+	if err := r.Follows.FollowTx(context.Background(), r.DB, followerID, followeeID); err != nil {
+		t.Fatalf("FollowTx: %v", err)
+	}
+}
+
+func testFollowAndUnfollow(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice, bob, carol, dave := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mustFollow(t, r, alice, bob)
+	mustFollow(t, r, alice, carol)
+	mustFollow(t, r, dave, alice)
+
+	if err := r.Follows.FollowTx(ctx, r.DB, alice, bob); err == nil {
+		t.Fatal("duplicate FollowTx succeeded")
+	}
+
+	if ok, err := r.Follows.IsFollow(ctx, alice, bob); err != nil || !ok {
+		t.Fatalf("IsFollow(alice, bob) = %v, %v; want true", ok, err)
+	}
+	if ok, err := r.Follows.IsFollow(ctx, bob, alice); err != nil || ok {
+		t.Fatalf("IsFollow(bob, alice) = %v, %v; want false", ok, err)
+	}
+
+	followed, err := r.Follows.FindFollowedAmong(ctx, alice, []uuid.UUID{bob, dave, uuid.New()})
+	if err != nil || !sameIDs(followed, []uuid.UUID{bob}) {
+		t.Fatalf("FindFollowedAmong = %v, %v; want [bob]", followed, err)
+	}
+	if followed, err := r.Follows.FindFollowedAmong(ctx, alice, nil); err != nil || followed == nil || len(followed) != 0 {
+		t.Fatalf("FindFollowedAmong(nil) = %#v, %v; want empty", followed, err)
+	}
+
+	if followees, err := r.Follows.FindFolloweeIDs(ctx, alice); err != nil || !sameIDs(followees, []uuid.UUID{bob, carol}) {
+		t.Fatalf("FindFolloweeIDs(alice) = %v, %v", followees, err)
+	}
+	if followers, err := r.Follows.FindFollowerIDs(ctx, alice); err != nil || !sameIDs(followers, []uuid.UUID{dave}) {
+		t.Fatalf("FindFollowerIDs(alice) = %v, %v", followers, err)
+	}
+
+	if err := r.Follows.UnfollowTx(ctx, r.DB, alice, bob); err != nil {
+		t.Fatalf("UnfollowTx: %v", err)
+	}
+	if ok, _ := r.Follows.IsFollow(ctx, alice, bob); ok {
+		t.Fatal("still following after UnfollowTx")
+	}
+	if err := r.Follows.UnfollowTx(ctx, r.DB, alice, bob); err == nil {
+		t.Fatal("UnfollowTx of missing relation succeeded")
+	}
+}
+
+func testFollowDeleteAllByUser(t *testing.T, r Repos) {
+	ctx := context.Background()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	mustFollow(t, r, alice, bob)
+	mustFollow(t, r, bob, alice)
+	mustFollow(t, r, bob, carol)
+
+	if err := r.Follows.DeleteAllByUserTx(ctx, r.DB, alice); err != nil {
+		t.Fatalf("DeleteAllByUserTx: %v", err)
+	}
+
+	if followers, err := r.Follows.FindFollowerIDs(ctx, alice); err != nil || len(followers) != 0 {
+		t.Fatalf("FindFollowerIDs(alice) = %v, %v; want empty", followers, err)
+	}
+	if followees, err := r.Follows.FindFolloweeIDs(ctx, bob); err != nil || !sameIDs(followees, []uuid.UUID{carol}) {
+		t.Fatalf("FindFolloweeIDs(bob) = %v, %v; want [carol]", followees, err)
+	}
+}
+
+// -------------------------
+// CalendarEventTemplatesRepository
+// -------------------------
+
+func newTemplate(userID uuid.UUID, name string, todos ...string) *models.CalendarEventTemplate {
+	template := &models.CalendarEventTemplate{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Name:            name,
+		Title:           name + " title",
+		Visibility:      "private",
+		DurationMinutes: 30,
+	}
+	for i, content := range todos {
+		template.Todos = append(template.Todos, models.CalendarEventTemplateTodo{
+			ID:       uuid.New(),
+			Content:  content,
+			Position: int32(i),
+		})
+	}
+	return template
+}
+
+func mustCreateTemplate(t *testing.T, r Repos, template *models.CalendarEventTemplate) *models.CalendarEventTemplate {
+	t.Helper()
+	created, err := r.Templates.Create(context.Background(), template)
+	if err != nil {
+		t.Fatalf("Create template %q: %v", template.Name, err)
+	}
+	return created
+}
+
+func templateContents(todos []models.CalendarEventTemplateTodo) []string {
+	result := make([]string, 0, len(todos))
+	for _, todo := range todos {
+		result = append(result, todo.Content)
+	}
+	return result
+}
+
+func testTemplateCreateAndFind(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// position 과 다른 순서로 저장해도 position 순으로 조회
+	morning := newTemplate(userID, "morning", "stretch", "coffee", "news")
+	morning.Todos[0].Position, morning.Todos[2].Position = 2, 0
+	mustCreateTemplate(t, r, morning)
+	mustCreateTemplate(t, r, newTemplate(userID, "evening", "dinner"))
+	mustCreateTemplate(t, r, newTemplate(uuid.New(), "another user"))
+
+	got, err := r.Templates.FindByID(ctx, morning.ID)
+	if err != nil || got == nil {
+		t.Fatalf("FindByID = %v, %v", got, err)
+	}
+	if got.Name != "morning" || got.UserID != userID || got.DurationMinutes != 30 {
+		t.Fatalf("FindByID = %+v", got)
+	}
+	if want := []string{"news", "coffee", "stretch"}; !equalStrings(templateContents(got.Todos), want) {
+		t.Fatalf("todos = %v, want %v", templateContents(got.Todos), want)
+	}
+	for _, todo := range got.Todos {
+		if todo.TemplateID != morning.ID {
+			t.Fatalf("todo %q template_id = %s, want %s", todo.Content, todo.TemplateID, morning.ID)
+		}
+	}
+
+	if missing, err := r.Templates.FindByID(ctx, uuid.New()); err != nil || missing != nil {
+		t.Fatalf("FindByID(unknown) = %v, %v; want nil, nil", missing, err)
+	}
+
+	list, err := r.Templates.FindByUserID(ctx, userID)
+	if err != nil || len(list) != 2 || list[0].Name != "evening" || list[1].Name != "morning" {
+		t.Fatalf("FindByUserID = %v, %v; want [evening morning]", list, err)
+	}
+	if want := []string{"news", "coffee", "stretch"}; !equalStrings(templateContents(list[1].Todos), want) {
+		t.Fatalf("FindByUserID todos = %v, want %v", templateContents(list[1].Todos), want)
+	}
+}
+
+func testTemplateUpdateReplacesTodos(t *testing.T, r Repos) {
+	ctx := context.Background()
+	template := mustCreateTemplate(t, r, newTemplate(uuid.New(), "morning", "stretch", "coffee"))
+
+	template.Title = "slow morning"
+	template.Todos = []models.CalendarEventTemplateTodo{
+		{ID: uuid.New(), TemplateID: template.ID, Content: "tea", Position: 0},
+	}
+	if err := r.Templates.Update(ctx, template); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err := r.Templates.FindByID(ctx, template.ID)
+	if err != nil || got == nil {
+		t.Fatalf("FindByID = %v, %v", got, err)
+	}
+	if got.Title != "slow morning" || !equalStrings(templateContents(got.Todos), []string{"tea"}) {
+		t.Fatalf("after update title=%q todos=%v", got.Title, templateContents(got.Todos))
+	}
+}
+
+func testTemplateDelete(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+	first := mustCreateTemplate(t, r, newTemplate(userID, "first", "a"))
+	second := mustCreateTemplate(t, r, newTemplate(userID, "second", "b"))
+	third := mustCreateTemplate(t, r, newTemplate(userID, "third", "c"))
+	other := mustCreateTemplate(t, r, newTemplate(otherID, "other", "d"))
+
+	if err := r.Templates.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, err := r.Templates.FindByID(ctx, first.ID); err != nil || got != nil {
+		t.Fatalf("FindByID(deleted) = %v, %v; want nil, nil", got, err)
+	}
+	if list, err := r.Templates.FindByUserID(ctx, userID); err != nil || len(list) != 2 {
+		t.Fatalf("FindByUserID after Delete = %v, %v; want 2", list, err)
+	}
+
+	if err := r.Templates.DeleteByUserID(ctx, userID); err != nil {
+		t.Fatalf("DeleteByUserID: %v", err)
+	}
+	for _, id := range []uuid.UUID{second.ID, third.ID} {
+		if got, err := r.Templates.FindByID(ctx, id); err != nil || got != nil {
+			t.Fatalf("FindByID(%s) after DeleteByUserID = %v, %v", id, got, err)
+		}
+	}
+	if got, err := r.Templates.FindByID(ctx, other.ID); err != nil || got == nil || len(got.Todos) != 1 {
+		t.Fatalf("other user's template = %v, %v; want kept with todos", got, err)
+	}
+}
+
+// -------------------------
+// IdempotencyKeysRepository
+// -------------------------
+
+func newIdempotencyKey(userID uuid.UUID, operation, key string, expiresAt time.Time) *models.IdempotencyKey {
+	return &models.IdempotencyKey{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Operation:   operation,
+		Key:         key,
+		RequestHash: "hash-" + key,
+		Status:      models.IdempotencyStatusPending,
+		CreatedAt:   baseTime,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func mustReserve(t *testing.T, r Repos, record *models.IdempotencyKey) {
+	t.Helper()
+	if ok, err := r.IdempotencyKeys.Reserve(context.Background(), record); err != nil || !ok {
+		t.Fatalf("Reserve(%s/%s) = %v, %v; want true", record.Operation, record.Key, ok, err)
+	}
+}
+
+func testIdempotencyReserve(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	expiresAt := baseTime.Add(time.Hour)
+	mustReserve(t, r, newIdempotencyKey(userID, "createEvent", "k1", expiresAt))
+
+	// 같은 (user_id, operation, key) 는 다시 선점할 수 없음
+	if ok, err := r.IdempotencyKeys.Reserve(ctx, newIdempotencyKey(userID, "createEvent", "k1", expiresAt)); err != nil || ok {
+		t.Fatalf("duplicate Reserve = %v, %v; want false", ok, err)
+	}
+	// 범위가 하나라도 다르면 별개의 키
+	mustReserve(t, r, newIdempotencyKey(userID, "deleteEvent", "k1", expiresAt))
+	mustReserve(t, r, newIdempotencyKey(uuid.New(), "createEvent", "k1", expiresAt))
+
+	got, err := r.IdempotencyKeys.Find(ctx, userID, "createEvent", "k1")
+	if err != nil || got == nil {
+		t.Fatalf("Find = %v, %v", got, err)
+	}
+	if got.Status != models.IdempotencyStatusPending || got.RequestHash != "hash-k1" || !got.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("Find = %+v", got)
+	}
+
+	if missing, err := r.IdempotencyKeys.Find(ctx, userID, "createEvent", "k2"); err != nil || missing != nil {
+		t.Fatalf("Find(unknown) = %v, %v; want nil, nil", missing, err)
+	}
+}
+
+func testIdempotencyCompleteAndDelete(t *testing.T, r Repos) {
+	ctx := context.Background()
+	record := newIdempotencyKey(uuid.New(), "createEvent", "k1", baseTime.Add(time.Hour))
+	mustReserve(t, r, record)
+
+	if err := r.IdempotencyKeys.Complete(ctx, record.ID, []byte(`{"id":"e1","ok":true}`)); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	got, err := r.IdempotencyKeys.Find(ctx, record.UserID, record.Operation, record.Key)
+	if err != nil || got == nil || got.Status != models.IdempotencyStatusCompleted {
+		t.Fatalf("Find after Complete = %+v, %v", got, err)
+	}
+	// jsonb 는 공백을 바꿔 저장하므로 값으로 비교
+	var response map[string]any
+	if err := json.Unmarshal(got.Response, &response); err != nil || response["id"] != "e1" || response["ok"] != true {
+		t.Fatalf("response = %s, %v", got.Response, err)
+	}
+
+	if err := r.IdempotencyKeys.Delete(ctx, record.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, err := r.IdempotencyKeys.Find(ctx, record.UserID, record.Operation, record.Key); err != nil || got != nil {
+		t.Fatalf("Find after Delete = %v, %v; want nil, nil", got, err)
+	}
+	// 삭제 후에는 같은 키를 다시 선점할 수 있음
+	mustReserve(t, r, newIdempotencyKey(record.UserID, record.Operation, record.Key, baseTime.Add(time.Hour)))
+}
+
+func testIdempotencyDeleteExpired(t *testing.T, r Repos) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := baseTime.Add(time.Hour)
+	mustReserve(t, r, newIdempotencyKey(userID, "createEvent", "expired", now.Add(-time.Second)))
+	mustReserve(t, r, newIdempotencyKey(userID, "createEvent", "boundary", now))
+	mustReserve(t, r, newIdempotencyKey(userID, "createEvent", "live", now.Add(time.Minute)))
+
+	deleted, err := r.IdempotencyKeys.DeleteExpired(ctx, now)
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteExpired = %d, %v; want 1", deleted, err)
+	}
+	for key, want := range map[string]bool{"expired": false, "boundary": true, "live": true} {
+		got, err := r.IdempotencyKeys.Find(ctx, userID, "createEvent", key)
+		if err != nil || (got != nil) != want {
+			t.Fatalf("Find(%s) after DeleteExpired = %v, %v; want present=%v", key, got, err, want)
+		}
+	}
+}
+
+// -------------------------
+// OutboxEventsRepository
+// -------------------------
+
+const outboxLease = time.Minute
+
+func mustAppendOutbox(t *testing.T, r Repos, aggregateID uuid.UUID, eventType string, availableAt time.Time) *models.OutboxEvent {
+	t.Helper()
+	event := &models.OutboxEvent{
+		ID:            uuid.New(),
+		AggregateType: "calendar_event",
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       []byte(`{}`),
+		Status:        models.OutboxStatusPending,
+		AvailableAt:   availableAt,
+		CreatedAt:     baseTime,
+	}
+	if err := r.Outbox.Append(context.Background(), event); err != nil {
+		t.Fatalf("Append %s: %v", eventType, err)
+	}
+	return event
+}
+
+func mustClaim(t *testing.T, r Repos, now time.Time, limit int) []string {
+	t.Helper()
+	events, err := r.Outbox.ClaimPending(context.Background(), now, outboxLease, limit)
+	if err != nil {
+		t.Fatalf("ClaimPending: %v", err)
+	}
+	types := make([]string, 0, len(events))
+	for i, e := range events {
+		if i > 0 && e.Sequence <= events[i-1].Sequence {
+			t.Fatalf("ClaimPending not in sequence order: %d after %d", e.Sequence, events[i-1].Sequence)
+		}
+		if e.LockedUntil == nil || !e.LockedUntil.Equal(now.Add(outboxLease)) {
+			t.Fatalf("claimed %s locked_until = %v, want %v", e.EventType, e.LockedUntil, now.Add(outboxLease))
+		}
+		types = append(types, e.EventType)
+	}
+	return types
+}
+
+func testOutboxClaimPendingOrder(t *testing.T, r Repos) {
+	now := baseTime.Add(time.Hour)
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	mustAppendOutbox(t, r, a, "a1", baseTime)
+	mustAppendOutbox(t, r, a, "a2", baseTime)
+	mustAppendOutbox(t, r, b, "b1", now.Add(time.Minute)) // 아직 발행 시각 전
+	mustAppendOutbox(t, r, b, "b2", baseTime)             // b1 이 끝나기 전까지 대기
+	mustAppendOutbox(t, r, c, "c1", baseTime)
+
+	if got := mustClaim(t, r, now, 1); !equalStrings(got, []string{"a1"}) {
+		t.Fatalf("ClaimPending(limit 1) = %v, want [a1]", got)
+	}
+	// 선점된 a1 은 건너뛰지만 a2 는 여전히 a1 뒤에서 대기
+	if got := mustClaim(t, r, now, 10); !equalStrings(got, []string{"c1"}) {
+		t.Fatalf("ClaimPending = %v, want [c1]", got)
+	}
+}
+
+func testOutboxClaimLease(t *testing.T, r Repos) {
+	ctx := context.Background()
+	now := baseTime.Add(time.Hour)
+	aggregateID := uuid.New()
+	first := mustAppendOutbox(t, r, aggregateID, "first", baseTime)
+	mustAppendOutbox(t, r, aggregateID, "second", baseTime)
+
+	if got := mustClaim(t, r, now, 10); !equalStrings(got, []string{"first"}) {
+		t.Fatalf("ClaimPending = %v, want [first]", got)
+	}
+	if got := mustClaim(t, r, now.Add(outboxLease-time.Second), 10); len(got) != 0 {
+		t.Fatalf("ClaimPending during lease = %v, want empty", got)
+	}
+	// 결과를 기록하지 못한 채 lease 가 지나면 다시 선점
+	if got := mustClaim(t, r, now.Add(outboxLease), 10); !equalStrings(got, []string{"first"}) {
+		t.Fatalf("ClaimPending after lease = %v, want [first]", got)
+	}
+
+	if err := r.Outbox.MarkPublished(ctx, first.ID, now); err != nil {
+		t.Fatalf("MarkPublished: %v", err)
+	}
+	if got := mustClaim(t, r, now.Add(outboxLease), 10); !equalStrings(got, []string{"second"}) {
+		t.Fatalf("ClaimPending after publish = %v, want [second]", got)
+	}
+}
+
+func testOutboxMarkRetry(t *testing.T, r Repos) {
+	ctx := context.Background()
+	now := baseTime.Add(time.Hour)
+	a, b := uuid.New(), uuid.New()
+	retried := mustAppendOutbox(t, r, a, "a1", baseTime)
+	mustAppendOutbox(t, r, a, "a2", baseTime)
+	failed := mustAppendOutbox(t, r, b, "b1", baseTime)
+	mustAppendOutbox(t, r, b, "b2", baseTime)
+	mustClaim(t, r, now, 10)
+
+	// 재시도: 선점을 풀고 nextAttemptAt 까지 대기, 뒤 이벤트는 계속 막음
+	if err := r.Outbox.MarkRetry(ctx, retried.ID, "timeout", now.Add(time.Hour), false); err != nil {
+		t.Fatalf("MarkRetry: %v", err)
+	}
+	// failed: 더 이상 발행하지 않고 뒤 이벤트도 막지 않음
+	if err := r.Outbox.MarkRetry(ctx, failed.ID, "rejected", now, true); err != nil {
+		t.Fatalf("MarkRetry(failed): %v", err)
+	}
+
+	if got := mustClaim(t, r, now, 10); !equalStrings(got, []string{"b2"}) {
+		t.Fatalf("ClaimPending after MarkRetry = %v, want [b2]", got)
+	}
+
+	// b2 의 lease 도 끝나므로 sequence 가 앞선 a1 만 확인
+	events, err := r.Outbox.ClaimPending(ctx, now.Add(time.Hour), outboxLease, 1)
+	if err != nil || len(events) != 1 || events[0].ID != retried.ID {
+		t.Fatalf("ClaimPending after backoff = %v, %v; want [a1]", events, err)
+	}
+	if events[0].Attempts != 1 || events[0].LastError != "timeout" || events[0].Status != models.OutboxStatusPending {
+		t.Fatalf("retried event = %+v", events[0])
+	}
+}
+
+func testOutboxDeletePublishedBefore(t *testing.T, r Repos) {
+	ctx := context.Background()
+	now := baseTime.Add(time.Hour)
+	old := mustAppendOutbox(t, r, uuid.New(), "old", baseTime)
+	recent := mustAppendOutbox(t, r, uuid.New(), "recent", baseTime)
+	mustAppendOutbox(t, r, uuid.New(), "pending", baseTime)
+
+	if err := r.Outbox.MarkPublished(ctx, old.ID, now.Add(-time.Minute)); err != nil {
+		t.Fatalf("MarkPublished(old): %v", err)
+	}
+	if err := r.Outbox.MarkPublished(ctx, recent.ID, now); err != nil {
+		t.Fatalf("MarkPublished(recent): %v", err)
+	}
+
+	deleted, err := r.Outbox.DeletePublishedBefore(ctx, now)
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeletePublishedBefore = %d, %v; want 1", deleted, err)
+	}
+	// pending 이벤트는 남아 있음
+	if got := mustClaim(t, r, now, 10); !equalStrings(got, []string{"pending"}) {
+		t.Fatalf("ClaimPending after cleanup = %v, want [pending]", got)
+	}
+}
+
+// -------------------------
+// PersistedQueriesRepository
+// -------------------------
+
+func testPersistedQuerySaveAndFind(t *testing.T, r Repos) {
+	ctx := context.Background()
+	hash := strings.Repeat("ab", 32)
+
+	if got, err := r.PersistedQueries.Find(ctx, hash); err != nil || got != nil {
+		t.Fatalf("Find(unknown) = %v, %v; want nil, nil", got, err)
+	}
+
+	if err := r.PersistedQueries.Save(ctx, &models.PersistedQuery{Hash: hash, Query: "{ me { id } }"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// 같은 hash 는 무시하고 처음 저장한 쿼리를 유지
+	if err := r.PersistedQueries.Save(ctx, &models.PersistedQuery{Hash: hash, Query: "{ other }"}); err != nil {
+		t.Fatalf("Save(duplicate): %v", err)
+	}
+
+	got, err := r.PersistedQueries.Find(ctx, hash)
+	if err != nil || got == nil || got.Query != "{ me { id } }" {
+		t.Fatalf("Find = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,57 @@
+package repositorytest
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rainbow96bear/planet_user_server/internal/migration"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TEST_DATABASE_DSN 이 설정된 경우에만 실제 PostgreSQL 에 대해 테스트를 실행합니다.
+// 테스트마다 테이블을 비우므로 전용 DB 를 사용하세요.
+//
+//	TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=planet_test sslmode=disable" go test ./...
+const TestDSNEnv = "TEST_DATABASE_DSN"
+
+// OpenPostgres: TEST_DATABASE_DSN 에 연결해 migration 을 적용합니다. 설정되지 않았으면 테스트를 건너뜁니다.
+func OpenPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(TestDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", TestDSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+	return db
+}
+
+// Truncate: 테이블을 비웁니다. (CASCADE)
+func Truncate(t *testing.T, db *gorm.DB, tables ...string) {
+	t.Helper()
+
+	if err := db.Exec("TRUNCATE " + strings.Join(tables, ", ") + " CASCADE").Error; err != nil {
+		t.Fatalf("truncate tables: %v", err)
+	}
+}
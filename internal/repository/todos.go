@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
 	"github.com/rainbow96bear/planet_user_server/internal/tx"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -52,20 +53,20 @@ func (r *TodosRepository) CreateTodos(
 	todos []models.Todo,
 ) error {
 	if len(todos) == 0 {
-		logger.Debugf("[TodosRepo] no todos to create")
+		logctx.Debugf(ctx, "[TodosRepo] no todos to create")
 		return nil
 	}
 
 	db := r.getDB(ctx)
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[TodosRepo] creating %d todos (calendar_event_id=%s)",
 		len(todos),
 		todos[0].CalendarEventID,
 	)
 
 	if err := db.WithContext(ctx).Create(&todos).Error; err != nil {
-		logger.Errorf(
+		logctx.Errorf(ctx,
 			"[TodosRepo] failed to create todos (calendar_event_id=%s): %v",
 			todos[0].CalendarEventID,
 			err,
@@ -73,7 +74,7 @@ func (r *TodosRepository) CreateTodos(
 		return fmt.Errorf("failed to create todos: %w", err)
 	}
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[TodosRepo] successfully created %d todos (calendar_event_id=%s)",
 		len(todos),
 		todos[0].CalendarEventID,
@@ -84,12 +85,15 @@ func (r *TodosRepository) CreateTodos(
 
 // -------------------------
 // Todo 상태 업데이트
+// expectedVersion 이 주어졌는데 DB의 version 과 다르면
+// 현재 Todo 와 함께 ErrVersionConflict 를 반환합니다.
 // -------------------------
 func (r *TodosRepository) UpdateTodoStatus(
 	ctx context.Context,
 	userID uuid.UUID,
 	todoID uuid.UUID,
 	isDone bool,
+	expectedVersion *int32,
 ) (*models.Todo, error) {
 
 	db := r.getDB(ctx)
@@ -108,12 +112,37 @@ func (r *TodosRepository) UpdateTodoStatus(
 		return nil, err
 	}
 
-	// 2️⃣ 상태 변경
-	todo.IsDone = isDone
-	if err := db.Save(&todo).Error; err != nil {
-		return nil, err
+	expected := todo.Version
+	if expectedVersion != nil {
+		expected = *expectedVersion
+	}
+	if expected != todo.Version {
+		return &todo, ErrVersionConflict
+	}
+
+	// 2️⃣ 상태 변경 (version 일치 시에만)
+	now := time.Now()
+	result := db.Model(&models.Todo{}).
+		Where("id = ? AND version = ?", todo.ID, expected).
+		Updates(map[string]interface{}{
+			"is_done":    isDone,
+			"updated_at": now,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if err := db.First(&todo, "id = ?", todo.ID).Error; err != nil {
+			return nil, err
+		}
+		return &todo, ErrVersionConflict
 	}
 
+	todo.IsDone = isDone
+	todo.UpdatedAt = now
+	todo.Version = expected + 1
+
 	return &todo, nil
 }
 
@@ -124,7 +153,6 @@ func (r *TodosRepository) FindByID(
 	db := r.getDB(ctx)
 	var todo models.Todo
 	err := db.WithContext(ctx).
-		Preload("CalendarEvent").
 		First(&todo, "id = ?", id).
 		Error
 
@@ -138,6 +166,28 @@ func (r *TodosRepository) FindByID(
 	return &todo, nil
 }
 
+// FindByEventIDs: 여러 일정의 Todo 를 한 번에 조회합니다. (DataLoader 용)
+func (r *TodosRepository) FindByEventIDs(
+	ctx context.Context,
+	eventIDs []uuid.UUID,
+) ([]models.Todo, error) {
+	db := r.getDB(ctx)
+
+	if len(eventIDs) == 0 {
+		return []models.Todo{}, nil
+	}
+
+	var todos []models.Todo
+	if err := db.
+		Where("calendar_event_id IN ?", eventIDs).
+		Order("created_at ASC").
+		Find(&todos).Error; err != nil {
+		return nil, fmt.Errorf("failed to find todos by event ids: %w", err)
+	}
+
+	return todos, nil
+}
+
 // // -------------------------
 // // EventID 기반 Todo 조회
 // // -------------------------
@@ -13,13 +13,42 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/graph"
 	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/mapper"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
 	"github.com/rainbow96bear/planet_user_server/middleware"
 	"github.com/rainbow96bear/planet_user_server/utils"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 )
 
+// Todos is the resolver for the todos field.
+func (r *calendarResolver) Todos(ctx context.Context, obj *model.Calendar) ([]*models.Todo, error) {
+	// 단건 조회 등에서 이미 함께 조회된 경우
+	if obj.Todos != nil {
+		return obj.Todos, nil
+	}
+
+	// 월별 목록 등 Todo 없이 조회된 일정은 요청 단위로 모아 한 번에 조회
+	eventID, err := uuid.Parse(obj.ID)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	todos, err := r.loaders(ctx).TodosByEventID.Load(ctx, eventID)
+	if err != nil {
+		logctx.Errorf(ctx, "load todos failed eventID=%s: %v", obj.ID, err)
+		return nil, errors.New("failed to get todos")
+	}
+
+	result := make([]*models.Todo, 0, len(todos))
+	for i := range todos {
+		result = append(result, mapper.ToTodoGraphQL(&todos[i]))
+	}
+	return result, nil
+}
+
 // CreateCalendarEvent is the resolver for the createCalendarEvent field.
 func (r *mutationResolver) CreateCalendarEvent(ctx context.Context, input model.CreateCalendarInput) (*model.Calendar, error) {
 	token, err := middleware.ExtractAccessToken(ctx)
@@ -32,14 +61,20 @@ func (r *mutationResolver) CreateCalendarEvent(ctx context.Context, input model.
 		return nil, fmt.Errorf("invalid user id format: %w", err)
 	}
 
-	calendar := dto.ToCalendarModel(input, userID)
+	// 같은 키의 재시도에는 새 일정을 만들지 않고 최초 응답을 반환
+	key := idempotencyKey(ctx, input.ClientMutationID)
 
-	created, err := r.CalendarService.CreateCalendarEvent(ctx, calendar)
-	if err != nil {
-		return nil, err
-	}
+	return service.Idempotent(ctx, r.IdempotencyService, userID, "createCalendarEvent", key, input,
+		func(ctx context.Context) (*model.Calendar, error) {
+			calendar := dto.ToCalendarModel(input, userID)
 
-	return dto.ToCalendarGraphQL(created), nil
+			created, err := r.CalendarService.CreateCalendarEvent(ctx, calendar)
+			if err != nil {
+				return nil, err
+			}
+
+			return dto.ToCalendarGraphQL(created), nil
+		})
 }
 
 // UpdateCalendarEvent is the resolver for the updateCalendarEvent field.
@@ -47,6 +82,7 @@ func (r *mutationResolver) UpdateCalendarEvent(
 	ctx context.Context,
 	eventID string,
 	input model.UpdateCalendarInput,
+	expectedVersion *int32,
 ) (*model.Calendar, error) {
 
 	token, err := middleware.ExtractAccessToken(ctx)
@@ -69,6 +105,7 @@ func (r *mutationResolver) UpdateCalendarEvent(
 		userID,
 		eventUUID,
 		input,
+		expectedVersion,
 	)
 	if err != nil {
 		return nil, err
@@ -86,13 +123,13 @@ func (r *mutationResolver) DeleteCalendarEvent(ctx context.Context, eventID stri
 
 	userID, err := utils.GetUserID(token)
 	if err != nil {
-		logger.Warnf("invalid token")
+		logctx.Warnf(ctx, "invalid token")
 		return false, errors.New("unauthorized")
 	}
 
 	eventUUID, err := uuid.Parse(eventID)
 	if err != nil {
-		logger.Warnf("invalid eventID: %s", eventID)
+		logctx.Warnf(ctx, "invalid eventID: %s", eventID)
 		return false, errors.New("invalid event id")
 	}
 
@@ -103,10 +140,41 @@ func (r *mutationResolver) DeleteCalendarEvent(ctx context.Context, eventID stri
 	return true, nil
 }
 
+// DuplicateCalendarEvent is the resolver for the duplicateCalendarEvent field.
+func (r *mutationResolver) DuplicateCalendarEvent(ctx context.Context, eventID string, newStartAt time.Time) (*model.Calendar, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	request := map[string]interface{}{"eventId": eventID, "newStartAt": newStartAt}
+	key := idempotencyKey(ctx, nil)
+
+	return service.Idempotent(ctx, r.IdempotencyService, userID, "duplicateCalendarEvent", key, request,
+		func(ctx context.Context) (*model.Calendar, error) {
+			event, err := r.CalendarService.DuplicateCalendarEvent(ctx, userID, eventUUID, newStartAt)
+			if err != nil {
+				return nil, err
+			}
+
+			return mapper.ToCalendarGraphQL(event), nil
+		})
+}
+
 // MyCalendarEvents is the resolver for the myCalendarEvents field.
 func (r *queryResolver) MyCalendarEvents(ctx context.Context, year int32, month int32) ([]*model.Calendar, error) {
-	logger.Infof("MyCalendarEvents start year=%d month=%d", year, month)
-	defer logger.Infof("MyCalendarEvents end year=%d month=%d", year, month)
+	logctx.Infof(ctx, "MyCalendarEvents start year=%d month=%d", year, month)
+	defer logctx.Infof(ctx, "MyCalendarEvents end year=%d month=%d", year, month)
 
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
@@ -115,7 +183,7 @@ func (r *queryResolver) MyCalendarEvents(ctx context.Context, year int32, month
 
 	userID, err := utils.GetUserID(token)
 	if err != nil {
-		logger.Warnf("invalid token")
+		logctx.Warnf(ctx, "invalid token")
 		return nil, errors.New("unauthorized")
 	}
 	events, err := r.CalendarService.GetMyCalendarEvents(
@@ -125,7 +193,7 @@ func (r *queryResolver) MyCalendarEvents(ctx context.Context, year int32, month
 		int(month),
 	)
 	if err != nil {
-		logger.Errorf("GetMyCalendarEvents failed: %v", err)
+		logctx.Errorf(ctx, "GetMyCalendarEvents failed: %v", err)
 		return nil, errors.New("failed to get calendar events")
 	}
 
@@ -138,8 +206,8 @@ func (r *queryResolver) MyCalendarEvent(
 	eventID string,
 ) (*model.Calendar, error) {
 
-	logger.Infof("MyCalendarEvent start eventID=%s", eventID)
-	defer logger.Infof("MyCalendarEvent end eventID=%s", eventID)
+	logctx.Infof(ctx, "MyCalendarEvent start eventID=%s", eventID)
+	defer logctx.Infof(ctx, "MyCalendarEvent end eventID=%s", eventID)
 
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
@@ -148,13 +216,13 @@ func (r *queryResolver) MyCalendarEvent(
 
 	userID, err := utils.GetUserID(token)
 	if err != nil {
-		logger.Warnf("invalid token")
+		logctx.Warnf(ctx, "invalid token")
 		return nil, errors.New("unauthorized")
 	}
 
 	eventUUID, err := uuid.Parse(eventID)
 	if err != nil {
-		logger.Warnf("invalid eventID: %s", eventID)
+		logctx.Warnf(ctx, "invalid eventID: %s", eventID)
 		return nil, errors.New("invalid event id")
 	}
 
@@ -164,7 +232,7 @@ func (r *queryResolver) MyCalendarEvent(
 		eventUUID,
 	)
 	if err != nil {
-		logger.Errorf("GetEventDetailWithTodosByID failed: %v", err)
+		logctx.Errorf(ctx, "GetEventDetailWithTodosByID failed: %v", err)
 		return nil, err
 	}
 
@@ -173,8 +241,8 @@ func (r *queryResolver) MyCalendarEvent(
 
 // MyCalendarEventsByDate is the resolver for the myCalendarEventsByDate field.
 func (r *queryResolver) MyCalendarEventsByDate(ctx context.Context, date time.Time) ([]*model.Calendar, error) {
-	logger.Infof("MyCalendarEventsByDate start date=%s", date.Format(time.RFC3339))
-	defer logger.Infof("MyCalendarEventsByDate end date=%s", date.Format(time.RFC3339))
+	logctx.Infof(ctx, "MyCalendarEventsByDate start date=%s", date.Format(time.RFC3339))
+	defer logctx.Infof(ctx, "MyCalendarEventsByDate end date=%s", date.Format(time.RFC3339))
 
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
@@ -183,7 +251,7 @@ func (r *queryResolver) MyCalendarEventsByDate(ctx context.Context, date time.Ti
 
 	userID, err := utils.GetUserID(token)
 	if err != nil {
-		logger.Warnf("invalid token")
+		logctx.Warnf(ctx, "invalid token")
 		return nil, errors.New("unauthorized")
 	}
 
@@ -193,7 +261,7 @@ func (r *queryResolver) MyCalendarEventsByDate(ctx context.Context, date time.Ti
 		date, // time.Time 타입
 	)
 	if err != nil {
-		logger.Errorf("GetMyCalendarEventsByDate failed: %v", err)
+		logctx.Errorf(ctx, "GetMyCalendarEventsByDate failed: %v", err)
 		return nil, errors.New("failed to get calendar events")
 	}
 
@@ -204,3 +272,8 @@ func (r *queryResolver) MyCalendarEventsByDate(ctx context.Context, date time.Ti
 func (r *queryResolver) UserCalendarEvents(ctx context.Context, userID string, year int32, month int32) ([]*model.Calendar, error) {
 	panic(fmt.Errorf("not implemented: UserCalendarEvents - userCalendarEvents"))
 }
+
+// Calendar returns graph.CalendarResolver implementation.
+func (r *Resolver) Calendar() graph.CalendarResolver { return &calendarResolver{r} }
+
+type calendarResolver struct{ *Resolver }
@@ -0,0 +1,171 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/mapper"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"github.com/rainbow96bear/planet_user_server/utils"
+)
+
+// CreateEventTemplate is the resolver for the createEventTemplate field.
+func (r *mutationResolver) CreateEventTemplate(ctx context.Context, input model.CreateEventTemplateInput) (*model.EventTemplate, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	template, err := r.EventTemplateService.CreateTemplate(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEventTemplateGraphQL(template), nil
+}
+
+// UpdateEventTemplate is the resolver for the updateEventTemplate field.
+func (r *mutationResolver) UpdateEventTemplate(ctx context.Context, templateID string, input model.UpdateEventTemplateInput) (*model.EventTemplate, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		return nil, errors.New("invalid template id")
+	}
+
+	template, err := r.EventTemplateService.UpdateTemplate(ctx, userID, templateUUID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEventTemplateGraphQL(template), nil
+}
+
+// DeleteEventTemplate is the resolver for the deleteEventTemplate field.
+func (r *mutationResolver) DeleteEventTemplate(ctx context.Context, templateID string) (bool, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return false, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid token")
+		return false, errors.New("unauthorized")
+	}
+
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid templateID: %s", templateID)
+		return false, errors.New("invalid template id")
+	}
+
+	if err := r.EventTemplateService.DeleteTemplate(ctx, userID, templateUUID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CreateEventFromTemplate is the resolver for the createEventFromTemplate field.
+func (r *mutationResolver) CreateEventFromTemplate(ctx context.Context, templateID string, startAt time.Time) (*model.Calendar, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		return nil, errors.New("invalid template id")
+	}
+
+	request := map[string]interface{}{"templateId": templateID, "startAt": startAt}
+	key := idempotencyKey(ctx, nil)
+
+	return service.Idempotent(ctx, r.IdempotencyService, userID, "createEventFromTemplate", key, request,
+		func(ctx context.Context) (*model.Calendar, error) {
+			event, err := r.EventTemplateService.CreateEventFromTemplate(ctx, userID, templateUUID, startAt)
+			if err != nil {
+				return nil, err
+			}
+
+			return mapper.ToCalendarGraphQL(event), nil
+		})
+}
+
+// MyEventTemplates is the resolver for the myEventTemplates field.
+func (r *queryResolver) MyEventTemplates(ctx context.Context) ([]*model.EventTemplate, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid token")
+		return nil, errors.New("unauthorized")
+	}
+
+	templates, err := r.EventTemplateService.GetMyTemplates(ctx, userID)
+	if err != nil {
+		logctx.Errorf(ctx, "GetMyTemplates failed: %v", err)
+		return nil, errors.New("failed to get event templates")
+	}
+
+	return mapper.ToEventTemplateGraphQLList(templates), nil
+}
+
+// MyEventTemplate is the resolver for the myEventTemplate field.
+func (r *queryResolver) MyEventTemplate(ctx context.Context, templateID string) (*model.EventTemplate, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid token")
+		return nil, errors.New("unauthorized")
+	}
+
+	templateUUID, err := uuid.Parse(templateID)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid templateID: %s", templateID)
+		return nil, errors.New("invalid template id")
+	}
+
+	template, err := r.EventTemplateService.GetMyTemplate(ctx, userID, templateUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEventTemplateGraphQL(template), nil
+}
@@ -9,14 +9,14 @@ import (
 	"context"
 
 	"github.com/rainbow96bear/planet_user_server/graph/model"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 )
 
 // CheckNicknameAvailability is the resolver for the checkNicknameAvailability field.
 func (r *queryResolver) CheckNicknameAvailability(ctx context.Context, nickname string) (*model.NicknameAvailability, error) {
 	available, err := r.ProfileService.IsNicknameAvailable(ctx, nickname)
 	if err != nil {
-		logger.Errorf("%s", err.Error())
+		logctx.Errorf(ctx, "%s", err.Error())
 		msg := "서버 오류가 발생했습니다"
 		return &model.NicknameAvailability{
 			Available: false,
@@ -13,23 +13,24 @@ import (
 	jwt "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/graph"
 	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/dataloader"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
 	"github.com/rainbow96bear/planet_user_server/middleware"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"github.com/rainbow96bear/planet_user_server/utils"
 )
 
 // UpdateMyProfile is the resolver for the updateMyProfile field.
-func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.UpdateProfileInput) (*model.UserProfile, error) {
-	logger.Infof("UpdateMyProfile start")
+func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.UpdateProfileInput, expectedVersion *int32) (*model.UserProfile, error) {
+	logctx.Infof(ctx, "UpdateMyProfile start")
 	// Authorization 헤더에서 access token 가져오기
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debugf("access token : %s", token)
-
 	// JWT 디코딩 (서명 검증은 서비스에서 처리할 수도 있음)
 
 	claims, ok := token.Claims.(jwt.MapClaims)
@@ -50,11 +51,12 @@ func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.Upda
 
 	// DTO 생성
 	updateDTO := &dto.ProfileUpdate{
-		UserID:       userID,
-		Nickname:     input.Nickname,
-		Bio:          input.Bio,
-		ProfileImage: input.ProfileImage,
-		Theme:        input.Theme,
+		UserID:          userID,
+		Nickname:        input.Nickname,
+		Bio:             input.Bio,
+		ProfileImage:    input.ProfileImage,
+		Theme:           input.Theme,
+		ExpectedVersion: expectedVersion,
 	}
 
 	// 서비스 호출
@@ -64,6 +66,10 @@ func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.Upda
 		if errors.Is(err, planet_err.ErrNicknameDuplicate) {
 			return nil, planet_err.ErrNicknameDuplicate
 		}
+		// version 충돌은 CONFLICT 코드/최신본을 그대로 전달
+		if errors.Is(err, planet_err.ErrVersionConflict) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to update profile: %w", err)
 	}
 
@@ -77,6 +83,7 @@ func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.Upda
 		Theme:          updatedDTO.Theme,
 		FollowerCount:  updatedDTO.FollowerCount,
 		FollowingCount: updatedDTO.FollowingCount,
+		Version:        updatedDTO.Version,
 	}
 
 	return updatedProfile, nil
@@ -84,16 +91,14 @@ func (r *mutationResolver) UpdateMyProfile(ctx context.Context, input model.Upda
 
 // MyProfile is the resolver for the myProfile field.
 func (r *queryResolver) MyProfile(ctx context.Context) (*model.UserProfile, error) {
-	logger.Infof("GetMyProfileInfo start")
-	defer logger.Infof("GetMyProfileInfo end")
+	logctx.Infof(ctx, "GetMyProfileInfo start")
+	defer logctx.Infof(ctx, "GetMyProfileInfo end")
 
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debugf("access token : %s", token)
-
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		return nil, errors.New("invalid token claims")
@@ -127,31 +132,36 @@ func (r *queryResolver) MyProfile(ctx context.Context) (*model.UserProfile, erro
 		FollowerCount:  dtoProfile.FollowerCount,
 		FollowingCount: dtoProfile.FollowingCount,
 		Theme:          dtoProfile.Theme,
+		Version:        dtoProfile.Version,
 	}, nil
 }
 
 // UserProfile is the resolver for the userProfile field.
 func (r *queryResolver) UserProfile(ctx context.Context, userID string) (*model.UserProfile, error) {
-	logger.Infof("GetUserProfile start, userID=%s", userID)
-	defer logger.Infof("GetUserProfile end, userID=%s", userID)
+	logctx.Infof(ctx, "GetUserProfile start, userID=%s", userID)
+	defer logctx.Infof(ctx, "GetUserProfile end, userID=%s", userID)
 
 	// 1. userID 파싱
 	parsedUserID, err := uuid.Parse(userID)
 	if err != nil {
-		logger.Warnf("invalid userID format: %s", userID)
+		logctx.Warnf(ctx, "invalid userID format: %s", userID)
 		return nil, errors.New("invalid user id")
 	}
 
-	// 2. 서비스 호출 (공개 프로필 조회)
-	dtoProfile, err := r.ProfileService.GetUserProfileInfo(ctx, parsedUserID)
+	// 2. 프로필 조회 (같은 요청의 다른 userProfile 호출과 한 번에 조회)
+	profile, err := r.loaders(ctx).ProfileByUserID.Load(ctx, parsedUserID)
 	if err != nil {
-		logger.Errorf(
+		logctx.Errorf(ctx,
 			"GetUserProfile failed, userID=%s, err=%v",
 			userID,
 			err,
 		)
 		return nil, err
 	}
+	if profile == nil {
+		return nil, planet_err.ErrNotFound
+	}
+	dtoProfile := dto.ToUserProfile(profile)
 
 	// 3. DTO → GraphQL Model 변환
 	result := &model.UserProfile{
@@ -161,6 +171,7 @@ func (r *queryResolver) UserProfile(ctx context.Context, userID string) (*model.
 		FollowerCount:  dtoProfile.FollowerCount,
 		FollowingCount: dtoProfile.FollowingCount,
 		Theme:          dtoProfile.Theme,
+		Version:        dtoProfile.Version,
 	}
 
 	if dtoProfile.Bio != "" {
@@ -172,3 +183,31 @@ func (r *queryResolver) UserProfile(ctx context.Context, userID string) (*model.
 
 	return result, nil
 }
+
+// FollowedByMe is the resolver for the followedByMe field.
+func (r *userProfileResolver) FollowedByMe(ctx context.Context, obj *model.UserProfile) (bool, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return false, nil // 비로그인 조회
+	}
+
+	viewerID, err := utils.GetUserID(token)
+	if err != nil {
+		return false, nil
+	}
+
+	targetID, err := uuid.Parse(obj.UserID)
+	if err != nil || targetID == viewerID {
+		return false, nil
+	}
+
+	return r.loaders(ctx).FollowState.Load(ctx, dataloader.FollowKey{
+		ViewerID: viewerID,
+		TargetID: targetID,
+	})
+}
+
+// UserProfile returns graph.UserProfileResolver implementation.
+func (r *Resolver) UserProfile() graph.UserProfileResolver { return &userProfileResolver{r} }
+
+type userProfileResolver struct{ *Resolver }
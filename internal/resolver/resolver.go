@@ -1,6 +1,13 @@
 package resolver
 
-import "github.com/rainbow96bear/planet_user_server/internal/service"
+import (
+	"context"
+
+	"github.com/rainbow96bear/planet_user_server/internal/dataloader"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+)
 
 // This file will not be regenerated automatically.
 //
@@ -8,19 +15,48 @@ import "github.com/rainbow96bear/planet_user_server/internal/service"
 // here.
 
 type Resolver struct {
-	ProfileService  service.ProfileServiceInterface
-	CalendarService service.CalendarServiceInterface
-	TodoService     service.TodoServiceInterface
+	ProfileService       service.ProfileServiceInterface
+	CalendarService      service.CalendarServiceInterface
+	TodoService          service.TodoServiceInterface
+	EventTemplateService service.EventTemplateServiceInterface
+	IdempotencyService   service.IdempotencyServiceInterface
+	Hub                  *pubsub.Hub
+	Loaders              *dataloader.Factory
 }
 
 func NewResolver(
 	profileSvc service.ProfileServiceInterface,
 	calendarSvc service.CalendarServiceInterface,
 	todoSvc service.TodoServiceInterface,
+	eventTemplateSvc service.EventTemplateServiceInterface,
+	idempotencySvc service.IdempotencyServiceInterface,
+	hub *pubsub.Hub,
+	loaders *dataloader.Factory,
 ) *Resolver {
 	return &Resolver{
-		ProfileService:  profileSvc,
-		CalendarService: calendarSvc,
-		TodoService:     todoSvc,
+		ProfileService:       profileSvc,
+		CalendarService:      calendarSvc,
+		TodoService:          todoSvc,
+		EventTemplateService: eventTemplateSvc,
+		IdempotencyService:   idempotencySvc,
+		Hub:                  hub,
+		Loaders:              loaders,
+	}
+}
+
+// loaders: GraphQL 라우트 미들웨어가 설치한 요청 단위 DataLoader.
+// 없으면 (websocket 구독 등) 호출마다 새로 만들어 캐시가 오래 남지 않게 합니다.
+func (r *Resolver) loaders(ctx context.Context) *dataloader.Loaders {
+	if loaders := dataloader.For(ctx); loaders != nil {
+		return loaders
+	}
+	return r.Loaders.New(ctx)
+}
+
+// idempotencyKey: clientMutationId 가 있으면 우선 사용하고, 없으면 Idempotency-Key 헤더 값을 사용합니다.
+func idempotencyKey(ctx context.Context, clientMutationID *string) string {
+	if clientMutationID != nil && *clientMutationID != "" {
+		return *clientMutationID
 	}
+	return middleware.ExtractIdempotencyKey(ctx)
 }
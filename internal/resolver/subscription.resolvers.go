@@ -0,0 +1,140 @@
+package resolver
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/graph"
+	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/mapper"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"github.com/rainbow96bear/planet_user_server/utils"
+)
+
+// CalendarEventChanged is the resolver for the calendarEventChanged field.
+func (r *subscriptionResolver) CalendarEventChanged(ctx context.Context, calendarID string) (<-chan *model.CalendarEventChange, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	viewerID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	ownerID, err := uuid.Parse(calendarID)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid calendarID: %s", calendarID)
+		return nil, errors.New("invalid calendar id")
+	}
+	isOwner := viewerID == ownerID
+
+	logctx.Infof(ctx, "CalendarEventChanged subscribe viewer=%s calendar=%s", viewerID, ownerID)
+
+	messages, unsubscribe := r.Hub.Subscribe(pubsub.CalendarTopic(ownerID))
+	out := make(chan *model.CalendarEventChange, 1)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				change, ok := msg.(pubsub.CalendarEventChange)
+				if !ok {
+					continue
+				}
+				// 다른 사용자의 캘린더는 public 일정만 전달 (비공개로 바뀌면 삭제 알림)
+				change, ok = change.ForViewer(isOwner)
+				if !ok {
+					continue
+				}
+
+				select {
+				case out <- mapper.ToCalendarEventChangeGraphQL(change):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// TodoChanged is the resolver for the todoChanged field.
+func (r *subscriptionResolver) TodoChanged(ctx context.Context, eventID string) (<-chan *model.TodoChange, error) {
+	token, err := middleware.ExtractAccessToken(ctx)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	userID, err := utils.GetUserID(token)
+	if err != nil {
+		return nil, errors.New("unauthorized")
+	}
+
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		logctx.Warnf(ctx, "invalid eventID: %s", eventID)
+		return nil, errors.New("invalid event id")
+	}
+
+	// 본인 일정만 구독 가능
+	if _, err := r.CalendarService.GetEventDetailWithTodosByID(ctx, userID, eventUUID); err != nil {
+		return nil, err
+	}
+
+	logctx.Infof(ctx, "TodoChanged subscribe user=%s event=%s", userID, eventUUID)
+
+	messages, unsubscribe := r.Hub.Subscribe(pubsub.TodoTopic(eventUUID))
+	out := make(chan *model.TodoChange, 1)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				change, ok := msg.(pubsub.TodoChange)
+				if !ok {
+					continue
+				}
+
+				select {
+				case out <- mapper.ToTodoChangeGraphQL(change):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Subscription returns graph.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graph.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
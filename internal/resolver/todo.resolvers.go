@@ -18,7 +18,7 @@ import (
 )
 
 // UpdateTodoDone is the resolver for the updateTodoDone field.
-func (r *mutationResolver) UpdateTodoDone(ctx context.Context, id string, isDone bool) (*models.Todo, error) {
+func (r *mutationResolver) UpdateTodoDone(ctx context.Context, id string, isDone bool, expectedVersion *int32) (*models.Todo, error) {
 	// 인증
 	token, err := middleware.ExtractAccessToken(ctx)
 	if err != nil {
@@ -42,6 +42,7 @@ func (r *mutationResolver) UpdateTodoDone(ctx context.Context, id string, isDone
 		userID,
 		todoID,
 		isDone,
+		expectedVersion,
 	)
 }
 
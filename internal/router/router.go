@@ -2,18 +2,24 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
+	"github.com/rainbow96bear/planet_user_server/internal/tracing"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // SetupRouter는 Gin 엔진을 설정하고 반환하며, 라우트 등록을 위한 콜백 함수를 받습니다.
 func SetupRouter(registerRoutes func(r *gin.Engine)) *gin.Engine {
 	// 1. Gin 인스턴스 초기화
-	r := gin.Default()
+	// (gin.Default 의 텍스트 접근 로그는 쿼리 문자열까지 남기므로 JSON 접근 로그로 대체)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(otelgin.Middleware(tracing.ServiceName))
+	r.Use(metrics.GinMiddleware())
 
 	// 2. 콜백 함수 실행 (여기서 GraphQL 라우트가 등록됨)
 	registerRoutes(r)
 
-	// 필요하다면 전역 미들웨어를 여기서 추가합니다.
-	// r.Use(gin.Recovery())
-
 	return r
 }
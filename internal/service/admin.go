@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AdminServiceInterface: 운영용 CLI 서브커맨드 (seed / recount-follows / export-user / purge-expired)
+type AdminServiceInterface interface {
+	Seed(ctx context.Context, eventsPerUser int) (*dto.SeedResult, error)
+	RecountFollows(ctx context.Context) (int64, error)
+	ExportUser(ctx context.Context, userID uuid.UUID) (*dto.UserExport, error)
+	PurgeExpired(ctx context.Context, outboxRetention time.Duration) (*dto.PurgeResult, error)
+}
+
+type AdminService struct {
+	DB                 *gorm.DB
+	ProfileService     ProfileServiceInterface
+	CalendarService    CalendarServiceInterface
+	ProfilesRepo       repository.ProfileRepositoryInterface
+	CalendarEventsRepo repository.CalendarEventsRepositoryInterface
+	TemplatesRepo      repository.CalendarEventTemplatesRepositoryInterface
+	FollowsRepo        repository.FollowsRepositoryInterface
+	IdempotencyRepo    repository.IdempotencyKeysRepositoryInterface
+	OutboxRepo         repository.OutboxEventsRepositoryInterface
+}
+
+func NewAdminService(
+	db *gorm.DB,
+	profileService ProfileServiceInterface,
+	calendarService CalendarServiceInterface,
+	profilesRepo repository.ProfileRepositoryInterface,
+	calendarRepo repository.CalendarEventsRepositoryInterface,
+	templatesRepo repository.CalendarEventTemplatesRepositoryInterface,
+	followsRepo repository.FollowsRepositoryInterface,
+	idempotencyRepo repository.IdempotencyKeysRepositoryInterface,
+	outboxRepo repository.OutboxEventsRepositoryInterface,
+) AdminServiceInterface {
+	return &AdminService{
+		DB:                 db,
+		ProfileService:     profileService,
+		CalendarService:    calendarService,
+		ProfilesRepo:       profilesRepo,
+		CalendarEventsRepo: calendarRepo,
+		TemplatesRepo:      templatesRepo,
+		FollowsRepo:        followsRepo,
+		IdempotencyRepo:    idempotencyRepo,
+		OutboxRepo:         outboxRepo,
+	}
+}
+
+// seedNamespace: 데모 사용자 ID 는 닉네임에서 결정적으로 생성되므로 seed 를 여러 번 실행해도 중복되지 않습니다.
+var seedNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("planet_user_server/seed"))
+
+var seedUsers = []dto.CreateProfileRequest{
+	{Nickname: "demo_mercury", Bio: "데모 계정입니다.", Theme: "light"},
+	{Nickname: "demo_venus", Bio: "데모 계정입니다.", Theme: "dark"},
+	{Nickname: "demo_earth", Bio: "데모 계정입니다.", Theme: "light"},
+}
+
+var seedEvents = []struct {
+	Title string
+	Emoji string
+	Todos []string
+}{
+	{"운동", "🏃", []string{"스트레칭", "러닝 5km"}},
+	{"스터디", "📚", []string{"자료 읽기", "정리 노트 작성"}},
+	{"장보기", "🛒", []string{"우유", "계란", "과일"}},
+	{"친구 약속", "☕", []string{"장소 예약"}},
+	{"프로젝트 회의", "💼", []string{"안건 정리", "회의록 공유"}},
+}
+
+var seedVisibilities = []string{"public", "friends", "private"}
+
+// Seed: 데모 사용자 / 이번 달 일정 / 서로 팔로우 관계를 생성합니다.
+// 이미 존재하는 데모 사용자에게는 일정을 다시 만들지 않습니다.
+func (s *AdminService) Seed(ctx context.Context, eventsPerUser int) (*dto.SeedResult, error) {
+	result := &dto.SeedResult{}
+	userIDs := make([]uuid.UUID, 0, len(seedUsers))
+
+	// 1) 데모 사용자
+	for _, user := range seedUsers {
+		req := user
+		req.UserID = uuid.NewSHA1(seedNamespace, []byte(user.Nickname))
+
+		created, err := s.ProfileService.CreateProfile(ctx, req)
+		if err != nil {
+			return result, fmt.Errorf("failed to seed profile %s: %w", user.Nickname, err)
+		}
+		userIDs = append(userIDs, req.UserID)
+
+		if created.AlreadyExisted {
+			result.UsersExisting++
+			continue
+		}
+		result.UsersCreated++
+
+		// 2) 이번 달 일정 (Todo 포함)
+		n, err := s.seedEventsOf(ctx, req.UserID, eventsPerUser)
+		result.EventsCreated += n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// 3) 데모 사용자끼리 팔로우 (순환)
+	for i, follower := range userIDs {
+		followee := userIDs[(i+1)%len(userIDs)]
+
+		exists, err := s.FollowsRepo.IsFollow(ctx, follower, followee)
+		if err != nil {
+			return result, err
+		}
+		if exists {
+			continue
+		}
+		if err := s.FollowsRepo.FollowTx(ctx, s.DB, follower, followee); err != nil {
+			return result, err
+		}
+		result.FollowsAdded++
+	}
+
+	// 팔로우 카운트는 follows 기준으로 맞춥니다.
+	if _, err := s.RecountFollows(ctx); err != nil {
+		return result, err
+	}
+
+	logctx.Infof(ctx, "[AdminService] seed done: %+v", *result)
+	return result, nil
+}
+
+func (s *AdminService) seedEventsOf(ctx context.Context, userID uuid.UUID, count int) (int, error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	created := 0
+	for i := 0; i < count; i++ {
+		tmpl := seedEvents[i%len(seedEvents)]
+		startAt := monthStart.AddDate(0, 0, (i*3)%28).Add(9 * time.Hour)
+
+		event := &models.CalendarEvent{
+			ID:         uuid.New(),
+			UserID:     userID,
+			Title:      tmpl.Title,
+			Emoji:      tmpl.Emoji,
+			StartAt:    startAt,
+			EndAt:      startAt.Add(time.Hour),
+			Visibility: seedVisibilities[i%len(seedVisibilities)],
+			Version:    1,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		for _, content := range tmpl.Todos {
+			event.Todos = append(event.Todos, models.Todo{
+				ID:              uuid.New(),
+				CalendarEventID: event.ID,
+				Content:         content,
+				Version:         1,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			})
+		}
+
+		if _, err := s.CalendarService.CreateCalendarEvent(ctx, event); err != nil {
+			return created, fmt.Errorf("failed to seed calendar event: %w", err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// RecountFollows: follows 테이블 기준으로 FollowerCount / FollowingCount 를 다시 계산합니다.
+func (s *AdminService) RecountFollows(ctx context.Context) (int64, error) {
+	updated, err := s.ProfilesRepo.RecountFollowCounts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	logctx.Infof(ctx, "[AdminService] recount follows: %d profiles corrected", updated)
+	return updated, nil
+}
+
+// ExportUser: 프로필 / 일정 (Todo 포함) / 템플릿 / 팔로우 관계를 한 번에 조회합니다.
+func (s *AdminService) ExportUser(ctx context.Context, userID uuid.UUID) (*dto.UserExport, error) {
+	profile, err := s.ProfilesRepo.GetMyProfileInfo(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load profile %s: %w", userID, err)
+	}
+
+	events, err := s.CalendarEventsRepo.FindAllWithTodosByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.TemplatesRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	following, err := s.FollowsRepo.FindFolloweeIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := s.FollowsRepo.FindFollowerIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserExport{
+		ExportedAt:     time.Now().UTC(),
+		Profile:        profile,
+		CalendarEvents: events,
+		Templates:      templates,
+		Following:      following,
+		Followers:      followers,
+	}, nil
+}
+
+// PurgeExpired: 더 이상 필요 없는 운영 데이터를 정리합니다.
+// (만료된 idempotency key, outboxRetention 보다 오래전에 발행이 끝난 outbox 이벤트)
+func (s *AdminService) PurgeExpired(ctx context.Context, outboxRetention time.Duration) (*dto.PurgeResult, error) {
+	now := time.Now()
+	result := &dto.PurgeResult{}
+
+	keys, err := s.IdempotencyRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return result, err
+	}
+	result.ExpiredIdempotencyKeys = keys
+
+	events, err := s.OutboxRepo.DeletePublishedBefore(ctx, now.Add(-outboxRetention))
+	if err != nil {
+		return result, err
+	}
+	result.PublishedOutboxEvents = events
+
+	logctx.Infof(ctx, "[AdminService] purge expired: %+v", *result)
+	return result, nil
+}
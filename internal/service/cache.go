@@ -1,46 +1,57 @@
 package service
 
-// CalendarCacheItem: Todo가 없는 Event만 저장
-// type CalendarCacheItem struct {
-// 	Data      []*models.CalendarEvents
-// 	ExpiresAt time.Time
-// }
+import (
+	"context"
+	"time"
 
-// // key: "UserID:year:month:visibility"
-// var calendarCache sync.Map
-// var cacheTTL = 1 * time.Minute
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/calendarcache"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+)
 
-// // BuildCacheKey generates key for caching
-// func buildCacheKey(UserID uuid.UUID, year int, month int, visibility string) string {
-// 	UserIDStr := UserID.String()
-// 	return UserIDStr + ":" +
-// 		time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).Format("2006-01") +
-// 		":" + visibility
-// }
+// cacheableMonth: 조회 범위가 정확히 한 달 (UTC 1일 00:00 ~ 다음 달 1일) 일 때만 월별 캐시를 사용합니다.
+func cacheableMonth(startDate, endDate time.Time) (time.Time, bool) {
+	month := calendarcache.MonthStart(startDate)
+	if !startDate.Equal(month) || !endDate.Equal(month.AddDate(0, 1, 0)) {
+		return time.Time{}, false
+	}
+	return month, true
+}
 
-// // GetCalendarCache retrieves cached calendars if exists and not expired
-// func GetCalendarCache(UserID uuid.UUID, year int, month int, visibility string) ([]*models.CalendarEvents, bool) {
-// 	key := buildCacheKey(UserID, year, month, visibility)
-// 	if item, ok := calendarCache.Load(key); ok {
-// 		cacheItem, valid := item.(CalendarCacheItem)
-// 		if valid && time.Now().Before(cacheItem.ExpiresAt) {
-// 			return cacheItem.Data, true
-// 		}
-// 		calendarCache.Delete(key)
-// 	}
-// 	return nil, false
-// }
+func monthCacheKey(userID uuid.UUID, month time.Time, visibility string) calendarcache.Key {
+	return calendarcache.Key{
+		UserID:     userID,
+		Month:      month,
+		Visibility: visibility,
+	}
+}
 
-// // SetCalendarCache stores calendar data in cache (Event only)
-// func SetCalendarCache(UserID uuid.UUID, year int, month int, visibility string, data []*models.CalendarEvents) {
-// 	key := buildCacheKey(UserID, year, month, visibility)
-// 	calendarCache.Store(key, CalendarCacheItem{
-// 		Data:      data,
-// 		ExpiresAt: time.Now().Add(cacheTTL),
-// 	})
-// }
+// monthCacheKeysOf: 일정이 걸쳐 있는 모든 달의 캐시 키
+// 수정 전 스냅샷과 수정 후 일정을 함께 넘기면 이동 / 공개 범위 변경 전후 양쪽이 모두 무효화됩니다.
+func monthCacheKeysOf(events ...*models.CalendarEvent) []calendarcache.Key {
+	seen := make(map[calendarcache.Key]struct{})
+	keys := make([]calendarcache.Key, 0)
 
-// func DeleteCalendarCache(UserID uuid.UUID, year int, month int, visibility string) {
-// 	key := buildCacheKey(UserID, year, month, visibility)
-// 	calendarCache.Delete(key)
-// }
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		for _, month := range calendarcache.MonthsOverlapping(event.StartAt, event.EndAt) {
+			key := monthCacheKey(event.UserID, month, event.Visibility)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// invalidateMonthCache: 커밋 이후에 호출합니다. (롤백된 변경으로 캐시를 지우지 않도록)
+func invalidateMonthCache(ctx context.Context, cache calendarcache.Cache, keys []calendarcache.Key) {
+	if len(keys) == 0 {
+		return
+	}
+	cache.Delete(ctx, keys...)
+}
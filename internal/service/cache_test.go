@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/calendarcache"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+)
+
+type cacheFixture struct {
+	cache    *calendarcache.LRU
+	events   *memory.CalendarEventsRepository
+	calendar service.CalendarServiceInterface
+	profile  service.ProfileServiceInterface
+	userID   uuid.UUID
+}
+
+func newCacheFixture(t *testing.T) *cacheFixture {
+	t.Helper()
+
+	store := memory.NewStore()
+	profiles := memory.NewProfileRepository(store)
+	events := memory.NewCalendarEventsRepository(store)
+	writer := outbox.NewWriter(memory.NewOutboxEventsRepository(store))
+	cache := calendarcache.NewLRU(100, time.Hour)
+
+	f := &cacheFixture{
+		cache:    cache,
+		events:   events,
+		calendar: service.NewCalendarService(nil, profiles, events, pubsub.NewHub(), writer, cache),
+		profile: service.NewProfileService(nil, profiles, events,
+			memory.NewCalendarEventTemplatesRepository(store), memory.NewFollowsRepository(store), writer, cache),
+		userID: uuid.New(),
+	}
+	if err := profiles.Create(context.Background(), &models.Profile{UserID: f.userID, Nickname: "alice"}); err != nil {
+		t.Fatalf("seed profile: %v", err)
+	}
+	return f
+}
+
+func (f *cacheFixture) createEvent(t *testing.T, visibility string, startAt, endAt time.Time) *models.CalendarEvent {
+	t.Helper()
+
+	event, err := f.events.CreateCalendarEvent(context.Background(), &models.CalendarEvent{
+		ID:         uuid.New(),
+		UserID:     f.userID,
+		Title:      "trip",
+		Visibility: visibility,
+		StartAt:    startAt,
+		EndAt:      endAt,
+	})
+	if err != nil {
+		t.Fatalf("seed event: %v", err)
+	}
+	return event
+}
+
+func (f *cacheFixture) key(month time.Month, visibility string) calendarcache.Key {
+	return calendarcache.Key{UserID: f.userID, Month: time.Date(2025, month, 1, 0, 0, 0, 0, time.UTC), Visibility: visibility}
+}
+
+// fill: 검사할 월 캐시를 모두 채워 둡니다.
+func (f *cacheFixture) fill(keys ...calendarcache.Key) {
+	for _, key := range keys {
+		f.cache.Set(context.Background(), key, []*models.CalendarEvent{})
+	}
+}
+
+func (f *cacheFixture) expectCached(t *testing.T, want map[calendarcache.Key]bool) {
+	t.Helper()
+
+	for key, cached := range want {
+		if _, ok := f.cache.Get(context.Background(), key); ok != cached {
+			t.Errorf("cache %s present = %v, want %v", key, ok, cached)
+		}
+	}
+}
+
+func TestDeleteCalendarEventInvalidatesSpannedMonths(t *testing.T) {
+	f := newCacheFixture(t)
+	event := f.createEvent(t, "public",
+		time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	keys := map[calendarcache.Key]bool{
+		f.key(1, "public"):  false,
+		f.key(2, "public"):  false,
+		f.key(3, "public"):  false,
+		f.key(4, "public"):  true, // 일정이 없는 달
+		f.key(2, "private"): true, // 다른 공개 범위
+	}
+	for key := range keys {
+		f.fill(key)
+	}
+
+	if err := f.calendar.DeleteCalendarEvent(context.Background(), f.userID, event.ID); err != nil {
+		t.Fatalf("DeleteCalendarEvent: %v", err)
+	}
+	f.expectCached(t, keys)
+}
+
+func TestDeleteProfileInvalidatesMonthCache(t *testing.T) {
+	f := newCacheFixture(t)
+	f.createEvent(t, "public", time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC))
+	f.createEvent(t, "private", time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 10, 1, 0, 0, 0, time.UTC))
+
+	other := calendarcache.Key{UserID: uuid.New(), Month: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Visibility: "public"}
+	keys := map[calendarcache.Key]bool{
+		f.key(1, "public"):  false,
+		f.key(2, "public"):  false,
+		f.key(5, "private"): false,
+		f.key(3, "public"):  true,
+		other:               true, // 다른 사용자
+	}
+	for key := range keys {
+		f.fill(key)
+	}
+
+	if err := f.profile.DeleteProfile(context.Background(), f.userID); err != nil {
+		t.Fatalf("DeleteProfile: %v", err)
+	}
+	f.expectCached(t, keys)
+}
@@ -9,11 +9,16 @@ import (
 	"github.com/google/uuid"
 	"github.com/rainbow96bear/planet_user_server/dto"
 	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/calendarcache"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/mapper"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
 	"github.com/rainbow96bear/planet_user_server/internal/repository"
 	"github.com/rainbow96bear/planet_user_server/internal/tx"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -40,28 +45,47 @@ type CalendarServiceInterface interface {
 		userID uuid.UUID,
 		eventID uuid.UUID,
 		input model.UpdateCalendarInput,
+		expectedVersion *int32,
+	) (*models.CalendarEvent, error)
+	DuplicateCalendarEvent(
+		ctx context.Context,
+		userID uuid.UUID,
+		eventID uuid.UUID,
+		newStartAt time.Time,
 	) (*models.CalendarEvent, error)
 }
 
 type CalendarService struct {
 	DB                 *gorm.DB
-	ProfilesRepo       *repository.ProfileRepository
-	CalendarEventsRepo *repository.CalendarEventsRepository
+	ProfilesRepo       repository.ProfileRepositoryInterface
+	CalendarEventsRepo repository.CalendarEventsRepositoryInterface
 	// TodosRepo          *repository.TodosRepository
 	// FollowsRepo        *repository.FollowsRepoitory
+	Hub        *pubsub.Hub         // 커밋 이후 변경 알림 발행 (GraphQL Subscription)
+	Outbox     *outbox.Writer      // 트랜잭션 내 도메인 이벤트 기록 (다른 서비스 연동)
+	MonthCache calendarcache.Cache // 월별 조회 (Todo 없는 Event) 캐시, 일정 변경 커밋 후 해당 월 무효화
 }
 
 func NewCalendarService(
 	db *gorm.DB,
-	profilesRepo *repository.ProfileRepository,
-	calendarRepo *repository.CalendarEventsRepository,
+	profilesRepo repository.ProfileRepositoryInterface,
+	calendarRepo repository.CalendarEventsRepositoryInterface,
 	// todoRepo *repository.TodosRepository,
+	hub *pubsub.Hub,
+	outboxWriter *outbox.Writer,
+	monthCache calendarcache.Cache,
 ) CalendarServiceInterface {
+	if monthCache == nil {
+		monthCache = calendarcache.Noop{}
+	}
 	return &CalendarService{
 		DB:                 db,
 		ProfilesRepo:       profilesRepo,
 		CalendarEventsRepo: calendarRepo,
 		// TodosRepo:          todoRepo,
+		Hub:        hub,
+		Outbox:     outboxWriter,
+		MonthCache: monthCache,
 	}
 }
 
@@ -72,7 +96,7 @@ func (s *CalendarService) GetMyCalendarEvents(
 	year, month int,
 ) ([]*models.CalendarEvent, error) {
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[GetMyCalendarEvents] userID=%s year=%d month=%d",
 		userID, year, month,
 	)
@@ -95,7 +119,7 @@ func (s *CalendarService) GetEventDetailWithTodosByID(
 	eventID uuid.UUID,
 ) (*models.CalendarEvent, error) {
 
-	logger.Infof("[GetEventDetailWithTodosByID] eventID=%s userID=%s", eventID, userID)
+	logctx.Infof(ctx, "[GetEventDetailWithTodosByID] eventID=%s userID=%s", eventID, userID)
 
 	event, err := s.CalendarEventsRepo.GetEventWithTodosByID(ctx, eventID)
 	if err != nil {
@@ -115,7 +139,7 @@ func (s *CalendarService) GetEventDetailWithTodosByID(
 
 // // 내 일일 계획 조회 (일별, Event + Todo 포함, PlanHandler에서 호출)
 func (s *CalendarService) GetMyCalendarEventsByDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*model.Calendar, error) {
-	logger.Infof("[GetMyCalendarEventsByDate] UserID=%s, date=%s", userID, date.Format("2006-01-02"))
+	logctx.Infof(ctx, "[GetMyCalendarEventsByDate] UserID=%s, date=%s", userID, date.Format("2006-01-02"))
 
 	// 조회 범위: 해당 일 00:00:00 부터 다음 날 00:00:00 까지
 	startDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
@@ -124,12 +148,12 @@ func (s *CalendarService) GetMyCalendarEventsByDate(ctx context.Context, userID
 	// Event와 Todo를 모두 포함하여 DB에서 조회 (캐시 미사용)
 	calendars, err := s.CalendarEventsRepo.FindCalendarsWithTodos(ctx, userID, []string{"public", "friends", "private"}, startDate, endDate)
 	if err != nil {
-		logger.Errorf("[GetMyCalendarEventsByDate] FindCalendarsWithTodos failed: %v", err)
+		logctx.Errorf(ctx, "[GetMyCalendarEventsByDate] FindCalendarsWithTodos failed: %v", err)
 		return nil, errors.New("failed to get calendar events")
 	}
 	for _, v := range calendars {
 		for _, p := range v.Todos {
-			logger.Debugf("P : %v", p)
+			logctx.Debugf(ctx, "P : %v", p)
 		}
 	}
 	// GraphQL 모델 변환
@@ -138,12 +162,21 @@ func (s *CalendarService) GetMyCalendarEventsByDate(ctx context.Context, userID
 
 // GetEventsWithoutTodos: 월별 캘린더 뷰를 위해 Todo가 없는 Event만 조회하고 캐시 사용
 func (s *CalendarService) GetEventsWithoutTodos(ctx context.Context, UserID uuid.UUID, visibilityLevels []string, startDate, endDate time.Time) ([]*models.CalendarEvent, error) {
-	logger.Infof("[GetEventsWithoutTodos] user=%s, start=%s, end=%s", UserID, startDate, endDate)
+	logctx.Infof(ctx, "[GetEventsWithoutTodos] user=%s, start=%s, end=%s", UserID, startDate, endDate)
 	var allCalendars []*models.CalendarEvent
 
+	month, cacheable := cacheableMonth(startDate, endDate)
+
 	remainingVis := make([]string, 0)
 
+	// 1) 공개 범위별 캐시 확인
 	for _, vis := range visibilityLevels {
+		if cacheable {
+			if cached, ok := s.MonthCache.Get(ctx, monthCacheKey(UserID, month, vis)); ok {
+				allCalendars = append(allCalendars, cached...)
+				continue
+			}
+		}
 		remainingVis = append(remainingVis, vis)
 	}
 
@@ -162,10 +195,15 @@ func (s *CalendarService) GetEventsWithoutTodos(ctx context.Context, UserID uuid
 				}
 			}
 			allCalendars = append(allCalendars, filtered...)
+
+			// 2) 캐시 miss 였던 공개 범위만 저장 (빈 결과도 저장)
+			if cacheable {
+				s.MonthCache.Set(ctx, monthCacheKey(UserID, month, vis), filtered)
+			}
 		}
 	}
 
-	logger.Infof("[GetEventsWithoutTodos] retrieved %d events", len(allCalendars))
+	logctx.Infof(ctx, "[GetEventsWithoutTodos] retrieved %d events", len(allCalendars))
 	return allCalendars, nil
 }
 
@@ -178,42 +216,46 @@ func (s *CalendarService) CreateCalendarEvent(
 	cal *models.CalendarEvent,
 ) (*models.CalendarEvent, error) {
 
-	txDB, newCtx, err := tx.BeginTx(ctx, s.DB)
-	if err != nil {
-		logger.Errorf("[CreateCalendar] failed to start transaction: %v", err)
-		return nil, errors.New("failed to start transaction")
-	}
-
-	defer func() {
-		if r := recover(); r != nil {
-			logger.Errorf("[CreateCalendar] panic occurred, rollback: %v", r)
-			txDB.Rollback()
-			panic(r)
-		}
-	}()
-
-	ctx = newCtx
-
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[CreateCalendar] start user=%s title=%s",
 		cal.UserID,
 		cal.Title,
 	)
 
-	// CalendarEvent 생성
-	created, err := s.CalendarEventsRepo.CreateCalendarEvent(ctx, cal)
-	if err != nil {
-		txDB.Rollback()
-		return nil, err
-	}
+	var created *models.CalendarEvent
+	err := tx.RunInTx(ctx, s.DB, func(txCtx context.Context) error {
+		// CalendarEvent 생성
+		var err error
+		created, err = s.CalendarEventsRepo.CreateCalendarEvent(txCtx, cal)
+		if err != nil {
+			return err
+		}
 
-	// Commit
-	if err := txDB.Commit().Error; err != nil {
-		logger.Errorf("[CreateCalendar] commit failed: %v", err)
+		// 도메인 이벤트 기록 (같은 트랜잭션)
+		if err := s.Outbox.Record(txCtx, outbox.AggregateCalendarEvent, created.ID, outbox.EventCalendarEventCreated,
+			outbox.NewCalendarEventPayload(created)); err != nil {
+			return err
+		}
+
+		// 커밋 이후: 캐시 무효화 + 알림
+		tx.AfterCommit(txCtx, func() {
+			metrics.CalendarEventsCreated.Inc()
+			invalidateMonthCache(ctx, s.MonthCache, monthCacheKeysOf(created))
+			s.Hub.PublishCalendarEventChange(pubsub.CalendarEventChange{
+				Action:  pubsub.ActionCreated,
+				UserID:  created.UserID,
+				EventID: created.ID,
+				Event:   created,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		logctx.Errorf(ctx, "[CreateCalendar] failed user=%s: %v", cal.UserID, err)
 		return nil, err
 	}
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[CreateCalendar] success calendar_event_id=%s",
 		created.ID,
 	)
@@ -221,11 +263,14 @@ func (s *CalendarService) CreateCalendarEvent(
 	return created, nil
 }
 
+// UpdateCalendarEvent: expectedVersion 이 없으면 조회 시점의 version 을 기준으로 갱신합니다.
+// version 이 맞지 않으면 서버 최신본을 담은 CONFLICT 에러를 반환합니다.
 func (s *CalendarService) UpdateCalendarEvent(
 	ctx context.Context,
 	userID uuid.UUID,
 	eventID uuid.UUID,
 	input model.UpdateCalendarInput,
+	expectedVersion *int32,
 ) (*models.CalendarEvent, error) {
 
 	event, err := s.CalendarEventsRepo.GetEventWithTodosByID(ctx, eventID)
@@ -256,15 +301,90 @@ func (s *CalendarService) UpdateCalendarEvent(
 		}
 	}
 
+	expected := event.Version
+	if expectedVersion != nil {
+		expected = *expectedVersion
+	}
+
+	// Todo 전체 교체 시 삭제 알림을 위해 기존 목록을 보관
+	previousTodos := event.Todos
+	// 기간 / 공개 범위 변경 전의 월별 캐시 키 (event 는 아래에서 직접 수정됨)
+	previousCacheKeys := monthCacheKeysOf(event)
+	previousVisibility := event.Visibility
+
 	dto.UpdateCalendarModelFromRequest(event, &req)
 
-	if err := s.CalendarEventsRepo.Update(ctx, event); err != nil {
+	err = tx.RunInTx(ctx, s.DB, func(txCtx context.Context) error {
+		if err := s.CalendarEventsRepo.Update(txCtx, event, expected); err != nil {
+			return err
+		}
+
+		// 도메인 이벤트 기록 (같은 트랜잭션)
+		if err := s.Outbox.Record(txCtx, outbox.AggregateCalendarEvent, event.ID, outbox.EventCalendarEventUpdated,
+			outbox.NewCalendarEventPayload(event)); err != nil {
+			return err
+		}
+
+		// 커밋 이후: 캐시 무효화 + 알림
+		tx.AfterCommit(txCtx, func() {
+			invalidateMonthCache(ctx, s.MonthCache, append(previousCacheKeys, monthCacheKeysOf(event)...))
+
+			s.Hub.PublishCalendarEventChange(pubsub.CalendarEventChange{
+				Action:  pubsub.ActionUpdated,
+				UserID:  event.UserID,
+				EventID: event.ID,
+				Event:   event,
+
+				PreviousVisibility: previousVisibility,
+			})
+			if req.Todos != nil {
+				s.publishTodosReplaced(event, previousTodos)
+			}
+		})
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			logctx.Warnf(ctx, "[UpdateCalendarEvent] version conflict event=%s expected=%d", eventID, expected)
+			return nil, s.calendarConflict(ctx, eventID)
+		}
+		logctx.Errorf(ctx, "[UpdateCalendarEvent] failed event=%s: %v", eventID, err)
 		return nil, err
 	}
 
 	return event, nil
 }
 
+// publishTodosReplaced: Todo 전체 교체 결과를 삭제 → 생성 순서로 알립니다.
+func (s *CalendarService) publishTodosReplaced(event *models.CalendarEvent, previous []models.Todo) {
+	for i := range previous {
+		s.Hub.PublishTodoChange(pubsub.TodoChange{
+			Action:  pubsub.ActionDeleted,
+			UserID:  event.UserID,
+			EventID: event.ID,
+			TodoID:  previous[i].ID,
+		})
+	}
+	for i := range event.Todos {
+		s.Hub.PublishTodoChange(pubsub.TodoChange{
+			Action:  pubsub.ActionCreated,
+			UserID:  event.UserID,
+			EventID: event.ID,
+			TodoID:  event.Todos[i].ID,
+			Todo:    &event.Todos[i],
+		})
+	}
+}
+
+// calendarConflict: 서버 최신본을 조회하여 CONFLICT 에러로 감쌉니다.
+func (s *CalendarService) calendarConflict(ctx context.Context, eventID uuid.UUID) error {
+	current, err := s.CalendarEventsRepo.GetEventWithTodosByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to load current event after conflict: %w", err)
+	}
+	return planet_err.NewConflictError(mapper.ToCalendarGraphQL(current))
+}
+
 func (s *CalendarService) DeleteCalendarEvent(ctx context.Context, UserID uuid.UUID, eventID uuid.UUID) error {
 	cal, err := s.CalendarEventsRepo.FindByID(ctx, eventID)
 	if err != nil {
@@ -274,13 +394,71 @@ func (s *CalendarService) DeleteCalendarEvent(ctx context.Context, UserID uuid.U
 		return fmt.Errorf("unauthorized or not found")
 	}
 
-	if err := s.CalendarEventsRepo.DeleteCalendarEvent(ctx, eventID); err != nil {
+	err = tx.RunInTx(ctx, s.DB, func(txCtx context.Context) error {
+		if err := s.CalendarEventsRepo.DeleteCalendarEvent(txCtx, eventID); err != nil {
+			return err
+		}
+
+		// 도메인 이벤트 기록 (같은 트랜잭션)
+		if err := s.Outbox.Record(txCtx, outbox.AggregateCalendarEvent, cal.ID, outbox.EventCalendarEventDeleted,
+			outbox.CalendarEventPayload{EventID: cal.ID, UserID: cal.UserID}); err != nil {
+			return err
+		}
+
+		// 커밋 이후: 캐시 무효화 + 알림
+		tx.AfterCommit(txCtx, func() {
+			invalidateMonthCache(ctx, s.MonthCache, monthCacheKeysOf(cal))
+
+			for i := range cal.Todos {
+				s.Hub.PublishTodoChange(pubsub.TodoChange{
+					Action:  pubsub.ActionDeleted,
+					UserID:  cal.UserID,
+					EventID: cal.ID,
+					TodoID:  cal.Todos[i].ID,
+				})
+			}
+			// 삭제된 일정의 스냅샷은 공개 범위 필터링에만 사용됩니다.
+			s.Hub.PublishCalendarEventChange(pubsub.CalendarEventChange{
+				Action:  pubsub.ActionDeleted,
+				UserID:  cal.UserID,
+				EventID: cal.ID,
+				Event:   cal,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		logctx.Errorf(ctx, "[DeleteCalendarEvent] failed event=%s: %v", eventID, err)
 		return err
 	}
 
 	return nil
 }
 
+// DuplicateCalendarEvent: 내 일정을 newStartAt 으로 복제합니다. (Todo 포함, 완료 상태 초기화)
+func (s *CalendarService) DuplicateCalendarEvent(
+	ctx context.Context,
+	userID uuid.UUID,
+	eventID uuid.UUID,
+	newStartAt time.Time,
+) (*models.CalendarEvent, error) {
+
+	logctx.Infof(ctx,
+		"[DuplicateCalendarEvent] user=%s event=%s newStartAt=%s",
+		userID, eventID, newStartAt.Format(time.RFC3339),
+	)
+
+	src, err := s.CalendarEventsRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if src == nil || src.UserID != userID {
+		return nil, fmt.Errorf("unauthorized or not found")
+	}
+
+	return s.CreateCalendarEvent(ctx, dto.DuplicateCalendarModel(src, newStartAt))
+}
+
 // // ----------------------------
 // // Utility
 // // ----------------------------
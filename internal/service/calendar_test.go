@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+)
+
+func TestUpdateCalendarEventVersionConflictReturnsCurrent(t *testing.T) {
+	store := memory.NewStore()
+	events := memory.NewCalendarEventsRepository(store)
+	svc := service.NewCalendarService(nil, memory.NewProfileRepository(store), events, pubsub.NewHub(),
+		outbox.NewWriter(memory.NewOutboxEventsRepository(store)), nil)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	startAt := time.Date(2025, 3, 14, 18, 0, 0, 0, time.UTC)
+	event, err := events.CreateCalendarEvent(ctx, &models.CalendarEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Title:      "dinner",
+		StartAt:    startAt,
+		EndAt:      startAt.Add(2 * time.Hour),
+		Visibility: "public",
+	})
+	if err != nil {
+		t.Fatalf("CreateCalendarEvent: %v", err)
+	}
+
+	updated, err := svc.UpdateCalendarEvent(ctx, userID, event.ID, model.UpdateCalendarInput{Title: ptr("lunch")}, ptr(int32(1)))
+	if err != nil || updated.Version != 2 {
+		t.Fatalf("first UpdateCalendarEvent = %+v, %v", updated, err)
+	}
+
+	// version 1 을 본 다른 탭의 수정
+	_, err = svc.UpdateCalendarEvent(ctx, userID, event.ID, model.UpdateCalendarInput{Title: ptr("brunch")}, ptr(int32(1)))
+
+	var codeErr *planet_err.CodeError
+	if !errors.As(err, &codeErr) || codeErr.Code != planet_err.CodeConflict || !errors.Is(err, planet_err.ErrVersionConflict) {
+		t.Fatalf("err = %v, want CONFLICT", err)
+	}
+	current, ok := codeErr.Data["current"].(*model.Calendar)
+	if !ok || current.Title != "lunch" || current.Version != 2 {
+		t.Fatalf("current = %#v, want lunch v2", codeErr.Data["current"])
+	}
+
+	stored, err := events.FindByID(ctx, event.ID)
+	if err != nil || stored.Title != "lunch" || stored.Version != 2 {
+		t.Fatalf("stored = %+v, %v, want unchanged by stale update", stored, err)
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/graph/model"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EventTemplateService: 반복되는 일정(Event + Todo 묶음) 템플릿을 관리합니다.
+type EventTemplateServiceInterface interface {
+	CreateTemplate(
+		ctx context.Context,
+		userID uuid.UUID,
+		input model.CreateEventTemplateInput,
+	) (*models.CalendarEventTemplate, error)
+	GetMyTemplates(
+		ctx context.Context,
+		userID uuid.UUID,
+	) ([]*models.CalendarEventTemplate, error)
+	GetMyTemplate(
+		ctx context.Context,
+		userID uuid.UUID,
+		templateID uuid.UUID,
+	) (*models.CalendarEventTemplate, error)
+	UpdateTemplate(
+		ctx context.Context,
+		userID uuid.UUID,
+		templateID uuid.UUID,
+		input model.UpdateEventTemplateInput,
+	) (*models.CalendarEventTemplate, error)
+	DeleteTemplate(
+		ctx context.Context,
+		userID uuid.UUID,
+		templateID uuid.UUID,
+	) error
+	CreateEventFromTemplate(
+		ctx context.Context,
+		userID uuid.UUID,
+		templateID uuid.UUID,
+		startAt time.Time,
+	) (*models.CalendarEvent, error)
+}
+
+type EventTemplateService struct {
+	db              *gorm.DB
+	TemplatesRepo   repository.CalendarEventTemplatesRepositoryInterface
+	CalendarService CalendarServiceInterface
+}
+
+func NewEventTemplateService(
+	db *gorm.DB,
+	templatesRepo repository.CalendarEventTemplatesRepositoryInterface,
+	calendarService CalendarServiceInterface,
+) EventTemplateServiceInterface {
+	return &EventTemplateService{
+		db:              db,
+		TemplatesRepo:   templatesRepo,
+		CalendarService: calendarService,
+	}
+}
+
+func (s *EventTemplateService) CreateTemplate(
+	ctx context.Context,
+	userID uuid.UUID,
+	input model.CreateEventTemplateInput,
+) (*models.CalendarEventTemplate, error) {
+
+	logctx.Infof(ctx, "[CreateTemplate] user=%s name=%s", userID, input.Name)
+
+	if input.DurationMinutes <= 0 {
+		return nil, errors.New("durationMinutes must be positive")
+	}
+
+	return s.TemplatesRepo.Create(ctx, dto.ToEventTemplateModel(input, userID))
+}
+
+func (s *EventTemplateService) GetMyTemplates(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*models.CalendarEventTemplate, error) {
+	return s.TemplatesRepo.FindByUserID(ctx, userID)
+}
+
+func (s *EventTemplateService) GetMyTemplate(
+	ctx context.Context,
+	userID uuid.UUID,
+	templateID uuid.UUID,
+) (*models.CalendarEventTemplate, error) {
+
+	template, err := s.TemplatesRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || template.UserID != userID {
+		return nil, fmt.Errorf("unauthorized or not found")
+	}
+
+	return template, nil
+}
+
+func (s *EventTemplateService) UpdateTemplate(
+	ctx context.Context,
+	userID uuid.UUID,
+	templateID uuid.UUID,
+	input model.UpdateEventTemplateInput,
+) (*models.CalendarEventTemplate, error) {
+
+	template, err := s.GetMyTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DurationMinutes != nil && *input.DurationMinutes <= 0 {
+		return nil, errors.New("durationMinutes must be positive")
+	}
+
+	dto.UpdateEventTemplateModelFromInput(template, input)
+
+	if err := s.TemplatesRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *EventTemplateService) DeleteTemplate(
+	ctx context.Context,
+	userID uuid.UUID,
+	templateID uuid.UUID,
+) error {
+
+	if _, err := s.GetMyTemplate(ctx, userID, templateID); err != nil {
+		return err
+	}
+
+	return s.TemplatesRepo.Delete(ctx, templateID)
+}
+
+// CreateEventFromTemplate: 템플릿 → CreateCalendarInput → ToCalendarModel 순으로 변환하여
+// 일반 일정 생성과 동일한 경로(CalendarService.CreateCalendarEvent)로 저장합니다.
+func (s *EventTemplateService) CreateEventFromTemplate(
+	ctx context.Context,
+	userID uuid.UUID,
+	templateID uuid.UUID,
+	startAt time.Time,
+) (*models.CalendarEvent, error) {
+
+	logctx.Infof(ctx,
+		"[CreateEventFromTemplate] user=%s template=%s startAt=%s",
+		userID, templateID, startAt.Format(time.RFC3339),
+	)
+
+	template, err := s.GetMyTemplate(ctx, userID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := dto.ToCreateCalendarInputFromTemplate(template, startAt)
+
+	return s.CalendarService.CreateCalendarEvent(ctx, dto.ToCalendarModel(input, userID))
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/gorm"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyService: Idempotency-Key / clientMutationId 로 mutation 재시도를 안전하게 처리합니다.
+// 첫 요청의 응답을 TTL 동안 저장해 두고, 같은 키의 재시도에는 저장된 응답을 그대로 돌려줍니다.
+type IdempotencyServiceInterface interface {
+	// RunInTx: 키 선점, mutation, 응답 저장을 하나의 트랜잭션으로 묶습니다.
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// Begin: 키를 선점합니다. 이미 완료된 키라면 replay=true 와 저장된 레코드를 반환합니다.
+	Begin(
+		ctx context.Context,
+		userID uuid.UUID,
+		operation string,
+		key string,
+		requestHash string,
+	) (record *models.IdempotencyKey, replay bool, err error)
+	Complete(ctx context.Context, record *models.IdempotencyKey, response interface{}) error
+	Abort(ctx context.Context, record *models.IdempotencyKey)
+}
+
+type IdempotencyService struct {
+	db              *gorm.DB
+	IdempotencyRepo repository.IdempotencyKeysRepositoryInterface
+	ttl             time.Duration
+}
+
+func NewIdempotencyService(
+	db *gorm.DB,
+	idempotencyRepo repository.IdempotencyKeysRepositoryInterface,
+	ttl time.Duration,
+) IdempotencyServiceInterface {
+	return &IdempotencyService{
+		db:              db,
+		IdempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+func (s *IdempotencyService) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return tx.RunInTx(ctx, s.db, fn)
+}
+
+func (s *IdempotencyService) Begin(
+	ctx context.Context,
+	userID uuid.UUID,
+	operation string,
+	key string,
+	requestHash string,
+) (*models.IdempotencyKey, bool, error) {
+
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, false, fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	now := time.Now()
+	record := &models.IdempotencyKey{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Operation:   operation,
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      models.IdempotencyStatusPending,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	reserved, err := s.IdempotencyRepo.Reserve(ctx, record)
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		return record, false, nil
+	}
+
+	existing, err := s.IdempotencyRepo.Find(ctx, userID, operation, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing == nil {
+		// 선점 직후 다른 요청이 Abort 한 경우
+		return nil, false, planet_err.NewCodeError(
+			planet_err.CodeIdempotencyInProgress,
+			planet_err.ErrIdempotencyInProgress.Error(),
+			http.StatusConflict,
+			planet_err.ErrIdempotencyInProgress,
+		)
+	}
+
+	// TTL 이 지난 키는 지우고 새로 선점합니다.
+	if now.After(existing.ExpiresAt) {
+		if err := s.IdempotencyRepo.Delete(ctx, existing.ID); err != nil {
+			return nil, false, err
+		}
+		return s.Begin(ctx, userID, operation, key, requestHash)
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, false, planet_err.NewCodeError(
+			planet_err.CodeIdempotencyKeyReused,
+			planet_err.ErrIdempotencyKeyReused.Error(),
+			http.StatusUnprocessableEntity,
+			planet_err.ErrIdempotencyKeyReused,
+		)
+	}
+
+	if existing.Status != models.IdempotencyStatusCompleted {
+		return nil, false, planet_err.NewCodeError(
+			planet_err.CodeIdempotencyInProgress,
+			planet_err.ErrIdempotencyInProgress.Error(),
+			http.StatusConflict,
+			planet_err.ErrIdempotencyInProgress,
+		)
+	}
+
+	logctx.Infof(ctx, "[Idempotency] replay user=%s operation=%s", userID, operation)
+	return existing, true, nil
+}
+
+func (s *IdempotencyService) Complete(
+	ctx context.Context,
+	record *models.IdempotencyKey,
+	response interface{},
+) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotent response: %w", err)
+	}
+	return s.IdempotencyRepo.Complete(ctx, record.ID, body)
+}
+
+// Abort: 실행이 실패하면 키를 풀어 클라이언트가 같은 키로 다시 시도할 수 있게 합니다.
+func (s *IdempotencyService) Abort(ctx context.Context, record *models.IdempotencyKey) {
+	if err := s.IdempotencyRepo.Delete(ctx, record.ID); err != nil {
+		logctx.Errorf(ctx, "[Idempotency] failed to release key id=%s: %v", record.ID, err)
+	}
+}
+
+// Idempotent: key 가 비어 있으면 fn 을 그대로 실행합니다.
+// key 가 있으면 첫 실행 결과를 저장하고, 재시도에는 저장된 결과를 복원해 반환합니다.
+//
+// 키 선점, fn, 응답 저장은 같은 트랜잭션에서 실행되므로 중간에 실패하거나 프로세스가 죽으면
+// mutation 과 pending 키가 함께 롤백되어 같은 키로 바로 다시 시도할 수 있습니다.
+// 같은 키의 동시 요청은 선점 INSERT 에서 먼저 온 트랜잭션이 끝날 때까지 기다린 뒤 저장된 응답을 받습니다.
+func Idempotent[T any](
+	ctx context.Context,
+	s IdempotencyServiceInterface,
+	userID uuid.UUID,
+	operation string,
+	key string,
+	request interface{},
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	var zero T
+
+	if key == "" {
+		return fn(ctx)
+	}
+
+	requestHash, err := hashRequest(request)
+	if err != nil {
+		return zero, err
+	}
+
+	var (
+		result   T
+		reserved *models.IdempotencyKey
+	)
+	err = s.RunInTx(ctx, func(txCtx context.Context) error {
+		reserved = nil // 재시도(serialization failure) 로 다시 실행될 수 있음
+
+		record, replay, err := s.Begin(txCtx, userID, operation, key, requestHash)
+		if err != nil {
+			return err
+		}
+		if replay {
+			if err := json.Unmarshal(record.Response, &result); err != nil {
+				return fmt.Errorf("failed to restore idempotent response: %w", err)
+			}
+			return nil
+		}
+		reserved = record
+
+		if result, err = fn(txCtx); err != nil {
+			return err
+		}
+		if err := s.Complete(txCtx, record, result); err != nil {
+			return fmt.Errorf("failed to store idempotent response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// 트랜잭션 없이 실행된 경우 (in-memory repository) 선점한 키가 남으므로 직접 풉니다.
+		// 롤백된 경우에는 지울 행이 없어 아무 일도 하지 않습니다.
+		if reserved != nil {
+			s.Abort(ctx, reserved)
+		}
+		return zero, err
+	}
+
+	return result, nil
+}
+
+func hashRequest(request interface{}) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", errors.New("failed to hash idempotent request")
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/repositorytest"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+)
+
+const (
+	testOperation = "createThing"
+	testKey       = "key-1"
+)
+
+var errMutation = errors.New("mutation failed")
+
+type result struct {
+	ID    string `json:"id"`
+	Calls int    `json:"calls"`
+}
+
+// flakyKeys: Complete / Delete 를 실패시켜 응답 저장 직전에 프로세스가 죽은 상황을 흉내 냅니다.
+type flakyKeys struct {
+	repository.IdempotencyKeysRepositoryInterface
+	failComplete bool
+	failDelete   bool
+}
+
+func (r *flakyKeys) Complete(ctx context.Context, id uuid.UUID, response []byte) error {
+	if r.failComplete {
+		return errors.New("complete failed")
+	}
+	return r.IdempotencyKeysRepositoryInterface.Complete(ctx, id, response)
+}
+
+func (r *flakyKeys) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.failDelete {
+		return errors.New("delete failed")
+	}
+	return r.IdempotencyKeysRepositoryInterface.Delete(ctx, id)
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	keys := memory.NewIdempotencyKeysRepository(memory.NewStore())
+	svc := service.NewIdempotencyService(nil, keys, time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	calls := 0
+	fn := func(ctx context.Context) (result, error) {
+		calls++
+		return result{ID: "a", Calls: calls}, nil
+	}
+
+	first, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", fn)
+	if err != nil {
+		t.Fatalf("first: %v", err)
+	}
+	replay, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", fn)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if calls != 1 || replay != first {
+		t.Fatalf("calls = %d, replay = %+v, want 1 call and %+v", calls, replay, first)
+	}
+
+	// 같은 키를 다른 요청에 재사용하면 거절
+	_, err = service.Idempotent(ctx, svc, userID, testOperation, testKey, "other", fn)
+	if !errors.Is(err, planet_err.ErrIdempotencyKeyReused) {
+		t.Fatalf("err = %v, want ErrIdempotencyKeyReused", err)
+	}
+}
+
+func TestIdempotentInProgress(t *testing.T) {
+	keys := memory.NewIdempotencyKeysRepository(memory.NewStore())
+	svc := service.NewIdempotencyService(nil, keys, time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// 첫 요청이 실행 중인 동안 같은 키로 재시도
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", func(ctx context.Context) (result, error) {
+			close(started)
+			<-release
+			return result{ID: "a"}, nil
+		})
+		done <- err
+	}()
+	<-started
+
+	_, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", func(ctx context.Context) (result, error) {
+		return result{}, errors.New("fn must not run while the key is in progress")
+	})
+	close(release)
+	if !errors.Is(err, planet_err.ErrIdempotencyInProgress) {
+		t.Fatalf("err = %v, want ErrIdempotencyInProgress", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+}
+
+func TestIdempotentFailureReleasesKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		failFn       bool
+		failComplete bool
+	}{
+		{name: "mutation fails", failFn: true},
+		{name: "storing response fails", failComplete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewStore()
+			keys := &flakyKeys{
+				IdempotencyKeysRepositoryInterface: memory.NewIdempotencyKeysRepository(store),
+				failComplete:                       tt.failComplete,
+			}
+			svc := service.NewIdempotencyService(nil, keys, time.Hour)
+			ctx := context.Background()
+			userID := uuid.New()
+
+			_, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", func(ctx context.Context) (result, error) {
+				if tt.failFn {
+					return result{}, errMutation
+				}
+				return result{ID: "a"}, nil
+			})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if record, _ := keys.Find(ctx, userID, testOperation, testKey); record != nil {
+				t.Fatalf("key left %s after failure", record.Status)
+			}
+
+			// 같은 키로 바로 다시 시도할 수 있어야 합니다.
+			keys.failComplete = false
+			got, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", func(ctx context.Context) (result, error) {
+				return result{ID: "b"}, nil
+			})
+			if err != nil || got.ID != "b" {
+				t.Fatalf("retry = %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+// TestIdempotentRollsBackWithMutation: 응답 저장 전에 죽으면 (Complete 실패, 정리도 실패)
+// mutation 과 pending 키가 같은 트랜잭션에서 함께 롤백되어야 합니다.
+func TestIdempotentRollsBackWithMutation(t *testing.T) {
+	db := repositorytest.OpenPostgres(t)
+	repositorytest.Truncate(t, db, "profiles", "idempotency_keys")
+
+	profiles := repository.NewProfilesRepository(db)
+	keys := &flakyKeys{
+		IdempotencyKeysRepositoryInterface: repository.NewIdempotencyKeysRepository(db),
+		failComplete:                       true,
+		failDelete:                         true,
+	}
+	svc := service.NewIdempotencyService(db, keys, time.Hour)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	createProfile := func(ctx context.Context) (result, error) {
+		err := profiles.Create(ctx, &models.Profile{UserID: userID, Nickname: "idem-" + userID.String()[:8]})
+		return result{ID: userID.String()}, err
+	}
+
+	if _, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", createProfile); err == nil {
+		t.Fatal("expected error")
+	}
+	if record, err := keys.Find(ctx, userID, testOperation, testKey); err != nil || record != nil {
+		t.Fatalf("key = %+v, err = %v, want rolled back", record, err)
+	}
+	if found, err := profiles.FindByUserIDs(ctx, []uuid.UUID{userID}); err != nil || len(found) != 0 {
+		t.Fatalf("profiles = %d, err = %v, want rolled back", len(found), err)
+	}
+
+	keys.failComplete, keys.failDelete = false, false
+	if _, err := service.Idempotent(ctx, svc, userID, testOperation, testKey, "req", createProfile); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	record, err := keys.Find(ctx, userID, testOperation, testKey)
+	if err != nil || record == nil || record.Status != models.IdempotencyStatusCompleted {
+		t.Fatalf("key = %+v, err = %v, want completed", record, err)
+	}
+}
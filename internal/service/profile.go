@@ -4,112 +4,214 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/calendarcache"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
 	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
 	"github.com/rainbow96bear/planet_user_server/internal/repository"
 	"github.com/rainbow96bear/planet_user_server/internal/tx"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
+const (
+	nicknameSuggestionCount = 3
+	nicknameCandidateCount  = 10 // 한 번의 쿼리로 확인할 후보 수
+)
+
 type ProfileServiceInterface interface {
 	IsNicknameAvailable(ctx context.Context, nickname string) (bool, error)
 	CreateProfile(ctx context.Context, req dto.CreateProfileRequest) (*dto.ProfileResponse, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.ProfileUpdate) (*dto.UserProfile, error)
 	GetMyProfileInfo(ctx context.Context, userID uuid.UUID) (*dto.UserProfile, error)
 	GetUserProfileInfo(ctx context.Context, userID uuid.UUID) (*dto.UserProfile, error)
+	DeleteProfile(ctx context.Context, userID uuid.UUID) error
 }
 
 type ProfileService struct {
-	db           *gorm.DB
-	ProfilesRepo *repository.ProfileRepository
+	db                 *gorm.DB
+	ProfilesRepo       repository.ProfileRepositoryInterface
+	CalendarEventsRepo repository.CalendarEventsRepositoryInterface
+	TemplatesRepo      repository.CalendarEventTemplatesRepositoryInterface
+	FollowsRepo        repository.FollowsRepositoryInterface
+	Outbox             *outbox.Writer
+	MonthCache         calendarcache.Cache // 회원 탈퇴 커밋 후 삭제된 일정의 월 캐시 무효화
 }
 
 func NewProfileService(
 	db *gorm.DB,
-	profilesRepo *repository.ProfileRepository,
+	profilesRepo repository.ProfileRepositoryInterface,
+	calendarRepo repository.CalendarEventsRepositoryInterface,
+	templatesRepo repository.CalendarEventTemplatesRepositoryInterface,
+	followsRepo repository.FollowsRepositoryInterface,
+	outboxWriter *outbox.Writer,
+	monthCache calendarcache.Cache,
 ) ProfileServiceInterface {
+	if monthCache == nil {
+		monthCache = calendarcache.Noop{}
+	}
 	return &ProfileService{
-		db:           db,
-		ProfilesRepo: profilesRepo,
+		db:                 db,
+		ProfilesRepo:       profilesRepo,
+		CalendarEventsRepo: calendarRepo,
+		TemplatesRepo:      templatesRepo,
+		FollowsRepo:        followsRepo,
+		Outbox:             outboxWriter,
+		MonthCache:         monthCache,
 	}
 }
 
 // 닉네임 중복 검사
 func (s *ProfileService) IsNicknameAvailable(ctx context.Context, nickname string) (bool, error) {
-	var count int64
-	if err := s.db.WithContext(ctx).Model(&models.Profile{}).Where("nickname = ?", nickname).Count(&count).Error; err != nil {
+	exists, err := s.ProfilesRepo.ExistsByNickname(ctx, nickname)
+	if err != nil {
 		return false, err
 	}
-	return count == 0, nil
+	return !exists, nil
 }
 
 // 회원가입 시 Profile 생성 (트랜잭션 + DTO)
 func (s *ProfileService) CreateProfile(ctx context.Context, req dto.CreateProfileRequest) (*dto.ProfileResponse, error) {
-	logger.Debugf("ProfileService: CreateProfile attempt for nickname=%s", req.Nickname)
+	logctx.Debugf(ctx, "ProfileService: CreateProfile attempt for nickname=%s", req.Nickname)
 
-	// 0. 트랜잭션 시작
-	txDB, newCtx, err := tx.BeginTx(ctx, s.db)
-	if err != nil {
-		logger.Errorf("CreateProfile: failed to start transaction: %v", err)
-		return nil, errors.New("failed to start transaction")
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			logger.Errorf("CreateProfile: panic occurred, rollback triggered: %v", r)
-			txDB.Rollback()
-			panic(r)
+	var (
+		response     *dto.ProfileResponse
+		insertFailed bool
+	)
+	err := tx.RunInTx(ctx, s.db, func(ctx context.Context) error {
+		// 1. 같은 사용자의 재시도(auth 서버 retry)라면 기존 프로필을 그대로 반환
+		existing, err := s.findProfileByUserID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			logctx.Infof(ctx, "CreateProfile: profile already exists for user=%s, returning existing", req.UserID)
+			response = toProfileResponse(existing, true)
+			return nil
+		}
+
+		// 2. 닉네임 중복 체크 (AutoSuffixNickname 이면 사용 가능한 후보로 대체)
+		available, err := s.IsNicknameAvailable(ctx, req.Nickname)
+		if err != nil {
+			return err
+		}
+		if !available {
+			suggestions, err := s.suggestNicknames(ctx, req.Nickname)
+			if err != nil {
+				return err
+			}
+			if !req.AutoSuffixNickname || len(suggestions) == 0 {
+				return planet_err.NewNicknameTakenError(suggestions)
+			}
+			logctx.Infof(ctx, "CreateProfile: nickname=%s taken, using %s", req.Nickname, suggestions[0])
+			req.Nickname = suggestions[0]
 		}
-	}()
 
-	ctx = newCtx
+		// 3. Profile 생성
+		profile := &models.Profile{
+			UserID:       req.UserID,
+			Nickname:     req.Nickname,
+			Bio:          req.Bio,
+			ProfileImage: req.ProfileImage,
+			Theme:        req.Theme, // 문자열 그대로 저장
+			Version:      1,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if err := s.ProfilesRepo.Create(ctx, profile); err != nil {
+			insertFailed = errors.Is(err, planet_err.ErrAlreadyExists)
+			return err
+		}
 
-	// 1. 닉네임 중복 체크
-	available, err := s.IsNicknameAvailable(ctx, req.Nickname)
+		// 4. 도메인 이벤트 기록 (같은 트랜잭션)
+		if err := s.Outbox.Record(ctx, outbox.AggregateProfile, profile.UserID, outbox.EventProfileCreated, outbox.ProfilePayload{
+			UserID:   profile.UserID,
+			Nickname: profile.Nickname,
+			Version:  profile.Version,
+		}); err != nil {
+			return err
+		}
+
+		response = toProfileResponse(profile, false)
+		return nil
+	})
 	if err != nil {
-		txDB.Rollback()
+		// 동시에 들어온 재시도가 먼저 생성한 경우 (user_id unique)
+		if insertFailed {
+			if existing, findErr := s.findProfileByUserID(ctx, req.UserID); findErr == nil && existing != nil {
+				return toProfileResponse(existing, true), nil
+			}
+		}
+		logctx.Errorf(ctx, "CreateProfile: failed for user=%s: %v", req.UserID, err)
 		return nil, err
 	}
-	if !available {
-		txDB.Rollback()
-		return nil, errors.New("nickname already in use")
-	}
 
-	// 2. Profile 생성
-	profile := &models.Profile{
-		UserID:       req.UserID,
-		Nickname:     req.Nickname,
-		Bio:          req.Bio,
-		ProfileImage: req.ProfileImage,
-		Theme:        req.Theme, // 문자열 그대로 저장
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+	return response, nil
+}
+
+func toProfileResponse(profile *models.Profile, alreadyExisted bool) *dto.ProfileResponse {
+	return &dto.ProfileResponse{
+		UserID:         profile.UserID,
+		Nickname:       profile.Nickname,
+		Bio:            profile.Bio,
+		ProfileImage:   profile.ProfileImage,
+		Theme:          profile.Theme, // 이제 문자열 그대로 전달
+		CreatedAt:      profile.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      profile.UpdatedAt.Format(time.RFC3339),
+		AlreadyExisted: alreadyExisted,
 	}
-	if err := txDB.Create(profile).Error; err != nil {
-		txDB.Rollback()
-		return nil, err
+}
+
+// findProfileByUserID: 프로필이 없으면 (nil, nil)
+func (s *ProfileService) findProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	profile, err := s.ProfilesRepo.GetMyProfileInfo(ctx, userID)
+	if err != nil {
+		if planet_err.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
+	return profile, nil
+}
 
-	if err := txDB.Commit().Error; err != nil {
-		txDB.Rollback()
-		return nil, errors.New("failed to commit transaction")
+// suggestNicknames: base 에 숫자 suffix 를 붙인 후보 중 사용 가능한 닉네임을 최대 nicknameSuggestionCount 개 반환합니다.
+func (s *ProfileService) suggestNicknames(ctx context.Context, base string) ([]string, error) {
+	candidates := make([]string, 0, nicknameCandidateCount)
+	seen := make(map[string]struct{}, nicknameCandidateCount)
+	for len(candidates) < nicknameCandidateCount {
+		candidate := fmt.Sprintf("%s%d", base, 1+rand.IntN(9999))
+		if _, ok := seen[candidate]; ok {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		candidates = append(candidates, candidate)
 	}
 
-	resp := &dto.ProfileResponse{
-		UserID:       profile.UserID,
-		Nickname:     profile.Nickname,
-		Bio:          profile.Bio,
-		ProfileImage: profile.ProfileImage,
-		Theme:        profile.Theme, // 이제 문자열 그대로 전달
-		CreatedAt:    profile.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    profile.UpdatedAt.Format(time.RFC3339),
+	taken, err := s.ProfilesRepo.FindByNicknames(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check nickname candidates: %w", err)
+	}
+	takenSet := make(map[string]struct{}, len(taken))
+	for _, p := range taken {
+		takenSet[p.Nickname] = struct{}{}
 	}
 
-	return resp, nil
+	suggestions := make([]string, 0, nicknameSuggestionCount)
+	for _, candidate := range candidates {
+		if _, ok := takenSet[candidate]; ok {
+			continue
+		}
+		suggestions = append(suggestions, candidate)
+		if len(suggestions) == nicknameSuggestionCount {
+			break
+		}
+	}
+	return suggestions, nil
 }
 
 // // 닉네임으로 사용자 UUID 조회
@@ -157,6 +259,7 @@ func (s *ProfileService) GetMyProfileInfo(ctx context.Context, userID uuid.UUID)
 		FollowerCount:  profile.FollowerCount,
 		FollowingCount: profile.FollowingCount,
 		Theme:          profile.Theme,
+		Version:        profile.Version,
 	}, nil
 }
 
@@ -176,9 +279,66 @@ func (s *ProfileService) GetUserProfileInfo(ctx context.Context, userID uuid.UUI
 		FollowerCount:  profile.FollowerCount,
 		FollowingCount: profile.FollowingCount,
 		Theme:          profile.Theme,
+		Version:        profile.Version,
 	}, nil
 }
 
+// 회원 탈퇴: 프로필, 팔로우 관계, 일정/Todo, 일정 템플릿을 한 트랜잭션에서 삭제합니다.
+func (s *ProfileService) DeleteProfile(ctx context.Context, userID uuid.UUID) error {
+	logctx.Infof(ctx, "ProfileService: DeleteProfile user=%s", userID)
+
+	err := tx.RunInTx(ctx, s.db, func(ctx context.Context) error {
+		profile, err := s.ProfilesRepo.GetMyProfileInfo(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get profile: %w", err)
+		}
+
+		// 1. 팔로우 관계 (상대방 카운트 보정 후 삭제)
+		if err := s.ProfilesRepo.DecrementFollowCountsOfUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to update follow counts: %w", err)
+		}
+		if err := s.FollowsRepo.DeleteAllByUserTx(ctx, tx.GetTx(ctx), userID); err != nil {
+			return err
+		}
+
+		// 2. 일정 + Todo (삭제 전 일정으로 무효화할 월 캐시 키를 계산)
+		events, err := s.CalendarEventsRepo.FindAllWithTodosByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.CalendarEventsRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+		cacheKeys := monthCacheKeysOf(events...)
+		tx.AfterCommit(ctx, func() {
+			invalidateMonthCache(ctx, s.MonthCache, cacheKeys)
+		})
+
+		// 3. 일정 템플릿
+		if err := s.TemplatesRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		// 4. 프로필
+		if err := s.ProfilesRepo.DeleteByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		// 5. 도메인 이벤트 기록 (같은 트랜잭션)
+		return s.Outbox.Record(ctx, outbox.AggregateProfile, userID, outbox.EventProfileDeleted, outbox.ProfilePayload{
+			UserID:   userID,
+			Nickname: profile.Nickname,
+			Version:  profile.Version,
+		})
+	})
+	if err != nil {
+		logctx.Errorf(ctx, "DeleteProfile: failed for user=%s: %v", userID, err)
+		return err
+	}
+
+	return nil
+}
+
 // // 프로필 업데이트
 func (s *ProfileService) UpdateProfile(
 	ctx context.Context,
@@ -186,31 +346,81 @@ func (s *ProfileService) UpdateProfile(
 	req *dto.ProfileUpdate,
 ) (*dto.UserProfile, error) {
 
-	// 소유권 확인
-	isMyProfile, err := s.ProfilesRepo.IsMyProfile(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify profile ownership: %w", err)
-	}
-	if !isMyProfile {
-		return nil, fmt.Errorf("unauthorized: cannot update another user's profile")
-	}
+	var profile *dto.UserProfile
+	err := tx.RunInTx(ctx, s.db, func(txCtx context.Context) error {
+		// 소유권 확인
+		isMyProfile, err := s.ProfilesRepo.IsMyProfile(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to verify profile ownership: %w", err)
+		}
+		if !isMyProfile {
+			return fmt.Errorf("unauthorized: cannot update another user's profile: %w", planet_err.ErrNotFound)
+		}
+
+		// 닉네임 변경 이벤트를 위해 변경 전 프로필 보관
+		before, err := s.ProfilesRepo.GetMyProfileInfo(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get profile: %w", err)
+		}
+
+		// 업데이트 (닉네임 중복 / version 충돌은 롤백 후 아래에서 처리)
+		if err := s.ProfilesRepo.UpdateProfile(txCtx, req); err != nil {
+			if errors.Is(err, planet_err.ErrNicknameDuplicate) || errors.Is(err, planet_err.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update profile: %w", err)
+		}
+
+		// 최신 프로필 반환
+		profile, err = s.GetMyProfileInfo(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated profile: %w", err)
+		}
 
-	// 업데이트
-	if err := s.ProfilesRepo.UpdateProfile(ctx, req); err != nil {
+		// 도메인 이벤트 기록 (같은 트랜잭션)
+		return s.recordProfileUpdated(txCtx, before, profile)
+	})
+	if err != nil {
 		// 닉네임 중복 오류 처리
 		if errors.Is(err, planet_err.ErrNicknameDuplicate) {
 			return nil, planet_err.ErrNicknameDuplicate
 		}
-		return nil, fmt.Errorf("failed to update profile: %w", err)
+		// version 충돌: 서버 최신본과 함께 반환 (롤백 이후 조회)
+		if errors.Is(err, planet_err.ErrVersionConflict) {
+			current, getErr := s.GetMyProfileInfo(ctx, userID)
+			if getErr != nil {
+				return nil, fmt.Errorf("failed to load current profile after conflict: %w", getErr)
+			}
+			return nil, planet_err.NewConflictError(current)
+		}
+		return nil, err
 	}
 
-	// 최신 프로필 반환
-	profile, err := s.GetMyProfileInfo(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch updated profile: %w", err)
+	return profile, nil
+}
+
+func (s *ProfileService) recordProfileUpdated(ctx context.Context, before *models.Profile, after *dto.UserProfile) error {
+	// 변경 사항이 없으면 version 도 그대로이므로 기록하지 않음
+	if before.Version == after.Version {
+		return nil
 	}
 
-	return profile, nil
+	if err := s.Outbox.Record(ctx, outbox.AggregateProfile, after.UserID, outbox.EventProfileUpdated, outbox.ProfilePayload{
+		UserID:   after.UserID,
+		Nickname: after.Nickname,
+		Version:  after.Version,
+	}); err != nil {
+		return err
+	}
+
+	if before.Nickname == after.Nickname {
+		return nil
+	}
+	return s.Outbox.Record(ctx, outbox.AggregateProfile, after.UserID, outbox.EventProfileNicknameChanged, outbox.NicknameChangedPayload{
+		UserID:      after.UserID,
+		OldNickname: before.Nickname,
+		NewNickname: after.Nickname,
+	})
 }
 
 // // 테마 조회
@@ -0,0 +1,158 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/dto"
+	"github.com/rainbow96bear/planet_user_server/internal/outbox"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+)
+
+type profileFixture struct {
+	profiles *memory.ProfileRepository
+	outbox   *memory.OutboxEventsRepository
+	svc      service.ProfileServiceInterface
+}
+
+func newProfileFixture() *profileFixture {
+	store := memory.NewStore()
+	f := &profileFixture{
+		profiles: memory.NewProfileRepository(store),
+		outbox:   memory.NewOutboxEventsRepository(store),
+	}
+	f.svc = service.NewProfileService(nil, f.profiles, memory.NewCalendarEventsRepository(store),
+		memory.NewCalendarEventTemplatesRepository(store), memory.NewFollowsRepository(store),
+		outbox.NewWriter(f.outbox), nil)
+	return f
+}
+
+// outboxCount: 기록된 outbox 이벤트 수
+func (f *profileFixture) outboxCount(t *testing.T) int {
+	t.Helper()
+	events, err := f.outbox.ClaimPending(context.Background(), time.Now().Add(time.Hour), time.Minute, 100)
+	if err != nil {
+		t.Fatalf("ClaimPending: %v", err)
+	}
+	return len(events)
+}
+
+func (f *profileFixture) create(t *testing.T, userID uuid.UUID, nickname string) *dto.ProfileResponse {
+	t.Helper()
+	created, err := f.svc.CreateProfile(context.Background(), dto.CreateProfileRequest{UserID: userID, Nickname: nickname})
+	if err != nil {
+		t.Fatalf("CreateProfile(%s): %v", nickname, err)
+	}
+	return created
+}
+
+func TestCreateProfileRetryReturnsExisting(t *testing.T) {
+	f := newProfileFixture()
+	userID := uuid.New()
+
+	first := f.create(t, userID, "alice")
+	if first.AlreadyExisted {
+		t.Fatal("first CreateProfile reported AlreadyExisted")
+	}
+
+	// auth 서버의 재시도: 닉네임이 달라도 같은 사용자면 기존 프로필을 반환
+	retry := f.create(t, userID, "alice_retry")
+	if !retry.AlreadyExisted || retry.UserID != userID || retry.Nickname != "alice" {
+		t.Fatalf("retry = %+v, want existing alice", retry)
+	}
+	if n := f.outboxCount(t); n != 1 {
+		t.Fatalf("outbox events = %d, want 1 (retry records nothing)", n)
+	}
+}
+
+func TestCreateProfileNicknameTaken(t *testing.T) {
+	f := newProfileFixture()
+	f.create(t, uuid.New(), "alice")
+	f.create(t, uuid.New(), "alice7")
+
+	_, err := f.svc.CreateProfile(context.Background(), dto.CreateProfileRequest{UserID: uuid.New(), Nickname: "alice"})
+
+	var codeErr *planet_err.CodeError
+	if !errors.As(err, &codeErr) || codeErr.Code != planet_err.CodeNicknameTaken {
+		t.Fatalf("err = %v, want NICKNAME_TAKEN", err)
+	}
+	if !errors.Is(err, planet_err.ErrNicknameDuplicate) {
+		t.Fatalf("err = %v, want wrapping ErrNicknameDuplicate", err)
+	}
+
+	suggestions, _ := codeErr.Data["suggestions"].([]string)
+	if len(suggestions) != 3 {
+		t.Fatalf("suggestions = %v, want 3", suggestions)
+	}
+	for _, nickname := range suggestions {
+		if !strings.HasPrefix(nickname, "alice") || nickname == "alice" || nickname == "alice7" {
+			t.Errorf("suggestion %q is not an available alice<N>", nickname)
+		}
+	}
+	if n := f.outboxCount(t); n != 2 {
+		t.Fatalf("outbox events = %d, want 2", n)
+	}
+}
+
+func TestCreateProfileAutoSuffixNickname(t *testing.T) {
+	f := newProfileFixture()
+	f.create(t, uuid.New(), "alice")
+
+	userID := uuid.New()
+	created, err := f.svc.CreateProfile(context.Background(), dto.CreateProfileRequest{
+		UserID:             userID,
+		Nickname:           "alice",
+		AutoSuffixNickname: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateProfile: %v", err)
+	}
+	if created.AlreadyExisted || created.Nickname == "alice" || !strings.HasPrefix(created.Nickname, "alice") {
+		t.Fatalf("created = %+v, want alice<N>", created)
+	}
+
+	stored, err := f.profiles.GetMyProfileInfo(context.Background(), userID)
+	if err != nil || stored.Nickname != created.Nickname {
+		t.Fatalf("stored = %+v, %v", stored, err)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestUpdateProfileVersionConflictReturnsCurrent(t *testing.T) {
+	f := newProfileFixture()
+	userID := uuid.New()
+	f.create(t, userID, "alice")
+	ctx := context.Background()
+
+	// 두 요청이 모두 version 1 을 보고 수정 → 먼저 온 요청만 반영
+	updated, err := f.svc.UpdateProfile(ctx, userID, &dto.ProfileUpdate{UserID: userID, Bio: ptr("first"), ExpectedVersion: ptr(int32(1))})
+	if err != nil || updated.Version != 2 {
+		t.Fatalf("first UpdateProfile = %+v, %v", updated, err)
+	}
+
+	_, err = f.svc.UpdateProfile(ctx, userID, &dto.ProfileUpdate{UserID: userID, Bio: ptr("second"), ExpectedVersion: ptr(int32(1))})
+
+	var codeErr *planet_err.CodeError
+	if !errors.As(err, &codeErr) || codeErr.Code != planet_err.CodeConflict {
+		t.Fatalf("err = %v, want CONFLICT", err)
+	}
+	if !errors.Is(err, planet_err.ErrVersionConflict) {
+		t.Fatalf("err = %v, want wrapping ErrVersionConflict", err)
+	}
+	current, ok := codeErr.Data["current"].(*dto.UserProfile)
+	if !ok || current.Bio != "first" || current.Version != 2 {
+		t.Fatalf("current = %#v, want first v2", codeErr.Data["current"])
+	}
+
+	stored, err := f.profiles.GetMyProfileInfo(ctx, userID)
+	if err != nil || stored.Bio != "first" || stored.Version != 2 {
+		t.Fatalf("stored = %+v, %v, want unchanged by stale update", stored, err)
+	}
+}
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"github.com/rainbow96bear/planet_user_server/internal/metrics"
 	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
 	"github.com/rainbow96bear/planet_user_server/internal/repository"
-	"github.com/rainbow96bear/planet_utils/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +22,7 @@ type TodoServiceInterface interface {
 		userID uuid.UUID,
 		todoID uuid.UUID,
 		isDone bool,
+		expectedVersion *int32,
 	) (*models.Todo, error)
 	FindByID(
 		ctx context.Context,
@@ -29,14 +34,16 @@ type TodoServiceInterface interface {
 type TodoService struct {
 	db *gorm.DB
 	// CalendarEventsRepo를 통해 Todo 테이블에 접근합니다.
-	TodosRepo *repository.TodosRepository
+	TodosRepo repository.TodosRepositoryInterface
+	Hub       *pubsub.Hub // 커밋 이후 변경 알림 발행 (GraphQL Subscription)
 }
 
 // NewTodoService: TodoService를 생성합니다.
-func NewTodoService(db *gorm.DB, todosRepo *repository.TodosRepository) *TodoService {
+func NewTodoService(db *gorm.DB, todosRepo repository.TodosRepositoryInterface, hub *pubsub.Hub) *TodoService {
 	return &TodoService{
 		db:        db,
 		TodosRepo: todosRepo,
+		Hub:       hub,
 	}
 }
 
@@ -45,15 +52,17 @@ func NewTodoService(db *gorm.DB, todosRepo *repository.TodosRepository) *TodoSer
 // // ----------------------------
 
 // UpdateTodoStatus: 특정 Todo 항목의 isDone 상태를 업데이트하고, 관련된 Event 캐시를 무효화합니다.
+// expectedVersion 이 맞지 않으면 서버 최신본을 담은 CONFLICT 에러를 반환합니다.
 // 💡 이 함수는 Handler에서 직접 호출됩니다.
 func (s *TodoService) UpdateTodoStatus(
 	ctx context.Context,
 	userID uuid.UUID,
 	todoID uuid.UUID,
 	isDone bool,
+	expectedVersion *int32,
 ) (*models.Todo, error) {
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[TodoService.UpdateTodoStatus] user=%s todo=%s done=%t",
 		userID, todoID, isDone,
 	)
@@ -64,11 +73,27 @@ func (s *TodoService) UpdateTodoStatus(
 		userID,
 		todoID,
 		isDone,
+		expectedVersion,
 	)
 	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, planet_err.NewConflictError(todo)
+		}
 		return nil, err
 	}
 
+	if isDone {
+		metrics.TodosCompleted.Inc()
+	}
+
+	s.Hub.PublishTodoChange(pubsub.TodoChange{
+		Action:  pubsub.ActionUpdated,
+		UserID:  userID,
+		EventID: todo.CalendarEventID,
+		TodoID:  todo.ID,
+		Todo:    todo,
+	})
+
 	return todo, nil
 }
 
@@ -82,7 +107,7 @@ func (s *TodoService) FindByID(
 	todoID uuid.UUID,
 ) (*models.Todo, error) {
 
-	logger.Infof(
+	logctx.Infof(ctx,
 		"[TodoService.FindByID] UserID=%s TodoID=%s",
 		userID, todoID,
 	)
@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/planet_err"
+	"github.com/rainbow96bear/planet_user_server/internal/pubsub"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/memory"
+	"github.com/rainbow96bear/planet_user_server/internal/service"
+)
+
+func TestUpdateTodoStatusVersionConflictReturnsCurrent(t *testing.T) {
+	store := memory.NewStore()
+	svc := service.NewTodoService(nil, memory.NewTodosRepository(store), pubsub.NewHub())
+	ctx := context.Background()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+	startAt := time.Date(2025, 3, 14, 18, 0, 0, 0, time.UTC)
+	event, err := memory.NewCalendarEventsRepository(store).CreateCalendarEvent(ctx, &models.CalendarEvent{
+		ID:         eventID,
+		UserID:     userID,
+		Title:      "dinner",
+		StartAt:    startAt,
+		EndAt:      startAt.Add(2 * time.Hour),
+		Visibility: "public",
+		Todos:      []models.Todo{{ID: uuid.New(), CalendarEventID: eventID, Content: "book a table"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCalendarEvent: %v", err)
+	}
+	todoID := event.Todos[0].ID
+
+	if _, err := svc.UpdateTodoStatus(ctx, userID, todoID, true, ptr(int32(1))); err != nil {
+		t.Fatalf("first UpdateTodoStatus: %v", err)
+	}
+
+	_, err = svc.UpdateTodoStatus(ctx, userID, todoID, false, ptr(int32(1)))
+
+	var codeErr *planet_err.CodeError
+	if !errors.As(err, &codeErr) || codeErr.Code != planet_err.CodeConflict || !errors.Is(err, planet_err.ErrVersionConflict) {
+		t.Fatalf("err = %v, want CONFLICT", err)
+	}
+	current, ok := codeErr.Data["current"].(*models.Todo)
+	if !ok || !current.IsDone || current.Version != 2 {
+		t.Fatalf("current = %#v, want done v2", codeErr.Data["current"])
+	}
+}
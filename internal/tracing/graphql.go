@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GraphQLExtension: operation 과 resolver field 마다 span 을 만듭니다.
+// FieldDepth 보다 깊은 field 는 span 을 만들지 않습니다. (0 이면 operation span 만)
+type GraphQLExtension struct {
+	FieldDepth int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = GraphQLExtension{}
+
+func (GraphQLExtension) ExtensionName() string {
+	return "OpenTelemetryTracing"
+}
+
+func (GraphQLExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e GraphQLExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	operation := oc.OperationName
+	if operation == "" {
+		operation = "anonymous"
+	}
+	opType := "unknown"
+	if oc.Operation != nil {
+		opType = string(oc.Operation.Operation)
+	}
+
+	ctx, span := Tracer().Start(ctx, "graphql."+opType+" "+operation,
+		trace.WithAttributes(
+			attribute.String("graphql.operation.name", operation),
+			attribute.String("graphql.operation.type", opType),
+		),
+	)
+	defer span.End()
+
+	resp := next(ctx)
+	if resp != nil && len(resp.Errors) > 0 {
+		span.SetStatus(codes.Error, resp.Errors.Error())
+	}
+	return resp
+}
+
+func (e GraphQLExtension) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver || fieldDepth(fc) > e.FieldDepth {
+		return next(ctx)
+	}
+
+	ctx, span := Tracer().Start(ctx, "graphql.resolve "+fc.Object+"."+fc.Field.Name,
+		trace.WithAttributes(
+			attribute.String("graphql.field.path", fc.Path().String()),
+		),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return res, err
+}
+
+// fieldDepth: 리스트 인덱스를 제외한 field 경로 깊이 (Query.myProfile = 1)
+func fieldDepth(fc *graphql.FieldContext) int {
+	depth := 0
+	for _, p := range fc.Path() {
+		if _, isIndex := p.(ast.PathIndex); !isIndex {
+			depth++
+		}
+	}
+	return depth
+}
@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rainbow96bear/planet_utils/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName: 리소스 / 계측 라이브러리 이름
+const ServiceName = "planet_user_server"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout" // collector 없이 로컬에서 확인
+	ExporterOTLP   = "otlp"   // OTLP/gRPC collector
+)
+
+type Config struct {
+	Exporter     string
+	OTLPEndpoint string // 비어 있으면 OTEL_EXPORTER_OTLP_ENDPOINT 등 표준 환경 변수 사용
+	SampleRatio  float64
+	Version      string
+}
+
+// Tracer: 서비스 내부에서 직접 span 을 만들 때 사용합니다.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// Init: 전역 TracerProvider 와 W3C trace-context 전파기를 설정합니다.
+// 반환된 shutdown 은 남은 span 을 flush 하므로 종료 시 반드시 호출해야 합니다.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		logger.Infof("[Tracing] disabled")
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		exporter = exp
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{}
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint), otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		exporter = exp
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Infof("[Tracing] enabled exporter=%s sampleRatio=%.2f", cfg.Exporter, cfg.SampleRatio)
+	return provider.Shutdown, nil
+}
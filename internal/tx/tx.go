@@ -23,13 +23,3 @@ func GetTx(ctx context.Context) *gorm.DB {
 	}
 	return nil // 트랜잭션이 Context에 없을 경우
 }
-
-// BeginTx는 DB에서 새 트랜잭션을 시작하고 Context에 담아 반환합니다.
-func BeginTx(ctx context.Context, db *gorm.DB) (*gorm.DB, context.Context, error) {
-	tx := db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return nil, nil, tx.Error
-	}
-	newCtx := WithTx(ctx, tx)
-	return tx, newCtx, nil
-}
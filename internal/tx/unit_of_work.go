@@ -0,0 +1,202 @@
+package tx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxRetries = 3
+	baseRetryBackoff  = 20 * time.Millisecond
+)
+
+// PostgreSQL SQLSTATE: 트랜잭션 전체를 다시 실행하면 성공할 수 있는 오류
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+type options struct {
+	isolation  sql.IsolationLevel
+	maxRetries int
+}
+
+// Option: RunInTx 동작을 바꿉니다. 중첩 호출(savepoint)에서는 무시됩니다.
+type Option func(*options)
+
+// WithIsolation: 트랜잭션 격리 수준을 지정합니다. (기본: DB 기본값, PostgreSQL 은 READ COMMITTED)
+func WithIsolation(level sql.IsolationLevel) Option {
+	return func(o *options) { o.isolation = level }
+}
+
+// WithMaxRetries: serialization failure / deadlock 시 재시도 횟수를 지정합니다. (기본 3, 0 이면 재시도 없음)
+func WithMaxRetries(n int) Option {
+	return func(o *options) { o.maxRetries = n }
+}
+
+// unitOfWork: 가장 바깥 트랜잭션 하나에 대한 상태. 중첩 호출은 같은 unitOfWork 를 공유합니다.
+type unitOfWork struct {
+	depth       int
+	afterCommit []func()
+}
+
+type unitOfWorkKey struct{}
+
+// RunInTx: fn 을 하나의 트랜잭션 안에서 실행합니다.
+//
+//   - fn 이 에러를 반환하거나 panic 하면 롤백하고, 성공하면 커밋합니다.
+//   - fn 에 전달되는 ctx 에는 트랜잭션이 담겨 있어 repository 가 그대로 사용합니다.
+//   - ctx 에 이미 트랜잭션이 있으면 savepoint 로 중첩됩니다. 중첩된 fn 이 실패하면 savepoint 까지만 되돌리고
+//     에러를 그대로 반환합니다. (바깥 트랜잭션을 계속할지는 호출자가 결정)
+//   - serialization failure / deadlock 이면 가장 바깥 트랜잭션 전체를 다시 실행하므로 fn 은 재실행해도 안전해야 합니다.
+//   - AfterCommit 으로 등록한 hook 은 가장 바깥 트랜잭션이 커밋된 뒤에만 실행됩니다.
+//   - db 가 nil 이면 (in-memory repository 로 구성한 테스트) 트랜잭션 없이 fn 을 실행하고, 성공하면 hook 을 실행합니다.
+func RunInTx(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error, opts ...Option) error {
+	if db == nil && GetTx(ctx) == nil {
+		return runWithoutTx(ctx, fn)
+	}
+
+	if parent := GetTx(ctx); parent != nil {
+		if uow, ok := ctx.Value(unitOfWorkKey{}).(*unitOfWork); ok {
+			return runNested(ctx, parent, uow, fn)
+		}
+		// tx.WithTx 로 직접 담은 트랜잭션: savepoint 만 사용하고 AfterCommit hook 은 바로 실행됩니다.
+		return runNested(ctx, parent, nil, fn)
+	}
+
+	o := options{isolation: sql.LevelDefault, maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for attempt := 0; ; attempt++ {
+		uow := &unitOfWork{}
+		err := runOnce(ctx, db, uow, &o, fn)
+		if err == nil {
+			for _, hook := range uow.afterCommit {
+				hook()
+			}
+			return nil
+		}
+
+		if !IsRetryable(err) || attempt >= o.maxRetries {
+			return err
+		}
+
+		backoff := baseRetryBackoff << attempt
+		backoff += rand.N(backoff)
+		logctx.Warnf(ctx, "[Tx] retrying transaction (attempt %d/%d) after %s: %v", attempt+1, o.maxRetries, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func runOnce(ctx context.Context, db *gorm.DB, uow *unitOfWork, o *options, fn func(ctx context.Context) error) (err error) {
+	txDB := db.WithContext(ctx).Begin(&sql.TxOptions{Isolation: o.isolation})
+	if txDB.Error != nil {
+		return fmt.Errorf("failed to start transaction: %w", txDB.Error)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if p := recover(); p != nil {
+			txDB.Rollback()
+			panic(p)
+		}
+		txDB.Rollback()
+	}()
+
+	txCtx := context.WithValue(WithTx(ctx, txDB), unitOfWorkKey{}, uow)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	if err := txDB.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// runWithoutTx: 롤백할 수 없으므로 fn 이 실패해도 이미 반영된 변경은 남습니다. 등록된 hook 만 버립니다.
+func runWithoutTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(unitOfWorkKey{}).(*unitOfWork); ok {
+		return fn(ctx)
+	}
+
+	uow := &unitOfWork{}
+	if err := fn(context.WithValue(ctx, unitOfWorkKey{}, uow)); err != nil {
+		return err
+	}
+	for _, hook := range uow.afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// runNested: uow 가 nil 이면 hook 관리 없이 savepoint 만 사용합니다.
+func runNested(ctx context.Context, parent *gorm.DB, uow *unitOfWork, fn func(ctx context.Context) error) (err error) {
+	if uow == nil {
+		uow = &unitOfWork{}
+	} else {
+		ctx = context.WithValue(ctx, unitOfWorkKey{}, uow)
+	}
+	name := fmt.Sprintf("sp_%d", uow.depth+1)
+	if err := parent.SavePoint(name).Error; err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	uow.depth++
+	hooks := len(uow.afterCommit)
+
+	defer func() {
+		uow.depth--
+		p := recover()
+		if p == nil && err == nil {
+			return
+		}
+		// 실패한 중첩 작업이 등록한 hook 은 버립니다.
+		uow.afterCommit = uow.afterCommit[:hooks]
+		if rbErr := parent.RollbackTo(name).Error; rbErr != nil && err != nil {
+			err = errors.Join(err, fmt.Errorf("failed to rollback to savepoint: %w", rbErr))
+		}
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// AfterCommit: 현재 트랜잭션이 커밋된 뒤 실행할 hook 을 등록합니다. (캐시 무효화, 알림 발행 등)
+// 롤백되면 실행되지 않으며, RunInTx 밖에서 호출하면 바로 실행합니다.
+func AfterCommit(ctx context.Context, hook func()) {
+	if uow, ok := ctx.Value(unitOfWorkKey{}).(*unitOfWork); ok {
+		uow.afterCommit = append(uow.afterCommit, hook)
+		return
+	}
+	hook()
+}
+
+// IsRetryable: 트랜잭션을 처음부터 다시 실행하면 성공할 수 있는 오류인지 확인합니다.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+}
@@ -0,0 +1,385 @@
+package tx_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rainbow96bear/planet_user_server/internal/models"
+	"github.com/rainbow96bear/planet_user_server/internal/repository"
+	"github.com/rainbow96bear/planet_user_server/internal/repository/repositorytest"
+	"github.com/rainbow96bear/planet_user_server/internal/tx"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errFn = errors.New("fn failed")
+
+func TestRunInTxNestedRollbackKeepsOuter(t *testing.T) {
+	db, rec := openRecorder(t)
+	var hooks []string
+
+	err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+		tx.AfterCommit(ctx, func() { hooks = append(hooks, "outer") })
+
+		nestedErr := tx.RunInTx(ctx, db, func(ctx context.Context) error {
+			tx.AfterCommit(ctx, func() { hooks = append(hooks, "failed nested") })
+			return errFn
+		})
+		if !errors.Is(nestedErr, errFn) {
+			t.Errorf("nested err = %v, want errFn", nestedErr)
+		}
+
+		return tx.RunInTx(ctx, db, func(ctx context.Context) error {
+			tx.AfterCommit(ctx, func() { hooks = append(hooks, "nested") })
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+
+	rec.expect(t,
+		"BEGIN",
+		"SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1",
+		"SAVEPOINT sp_1",
+		"COMMIT",
+	)
+	if want := []string{"outer", "nested"}; !slices.Equal(hooks, want) {
+		t.Fatalf("hooks = %v, want %v", hooks, want)
+	}
+}
+
+func TestRunInTxPanicRollsBack(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(db *gorm.DB) func(ctx context.Context) error
+		want []string
+	}{
+		{
+			name: "outer",
+			fn: func(*gorm.DB) func(ctx context.Context) error {
+				return func(context.Context) error { panic("boom") }
+			},
+			want: []string{"BEGIN", "ROLLBACK"},
+		},
+		{
+			name: "nested",
+			fn: func(db *gorm.DB) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return tx.RunInTx(ctx, db, func(context.Context) error { panic("boom") })
+				}
+			},
+			want: []string{"BEGIN", "SAVEPOINT sp_1", "ROLLBACK TO SAVEPOINT sp_1", "ROLLBACK"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := openRecorder(t)
+			hookRan := false
+
+			func() {
+				defer func() {
+					if p := recover(); p != "boom" {
+						t.Fatalf("recovered %v, want re-panic", p)
+					}
+				}()
+				tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+					tx.AfterCommit(ctx, func() { hookRan = true })
+					return tt.fn(db)(ctx)
+				})
+			}()
+
+			rec.expect(t, tt.want...)
+			if hookRan {
+				t.Fatal("AfterCommit hook ran after panic")
+			}
+		})
+	}
+}
+
+func TestRunInTxRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		commitErr error
+		opts      []tx.Option
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "serialization failure", commitErr: &pgconn.PgError{Code: "40001"}, wantCalls: 2},
+		{name: "deadlock", commitErr: &pgconn.PgError{Code: "40P01"}, wantCalls: 2},
+		{name: "unique violation", commitErr: &pgconn.PgError{Code: "23505"}, wantCalls: 1, wantErr: true},
+		{name: "other error", commitErr: errors.New("connection reset"), wantCalls: 1, wantErr: true},
+		{name: "retries disabled", commitErr: &pgconn.PgError{Code: "40001"}, opts: []tx.Option{tx.WithMaxRetries(0)}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := openRecorder(t)
+			rec.commitErrs = []error{tt.commitErr}
+			calls, hooks := 0, 0
+
+			err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+				calls++
+				tx.AfterCommit(ctx, func() { hooks++ })
+				return nil
+			}, tt.opts...)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Fatalf("fn ran %d times, want %d", calls, tt.wantCalls)
+			}
+			// 실패한 시도에서 등록한 hook 은 버리고, 커밋된 시도의 hook 만 한 번 실행합니다.
+			wantHooks := 1
+			if tt.wantErr {
+				wantHooks = 0
+			}
+			if hooks != wantHooks {
+				t.Fatalf("hooks ran %d times, want %d", hooks, wantHooks)
+			}
+		})
+	}
+}
+
+func TestRunInTxRetriesFnError(t *testing.T) {
+	db, rec := openRecorder(t)
+	calls := 0
+
+	err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("update: %w", &pgconn.PgError{Code: "40001"})
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("err = %v, calls = %d, want nil after 2 calls", err, calls)
+	}
+	rec.expect(t, "BEGIN", "ROLLBACK", "BEGIN", "COMMIT")
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("skipped on rollback", func(t *testing.T) {
+		db, rec := openRecorder(t)
+		hookRan := false
+
+		err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+			nestedErr := tx.RunInTx(ctx, db, func(ctx context.Context) error {
+				tx.AfterCommit(ctx, func() { hookRan = true })
+				return nil
+			})
+			if nestedErr != nil {
+				return nestedErr
+			}
+			return errFn // 중첩은 성공했지만 바깥이 롤백
+		})
+		if !errors.Is(err, errFn) {
+			t.Fatalf("err = %v, want errFn", err)
+		}
+		rec.expect(t, "BEGIN", "SAVEPOINT sp_1", "ROLLBACK")
+		if hookRan {
+			t.Fatal("AfterCommit hook ran after rollback")
+		}
+	})
+
+	t.Run("runs once after outermost commit", func(t *testing.T) {
+		db, _ := openRecorder(t)
+		ran := 0
+
+		err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+			err := tx.RunInTx(ctx, db, func(ctx context.Context) error {
+				return tx.RunInTx(ctx, db, func(ctx context.Context) error {
+					tx.AfterCommit(ctx, func() { ran++ })
+					return nil
+				})
+			})
+			if ran != 0 {
+				t.Errorf("hook ran before the outermost commit")
+			}
+			return err
+		})
+		if err != nil || ran != 1 {
+			t.Fatalf("err = %v, hook ran %d times, want once", err, ran)
+		}
+	})
+
+	t.Run("outside transaction runs immediately", func(t *testing.T) {
+		ran := false
+		tx.AfterCommit(context.Background(), func() { ran = true })
+		if !ran {
+			t.Fatal("hook did not run")
+		}
+	})
+}
+
+func TestWithIsolation(t *testing.T) {
+	db, rec := openRecorder(t)
+
+	err := tx.RunInTx(context.Background(), db, func(context.Context) error { return nil },
+		tx.WithIsolation(sql.LevelSerializable))
+	if err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+	rec.expect(t, "BEGIN Serializable", "COMMIT")
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&pgconn.PgError{Code: "40001"}, true},
+		{&pgconn.PgError{Code: "40P01"}, true},
+		{fmt.Errorf("failed to commit transaction: %w", &pgconn.PgError{Code: "40001"}), true},
+		{&pgconn.PgError{Code: "23505"}, false},
+		{errors.New("40001"), false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := tx.IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestRunInTxSavepointPostgres: 실패한 중첩 작업의 INSERT 만 되돌리고 바깥 트랜잭션의 INSERT 는 커밋합니다.
+func TestRunInTxSavepointPostgres(t *testing.T) {
+	db := repositorytest.OpenPostgres(t)
+	repositorytest.Truncate(t, db, "profiles")
+
+	profiles := repository.NewProfilesRepository(db)
+	outer := &models.Profile{UserID: uuid.New(), Nickname: "outer"}
+	nested := &models.Profile{UserID: uuid.New(), Nickname: "nested"}
+
+	err := tx.RunInTx(context.Background(), db, func(ctx context.Context) error {
+		if err := profiles.Create(ctx, outer); err != nil {
+			return err
+		}
+		nestedErr := tx.RunInTx(ctx, db, func(ctx context.Context) error {
+			if err := profiles.Create(ctx, nested); err != nil {
+				return err
+			}
+			return errFn
+		})
+		if !errors.Is(nestedErr, errFn) {
+			return fmt.Errorf("nested err = %v, want errFn", nestedErr)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+
+	found, err := profiles.FindByUserIDs(context.Background(), []uuid.UUID{outer.UserID, nested.UserID})
+	if err != nil {
+		t.Fatalf("find profiles: %v", err)
+	}
+	if len(found) != 1 || found[0].UserID != outer.UserID {
+		t.Fatalf("profiles = %v, want only the outer one", found)
+	}
+}
+
+// -------------------------
+// 실행된 트랜잭션 명령을 기록하는 database/sql 드라이버
+// -------------------------
+
+type recorder struct {
+	mu         sync.Mutex
+	log        []string
+	commitErrs []error // Commit 마다 앞에서부터 하나씩 반환
+}
+
+func openRecorder(t *testing.T) (*gorm.DB, *recorder) {
+	t.Helper()
+
+	rec := &recorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(rec)}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open recorder: %v", err)
+	}
+	return db, rec
+}
+
+func (r *recorder) record(stmt string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log = append(r.log, stmt)
+}
+
+func (r *recorder) expect(t *testing.T, want ...string) {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !slices.Equal(r.log, want) {
+		t.Fatalf("statements = %q, want %q", r.log, want)
+	}
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recordConn{r: r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return r }
+func (r *recorder) Open(string) (driver.Conn, error)             { return &recordConn{r: r}, nil }
+
+type recordConn struct {
+	r *recorder
+}
+
+func (c *recordConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported: %s", query)
+}
+
+func (c *recordConn) Close() error { return nil }
+
+func (c *recordConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if level := sql.IsolationLevel(opts.Isolation); level != sql.LevelDefault {
+		c.r.record("BEGIN " + level.String())
+	} else {
+		c.r.record("BEGIN")
+	}
+	return &recordTx{r: c.r}, nil
+}
+
+func (c *recordConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.r.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+type recordTx struct {
+	r *recorder
+}
+
+func (tx *recordTx) Commit() error {
+	tx.r.record("COMMIT")
+
+	tx.r.mu.Lock()
+	defer tx.r.mu.Unlock()
+	if len(tx.r.commitErrs) == 0 {
+		return nil
+	}
+	err := tx.r.commitErrs[0]
+	tx.r.commitErrs = tx.r.commitErrs[1:]
+	return err
+}
+
+func (tx *recordTx) Rollback() error {
+	tx.r.record("ROLLBACK")
+	return nil
+}
@@ -10,19 +10,35 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
 )
 
 type contextKey string
 
 const ContextKeyAccessToken contextKey = "access_token"
+const ContextKeyIdempotencyKey contextKey = "idempotency_key"
+const ContextKeyClientIP contextKey = "client_ip"
+
+// IdempotencyKeyHeader: 클라이언트 재시도 시 같은 값을 보내면 원래 응답을 그대로 돌려받습니다.
+const IdempotencyKeyHeader = "Idempotency-Key"
 
 var (
 	ErrAuthorizationMissing = errors.New("authorization header missing")
 	ErrMalformedToken       = errors.New("malformed authorization token")
+	ErrUnauthorized         = errors.New("unauthorized") // 서명 / 만료 검증 실패
 )
 
+// accessToken: Context 에 담기는 검증 결과. 토큰이 있었지만 검증에 실패하면 err 만 채워집니다.
+type accessToken struct {
+	token *jwt.Token
+	err   error
+}
+
 // 🚨 Gin 전용 미들웨어 함수: gin.HandlerFunc를 반환합니다.
-func AuthMiddleware() gin.HandlerFunc {
+// secret (auth.jwt_secret_key) 으로 HS256 서명과 만료를 검증한 토큰만 resolver 에 전달됩니다.
+// /graphql 의 모든 query / mutation 에 적용되며, 서명이 틀리거나 만료된 토큰은 비로그인이 아니라
+// unauthorized 로 처리됩니다. (이전에는 서명 없이 sub 만 읽었습니다.)
+func AuthMiddleware(secret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		token := ""
@@ -31,8 +47,9 @@ func AuthMiddleware() gin.HandlerFunc {
 			token = strings.TrimPrefix(authHeader, "Bearer ")
 		}
 
-		// Context를 복사하고 토큰 값을 주입
-		ctx := context.WithValue(c.Request.Context(), ContextKeyAccessToken, token)
+		// Context를 복사하고 검증한 토큰을 주입
+		ctx := WithAccessToken(c.Request.Context(), token, secret)
+		ctx = context.WithValue(ctx, ContextKeyClientIP, c.ClientIP()) // 비로그인 요청의 rate limit 키
 
 		// 업데이트된 Context로 요청 객체 대체
 		c.Request = c.Request.WithContext(ctx)
@@ -42,25 +59,88 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
-func ExtractAccessToken(ctx context.Context) (*jwt.Token, error) {
-	accessToken, ok := ctx.Value(ContextKeyAccessToken).(string)
-
-	if !ok || accessToken == "" {
-		return nil, ErrAuthorizationMissing
+// WithAccessToken: 토큰을 검증해 Context 에 주입합니다. HTTP 헤더가 없는 경로(예: websocket connection_init)에서도 사용합니다.
+// token 이 비어 있으면 비로그인 요청으로 취급합니다.
+func WithAccessToken(ctx context.Context, token string, secret string) context.Context {
+	token = strings.TrimPrefix(token, "Bearer ")
+	if token == "" {
+		return ctx
 	}
 
-	tokenString := strings.TrimPrefix(accessToken, "Bearer ")
-
-	if tokenString == "" {
-		tokenString = accessToken
+	v := accessToken{}
+	if parsed, err := ParseAccessToken(token, secret); err != nil {
+		v.err = ErrUnauthorized // 실패 이유(서명 / 만료 / 형식)는 클라이언트에 노출하지 않습니다.
+	} else {
+		v.token = parsed
 	}
+	ctx = context.WithValue(ctx, ContextKeyAccessToken, v)
+	setLogUserID(ctx)
+	return ctx
+}
 
-	if tokenString == "" {
-		return nil, ErrMalformedToken
+// ParseAccessToken: HS256 서명과 exp 를 검증합니다. (exp 가 없는 토큰도 거절)
+func ParseAccessToken(tokenString string, secret string) (*jwt.Token, error) {
+	if secret == "" {
+		return nil, ErrUnauthorized
 	}
-	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+
+	token, err := jwt.Parse(tokenString, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
 	}
 	return token, nil
 }
+
+// setLogUserID: 요청 로그에 user_id 를 남깁니다. (검증된 토큰의 sub 만, 토큰 자체는 기록하지 않음)
+func setLogUserID(ctx context.Context) {
+	token, err := ExtractAccessToken(ctx)
+	if err != nil {
+		return
+	}
+	if sub, err := token.Claims.GetSubject(); err == nil && sub != "" {
+		logctx.SetUserID(ctx, sub)
+	}
+}
+
+// ExtractAccessToken: AuthMiddleware / WithAccessToken 이 검증한 토큰을 반환합니다.
+// 토큰이 없으면 ErrAuthorizationMissing, 검증에 실패했으면 ErrUnauthorized 입니다.
+func ExtractAccessToken(ctx context.Context) (*jwt.Token, error) {
+	v, ok := ctx.Value(ContextKeyAccessToken).(accessToken)
+	if !ok {
+		return nil, ErrAuthorizationMissing
+	}
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v.token, nil
+}
+
+// ExtractClientIP: AuthMiddleware 를 거치지 않은 요청이면 빈 문자열을 반환합니다.
+func ExtractClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(ContextKeyClientIP).(string)
+	return ip
+}
+
+// IdempotencyKeyMiddleware: Idempotency-Key 헤더를 Context 에 주입합니다.
+func IdempotencyKeyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key != "" {
+			ctx := context.WithValue(c.Request.Context(), ContextKeyIdempotencyKey, key)
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
+
+// ExtractIdempotencyKey: 헤더가 없으면 빈 문자열을 반환합니다.
+func ExtractIdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(ContextKeyIdempotencyKey).(string)
+	return key
+}
@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rainbow96bear/planet_user_server/middleware"
+)
+
+const secret = "test-secret"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func claims(exp time.Time) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "00000000-0000-0000-0000-00000000000a",
+		ExpiresAt: jwt.NewNumericDate(exp),
+	}
+}
+
+func TestWithAccessToken(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			token: sign(t, jwt.SigningMethodHS256, []byte(secret), claims(future)),
+		},
+		{
+			name:  "bearer prefix",
+			token: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(secret), claims(future)),
+		},
+		{
+			name:    "missing",
+			token:   "",
+			wantErr: middleware.ErrAuthorizationMissing,
+		},
+		{
+			name:    "wrong key",
+			token:   sign(t, jwt.SigningMethodHS256, []byte("forged"), claims(future)),
+			wantErr: middleware.ErrUnauthorized,
+		},
+		{
+			name:    "unsigned (alg none)",
+			token:   sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(future)),
+			wantErr: middleware.ErrUnauthorized,
+		},
+		{
+			name:    "other algorithm",
+			token:   sign(t, jwt.SigningMethodHS512, []byte(secret), claims(future)),
+			wantErr: middleware.ErrUnauthorized,
+		},
+		{
+			name:    "expired",
+			token:   sign(t, jwt.SigningMethodHS256, []byte(secret), claims(time.Now().Add(-time.Minute))),
+			wantErr: middleware.ErrUnauthorized,
+		},
+		{
+			name:    "without exp",
+			token:   sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.RegisteredClaims{Subject: "user"}),
+			wantErr: middleware.ErrUnauthorized,
+		},
+		{
+			name:    "malformed",
+			token:   "not-a-jwt",
+			wantErr: middleware.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := middleware.WithAccessToken(context.Background(), tt.token, secret)
+
+			token, err := middleware.ExtractAccessToken(ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if token != nil {
+					t.Fatalf("token = %v, want nil", token)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if sub, _ := token.Claims.GetSubject(); sub != "00000000-0000-0000-0000-00000000000a" {
+				t.Fatalf("sub = %q", sub)
+			}
+		})
+	}
+}
+
+func TestParseAccessTokenWithoutSecret(t *testing.T) {
+	token := sign(t, jwt.SigningMethodHS256, []byte(""), claims(time.Now().Add(time.Hour)))
+	if _, err := middleware.ParseAccessToken(token, ""); !errors.Is(err, middleware.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rainbow96bear/planet_user_server/internal/logctx"
+)
+
+// maxRequestIDLength: 클라이언트가 보낸 X-Request-ID 의 최대 길이 (로그 오염 방지)
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware: X-Request-ID 를 받거나 새로 만들고, 요청 종료 시 JSON 접근 로그를 남깁니다.
+// 가장 먼저 등록해야 이후 미들웨어 / 핸들러 로그에 request_id 가 붙습니다.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(logctx.RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx := logctx.NewContext(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(logctx.RequestIDHeader, requestID)
+
+		c.Next()
+
+		// 쿼리 문자열은 토큰이 섞일 수 있어 path 만 기록
+		logctx.Logger(ctx).Info("http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		)
+	}
+}
+
+// validRequestID: 출력 가능한 ASCII 만 허용합니다.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}